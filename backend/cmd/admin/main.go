@@ -0,0 +1,150 @@
+// Command admin runs administrative tasks against the configured database.
+//
+//	go run ./cmd/admin backup [-dir ./data/backups] [-compress=true]
+//	go run ./cmd/admin restore -from ./data/backups/backup-....db.gz
+//
+// Both commands read the same configuration as the server (-config or
+// CONFIG_FILE plus environment). Backups can be taken while the server is
+// running; restores must be run with the server stopped.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/keel/api/internal/backup"
+	"github.com/keel/api/internal/config"
+	"github.com/keel/api/internal/database"
+	"github.com/keel/api/migrations"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "backup":
+		err = runBackup(os.Args[2:])
+	case "restore":
+		err = runRestore(os.Args[2:])
+	case "-h", "-help", "--help", "help":
+		usage()
+		return
+	default:
+		fmt.Printf("Error: unknown command %q\n\n", os.Args[1])
+		usage()
+		os.Exit(1)
+	}
+
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Println(`Usage: admin <command> [flags]
+
+Commands:
+  backup   Take a verified snapshot of the SQLite database
+  restore  Replace the SQLite database with a snapshot (server must be stopped)
+
+Run "admin <command> -h" for command flags.`)
+}
+
+func runBackup(args []string) error {
+	fs := flag.NewFlagSet("backup", flag.ExitOnError)
+	configPath := fs.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML config file")
+	dir := fs.String("dir", "", "directory to write the snapshot to (default: backup.dir from config)")
+	compress := fs.Bool("compress", false, "gzip the snapshot (default: backup.compress from config)")
+	_ = fs.Parse(args)
+
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+
+	cfg, dbPath, err := loadSQLite(*configPath)
+	if err != nil {
+		return err
+	}
+
+	opts := backup.Options{Compress: cfg.Backup.Compress}
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "compress" {
+			opts.Compress = *compress
+		}
+	})
+	if *dir == "" {
+		*dir = cfg.Backup.Dir
+	}
+
+	result, err := backup.Snapshot(context.Background(), dbPath, *dir, opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Backup created: %s (%d bytes, schema version %d)\n", result.Path, result.Size, result.SchemaVersion)
+	return nil
+}
+
+func runRestore(args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	configPath := fs.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML config file")
+	from := fs.String("from", "", "snapshot file to restore (.db or .db.gz)")
+	_ = fs.Parse(args)
+
+	if *from == "" {
+		fs.Usage()
+		return fmt.Errorf("-from is required")
+	}
+
+	_, dbPath, err := loadSQLite(*configPath)
+	if err != nil {
+		return err
+	}
+
+	latest, err := database.LatestVersion(migrations.FS, string(database.SQLite))
+	if err != nil {
+		return err
+	}
+
+	result, err := backup.Restore(context.Background(), *from, dbPath, latest)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Restored %s to %s (schema version %d)\n", *from, result.Path, result.SchemaVersion)
+	if result.SchemaVersion < latest {
+		fmt.Printf("Pending migrations up to version %d will run on next server start.\n", latest)
+	}
+	return nil
+}
+
+// loadSQLite loads the configuration and returns the path of its SQLite
+// database file.
+func loadSQLite(configPath string) (*config.Config, string, error) {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	dialect, err := database.DialectFromURL(cfg.Database.URL)
+	if err != nil {
+		return nil, "", err
+	}
+	if dialect != database.SQLite {
+		return nil, "", fmt.Errorf("backup and restore support SQLite only; use pg_dump and pg_restore for %s", dialect)
+	}
+
+	dbPath, err := database.SQLitePath(cfg.Database.URL)
+	if err != nil {
+		return nil, "", err
+	}
+	return cfg, dbPath, nil
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+// UnifiedDiff returns a unified diff from old to new, labelled with path,
+// or "" if they are equal. It compares lines with a longest common
+// subsequence, which is fine for source files of a few thousand lines.
+func UnifiedDiff(path string, old, new []byte) string {
+	a, b := splitLines(string(old)), splitLines(string(new))
+	ops := diffLines(a, b)
+
+	var out strings.Builder
+	for start := 0; start < len(ops); {
+		// Find the next change and the run of ops around it.
+		for start < len(ops) && ops[start].kind == ' ' {
+			start++
+		}
+		if start == len(ops) {
+			break
+		}
+		from := max(0, start-diffContext)
+		end, gap := start, 0
+		for end < len(ops) && gap <= 2*diffContext {
+			if ops[end].kind == ' ' {
+				gap++
+			} else {
+				gap = 0
+			}
+			end++
+		}
+		end -= max(0, gap-diffContext)
+
+		if out.Len() == 0 {
+			oldName, newName := "a/"+path, "b/"+path
+			if len(old) == 0 {
+				oldName = "/dev/null"
+			}
+			if len(new) == 0 {
+				newName = "/dev/null"
+			}
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", oldName, newName)
+		}
+		hunk := ops[from:end]
+		aStart, bStart := ops[from].a, ops[from].b
+		var aLen, bLen int
+		for _, op := range hunk {
+			if op.kind != '+' {
+				aLen++
+			}
+			if op.kind != '-' {
+				bLen++
+			}
+		}
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", hunkRange(aStart, aLen), hunkRange(bStart, bLen))
+		for _, op := range hunk {
+			out.WriteByte(op.kind)
+			out.WriteString(op.line)
+			if !strings.HasSuffix(op.line, "\n") {
+				out.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		start = end
+	}
+	return out.String()
+}
+
+type diffOp struct {
+	kind byte // ' ', '-' or '+'
+	line string
+	a, b int // line indexes in old and new before this op
+}
+
+func diffLines(a, b []string) []diffOp {
+	// lcs[i][j] is the length of the LCS of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i], i, j})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, diffOp{'-', a[i], i, j})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j], i, j})
+			j++
+		}
+	}
+	return ops
+}
+
+func hunkRange(start, n int) string {
+	if n == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if n == 1 {
+		return fmt.Sprint(start + 1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, n)
+}
+
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Field is a column on the resource's table, in addition to the id and
+// timestamps every resource has.
+type Field struct {
+	Name       string   `yaml:"name"` // snake_case column name
+	Type       string   `yaml:"type"` // see fieldTypes
+	Nullable   bool     `yaml:"nullable"`
+	Unique     bool     `yaml:"unique"`
+	Values     []string `yaml:"values"`     // enum: allowed values
+	References string   `yaml:"references"` // ref: table or resource name
+	OnDelete   string   `yaml:"on_delete"`  // ref: cascade, restrict or set null
+	Default    string   `yaml:"default"`
+
+	// refModel is the referenced store model, resolved by Resource.Validate.
+	refModel string
+	// parent marks the foreign key Resource.Validate adds for the parent.
+	parent bool
+}
+
+// fieldType describes how a field type maps onto each layer.
+type fieldType struct {
+	goType   string // service and API type
+	sqlite   string
+	postgres string
+	null     string // sql.Null* type and its value field
+	nullVal  string
+	openAPI  string // type and format lines
+}
+
+var fieldTypes = map[string]fieldType{
+	"string": {"string", "TEXT", "TEXT", "sql.NullString", "String", "type: string"},
+	"int":    {"int64", "INTEGER", "BIGINT", "sql.NullInt64", "Int64", "type: integer\nformat: int64"},
+	"float":  {"float64", "REAL", "DOUBLE PRECISION", "sql.NullFloat64", "Float64", "type: number\nformat: double"},
+	"bool":   {"bool", "BOOLEAN", "BOOLEAN", "sql.NullBool", "Bool", "type: boolean"},
+	"time":   {"time.Time", "DATETIME", "TIMESTAMPTZ", "sql.NullTime", "Time", "type: string\nformat: date-time"},
+	"enum":   {"string", "TEXT", "TEXT", "sql.NullString", "String", "type: string"},
+	"ref":    {"string", "TEXT", "TEXT", "sql.NullString", "String", "type: string\nformat: uuid"},
+}
+
+func (f Field) typ() fieldType { return fieldTypes[f.Type] }
+
+// GoName returns the exported Go field name, e.g. DueDate.
+func (f Field) GoName() string { return toPascal(f.Name) }
+
+// JSONName returns the API field name, e.g. dueDate.
+func (f Field) JSONName() string { return toCamel(f.Name) }
+
+// Label returns the field name for messages, e.g. "Due date".
+func (f Field) Label() string { return upperFirst(strings.ReplaceAll(f.Name, "_", " ")) }
+
+// IsText reports whether the field is a string in Go.
+func (f Field) IsText() bool { return f.typ().goType == "string" }
+
+// IsEnum reports whether the field only accepts Values.
+func (f Field) IsEnum() bool { return f.Type == "enum" }
+
+// IsRef reports whether the field is a foreign key.
+func (f Field) IsRef() bool { return f.Type == "ref" }
+
+// IsParent reports whether the field is the key to the resource's parent.
+func (f Field) IsParent() bool { return f.parent }
+
+// RefRoute returns the URL path segment of the referenced table, e.g.
+// users.
+func (f Field) RefRoute() string { return strings.ReplaceAll(f.References, "_", "-") }
+
+// Required reports whether the field must be present on create.
+func (f Field) Required() bool { return !f.Nullable && f.Default == "" }
+
+// Column returns the column definition for dialect, e.g.
+// "status TEXT NOT NULL DEFAULT 'draft' CHECK(status IN ('draft', 'done'))".
+func (f Field) Column(dialect string) string {
+	def := f.Name + " " + f.typ().sqlite
+	if dialect == "postgres" {
+		def = f.Name + " " + f.typ().postgres
+	}
+	if !f.Nullable {
+		def += " NOT NULL"
+	}
+	if f.Unique {
+		def += " UNIQUE"
+	}
+	if f.Default != "" {
+		def += " DEFAULT " + f.sqlDefault()
+	}
+	if f.IsEnum() {
+		quoted := make([]string, len(f.Values))
+		for i, v := range f.Values {
+			quoted[i] = sqlQuote(v)
+		}
+		def += fmt.Sprintf(" CHECK(%s IN (%s))", f.Name, strings.Join(quoted, ", "))
+	}
+	if f.IsRef() {
+		def += fmt.Sprintf(" REFERENCES %s(id) ON DELETE %s", f.References, strings.ToUpper(f.OnDelete))
+	}
+	return def
+}
+
+func (f Field) sqlDefault() string {
+	switch f.Type {
+	case "int", "float":
+		return f.Default
+	case "bool":
+		return strings.ToUpper(f.Default)
+	case "time":
+		return "CURRENT_TIMESTAMP"
+	default:
+		return sqlQuote(f.Default)
+	}
+}
+
+// DefaultGo returns the default as a Go expression of the field's type.
+func (f Field) DefaultGo() string {
+	switch f.Type {
+	case "int", "float", "bool":
+		return f.Default
+	case "time":
+		return "time.Now()"
+	default:
+		return strconv.Quote(f.Default)
+	}
+}
+
+// StoreType returns the Go type sqlc uses for the column.
+func (f Field) StoreType() string {
+	if f.Nullable {
+		return f.typ().null
+	}
+	return f.typ().goType
+}
+
+// BaseType returns the field's Go type.
+func (f Field) BaseType() string { return f.typ().goType }
+
+// ServiceType returns the type used by the service model and API
+// responses; nullable fields are pointers.
+func (f Field) ServiceType() string {
+	if f.Nullable {
+		return "*" + f.typ().goType
+	}
+	return f.typ().goType
+}
+
+// CreateType returns the type used in create requests. Required text
+// fields are plain strings checked against ""; everything else is a pointer
+// so that absence can be told apart from the zero value.
+func (f Field) CreateType() string {
+	if f.IsText() && f.Required() {
+		return "string"
+	}
+	return "*" + f.typ().goType
+}
+
+// ToStore returns an expression converting v, of the field's Go type, to
+// the store type.
+func (f Field) ToStore(v string) string {
+	if f.Nullable {
+		return fmt.Sprintf("%s{%s: %s, Valid: true}", f.typ().null, f.typ().nullVal, v)
+	}
+	return v
+}
+
+// NullValue returns the value field of the sql.Null* store type.
+func (f Field) NullValue() string { return f.typ().nullVal }
+
+// RefModel returns the referenced store model, e.g. User.
+func (f Field) RefModel() string { return f.refModel }
+
+// RefName returns the referenced record's name for errors: the field name
+// without its _id suffix, e.g. Owner for owner_id.
+func (f Field) RefName() string { return toPascal(strings.TrimSuffix(f.Name, "_id")) }
+
+// RefLabel returns RefName for messages, e.g. "Owner".
+func (f Field) RefLabel() string {
+	return upperFirst(strings.ReplaceAll(strings.TrimSuffix(f.Name, "_id"), "_", " "))
+}
+
+// ValuesList returns the enum values for messages, e.g. "draft, done".
+func (f Field) ValuesList() string { return strings.Join(f.Values, ", ") }
+
+// ValuesGo returns the enum values as a Go slice literal body.
+func (f Field) ValuesGo() string {
+	quoted := make([]string, len(f.Values))
+	for i, v := range f.Values {
+		quoted[i] = strconv.Quote(v)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// OpenAPIType returns the schema type lines, indented by indent spaces.
+func (f Field) OpenAPIType(indent int) string {
+	pad := strings.Repeat(" ", indent)
+	lines := strings.Split(f.typ().openAPI, "\n")
+	if f.IsEnum() {
+		lines = append(lines, "enum:")
+		for _, v := range f.Values {
+			lines = append(lines, "  - "+v)
+		}
+	}
+	return pad + strings.Join(lines, "\n"+pad)
+}
+
+// OpenAPIResponseType is OpenAPIType for responses, where nullable fields
+// may be null.
+func (f Field) OpenAPIResponseType(indent int) string {
+	s := f.OpenAPIType(indent)
+	if !f.Nullable {
+		return s
+	}
+	base, _ := strings.CutPrefix(strings.Split(f.typ().openAPI, "\n")[0], "type: ")
+	s = strings.Replace(s, "type: "+base, fmt.Sprintf("type: [%s, \"null\"]", base), 1)
+	if f.IsEnum() {
+		s += "\n" + strings.Repeat(" ", indent) + "  - null"
+	}
+	return s
+}
+
+// Description returns the field's OpenAPI description, e.g. "Owner ID".
+func (f Field) Description() string {
+	if f.IsRef() {
+		return f.RefLabel() + " ID"
+	}
+	return f.Label()
+}
+
+var plainScalarRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// yamlKeywords are plain scalars YAML would not read as strings.
+var yamlKeywords = map[string]bool{"true": true, "false": true, "null": true, "yes": true, "no": true, "on": true, "off": true, "y": true, "n": true}
+
+// OpenAPIDefault returns the default as a YAML scalar, or "" if there is
+// none or it is computed by the server.
+func (f Field) OpenAPIDefault() string {
+	switch {
+	case f.Default == "" || f.Type == "time":
+		return ""
+	case f.IsText() && (!plainScalarRe.MatchString(f.Default) || yamlKeywords[strings.ToLower(f.Default)]):
+		return strconv.Quote(f.Default)
+	default:
+		return f.Default
+	}
+}
+
+// Sample returns a Go literal used as the field's value in generated
+// tests; variant 1 and 2 give different values.
+func (f Field) Sample(variant int) string {
+	switch f.Type {
+	case "int":
+		return strconv.Itoa(variant)
+	case "float":
+		return fmt.Sprintf("%d.5", variant)
+	case "bool":
+		return strconv.FormatBool(variant == 1)
+	case "time":
+		return fmt.Sprintf("%q", fmt.Sprintf("2025-0%d-01T09:00:00Z", variant))
+	case "enum":
+		return strconv.Quote(f.Values[(variant-1)*(len(f.Values)-1)])
+	case "ref":
+		return goVar(f.Name) + "Ref.ID"
+	default:
+		return fmt.Sprintf("%q", fmt.Sprintf("%s %d", strings.ReplaceAll(f.Name, "_", " "), variant))
+	}
+}
+
+// FixtureValue returns a Go expression of the store type used by
+// generated fixtures, which fill in only non-null fields. id is a unique
+// string in scope; refs are created by the fixture for the referenced
+// model.
+func (f Field) FixtureValue() string {
+	switch f.Type {
+	case "int":
+		return "1"
+	case "float":
+		return "1.5"
+	case "bool":
+		return "true"
+	case "time":
+		return "time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)"
+	case "enum":
+		if f.Default != "" {
+			return strconv.Quote(f.Default)
+		}
+		return strconv.Quote(f.Values[0])
+	case "ref":
+		return "Create" + f.refModel + "(t, s).ID"
+	default:
+		return fmt.Sprintf("%q + id", strings.ReplaceAll(f.Name, "_", " ")+" ")
+	}
+}
+
+func sqlQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -1,218 +1,214 @@
+// Command scaffold generates a complete CRUD resource: migrations for both
+// databases, sqlc queries and their generated store code, a service, a
+// handler, handler tests and a test fixture. It also adds the resource's
+// paths and schemas to api/openapi.yaml, from which the TypeScript client is
+// generated.
+//
+//	go run ./cmd/scaffold -name season
+//	go run ./cmd/scaffold -name project -field title:string:unique \
+//		-field 'status:enum(draft|active):default=draft' \
+//		-field 'owner_id:ref(users)' -field due:time:nullable
+//	go run ./cmd/scaffold -schema project.yaml
+//	go run ./cmd/scaffold -name note -parent users -parent-on-delete restrict
+//
+// Fields default to a single required "name" string; see ParseField and
+// LoadSchema for the field syntax. -parent nests the resource under another
+// one: notes then live at /api/users/{userId}/notes, with a foreign key to
+// users and lists scoped to one user.
+//
+// Run it from the backend directory. The new handler is added to
+// internal/handler/resources_gen.go, which the server mounts under /api, so
+// the resource is served without editing internal/app.
+//
+// -dry-run lists the files that would change and -diff prints the changes;
+// neither writes anything. -regen regenerates an existing resource after its
+// fields changed: code between scaffold:begin and scaffold:end markers is
+// replaced and everything else is kept (see MergeRegions). -remove deletes a
+// resource and takes it out of the shared files again:
+//
+//	go run ./cmd/scaffold -name season -field name -field starts:time -regen -diff
+//	go run ./cmd/scaffold -name season -remove
 package main
 
 import (
+	"bytes"
+	"embed"
+	"errors"
 	"flag"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
 	"text/template"
-	"unicode"
 )
 
-var (
-	resourceName string
-)
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var templateFuncs = template.FuncMap{
+	"camel": goVar,
+	"lower": strings.ToLower,
+}
 
 func main() {
-	flag.StringVar(&resourceName, "name", "", "Name of the resource to scaffold (e.g., 'season')")
+	var fields fieldFlags
+	name := flag.String("name", "", "Name of the resource to scaffold (e.g., 'season')")
+	schema := flag.String("schema", "", "YAML file describing the resource and its fields")
+	dir := flag.String("dir", ".", "Backend directory to generate into")
+	flag.Var(&fields, "field", "Field definition name[:type][:option...], repeatable (e.g., 'status:enum(draft|done):default=draft')")
+	parent := flag.String("parent", "", "Table the resource belongs to; nests its routes under the parent's (e.g., 'users')")
+	parentOnDelete := flag.String("parent-on-delete", "", "What deleting the parent does to the resource: cascade (default) or restrict")
+	dryRun := flag.Bool("dry-run", false, "Print the files that would change without writing them")
+	diff := flag.Bool("diff", false, "Print a diff of the changes without writing them")
+	regen := flag.Bool("regen", false, "Regenerate an existing resource, keeping code outside scaffold markers")
+	remove := flag.Bool("remove", false, "Remove the resource named by -name")
 	flag.Parse()
 
-	if resourceName == "" {
-		fmt.Println("Error: Resource name is required")
-		flag.Usage()
-		os.Exit(1)
+	var res *Resource
+	var files []File
+	var err error
+	if *remove {
+		if *name == "" {
+			fail(errors.New("-remove needs -name"), true)
+		}
+		files, err = Remove(*dir, *name)
+	} else {
+		res, err = loadResource(*name, *schema, fields)
+		if err == nil && *parent != "" {
+			res.Parent = *parent
+		}
+		if err == nil && *parentOnDelete != "" {
+			res.ParentOnDelete = *parentOnDelete
+		}
+		if err == nil {
+			err = res.Validate(*dir)
+		}
+		if err != nil {
+			fail(err, true)
+		}
+		fmt.Printf("Scaffolding resource: %s (Pascal: %s)\n", res.Name, res.Pascal())
+		files, err = Plan(*dir, res, *regen)
 	}
-
-	// Normalize name
-	resourceName = strings.ToLower(resourceName)
-	pascalName := toPascalCase(resourceName)
-
-	fmt.Printf("Scaffolding resource: %s (Pascal: %s)\n", resourceName, pascalName)
-
-	// Define files to generate
-	files := []struct {
-		Path    string
-		Content string
-	}{
-		{
-			Path: fmt.Sprintf("internal/model/%s.go", resourceName),
-			Content: `package model
-
-type {{.PascalName}} struct {
-	ID   string ` + "`json:\"id\"`" + `
-	Name string ` + "`json:\"name\"`" + `
-}
-`,
-		},
-		{
-			Path: fmt.Sprintf("internal/store/%s_store.go", resourceName),
-			Content: `package store
-
-import (
-	"context"
-	"database/sql"
-	"github.com/keel/api/internal/model"
-)
-
-type {{.PascalName}}Store struct {
-	*BaseStore
-}
-
-func New{{.PascalName}}Store(db *sql.DB) *{{.PascalName}}Store {
-	return &{{.PascalName}}Store{BaseStore: NewBaseStore(db)}
-}
-
-func (s *{{.PascalName}}Store) Create(ctx context.Context, item *model.{{.PascalName}}) error {
-	// TODO: Implement Create
-	return nil
-}
-
-func (s *{{.PascalName}}Store) Get(ctx context.Context, id string) (*model.{{.PascalName}}, error) {
-	// TODO: Implement Get
-	return nil, nil // sql.ErrNoRows
-}
-
-func (s *{{.PascalName}}Store) List(ctx context.Context) ([]*model.{{.PascalName}}, error) {
-	// TODO: Implement List
-	return []*model.{{.PascalName}}{}, nil
-}
-`,
-		},
-		{
-			Path: fmt.Sprintf("internal/service/%s.go", resourceName),
-			Content: `package service
-
-import (
-	"context"
-	"github.com/keel/api/internal/model"
-	"github.com/keel/api/internal/store"
-)
-
-type {{.PascalName}}Service struct {
-	store *store.{{.PascalName}}Store
-}
-
-func New{{.PascalName}}Service(store *store.{{.PascalName}}Store) *{{.PascalName}}Service {
-	return &{{.PascalName}}Service{store: store}
-}
-
-func (s *{{.PascalName}}Service) Create(ctx context.Context, item *model.{{.PascalName}}) error {
-	return s.store.Create(ctx, item)
-}
-
-func (s *{{.PascalName}}Service) Get(ctx context.Context, id string) (*model.{{.PascalName}}, error) {
-	return s.store.Get(ctx, id)
-}
-
-func (s *{{.PascalName}}Service) List(ctx context.Context) ([]*model.{{.PascalName}}, error) {
-	return s.store.List(ctx)
-}
-`,
-		},
-		{
-			Path: fmt.Sprintf("internal/handler/%s.go", resourceName),
-			Content: `package handler
-
-import (
-	"encoding/json"
-	"net/http"
-	"github.com/go-chi/chi/v5"
-	"github.com/keel/api/internal/service"
-)
-
-type {{.PascalName}}Handler struct {
-	service *service.{{.PascalName}}Service
-}
-
-func New{{.PascalName}}Handler(service *service.{{.PascalName}}Service) *{{.PascalName}}Handler {
-	return &{{.PascalName}}Handler{service: service}
-}
-
-func (h *{{.PascalName}}Handler) RegisterRoutes(r chi.Router) {
-	r.Route("/{{.LowerName}}s", func(r chi.Router) {
-		r.Get("/", h.List)
-		r.Get("/{id}", h.Get)
-		// r.Post("/", h.Create)
-	})
-}
-
-func (h *{{.PascalName}}Handler) List(w http.ResponseWriter, r *http.Request) {
-	items, err := h.service.List(r.Context())
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+		fail(err, false)
 	}
-	json.NewEncoder(w).Encode(items)
-}
 
-func (h *{{.PascalName}}Handler) Get(w http.ResponseWriter, r *http.Request) {
-	id := chi.URLParam(r, "id")
-	item, err := h.service.Get(r.Context(), id)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
-		return
+	for _, f := range files {
+		if err := apply(*dir, f, *dryRun, *diff); err != nil {
+			fail(err, false)
+		}
+	}
+	if res != nil && !*dryRun && !*diff {
+		printNextSteps(res, *regen)
 	}
-	json.NewEncoder(w).Encode(item)
 }
-`,
-		},
+
+func fail(err error, usage bool) {
+	fmt.Println("Error:", err)
+	if usage {
+		flag.Usage()
+	}
+	os.Exit(1)
+}
+
+// apply writes or deletes f, or with dryRun or diff only describes the
+// change. Files that would not change are skipped.
+func apply(dir string, f File, dryRun, diff bool) error {
+	path := filepath.Join(dir, f.Path)
+	verb := "Created"
+	switch {
+	case f.Delete:
+		verb = "Deleted"
+	case f.Update:
+		verb = "Updated"
 	}
 
-	data := struct {
-		PascalName string
-		LowerName  string
-	}{
-		PascalName: pascalName,
-		LowerName:  resourceName,
+	old, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if !f.Delete && err == nil && bytes.Equal(old, f.Content) {
+		return nil
 	}
 
-	for _, f := range files {
-		tmpl, err := template.New("file").Parse(f.Content)
-		if err != nil {
-			fmt.Printf("Error parsing template for %s: %v\n", f.Path, err)
-			continue
+	switch {
+	case diff:
+		fmt.Print(UnifiedDiff(f.Path, old, f.Content))
+		return nil
+	case dryRun:
+		fmt.Printf("Would have %s: %s\n", strings.ToLower(verb), f.Path)
+		return nil
+	case f.Delete:
+		if err := os.Remove(path); err != nil {
+			return err
 		}
-
-		// Ensure directory exists
-		dir := filepath.Dir(f.Path)
-		if err := os.MkdirAll(dir, 0755); err != nil {
-			fmt.Printf("Error creating directory %s: %v\n", dir, err)
-			continue
+	default:
+		if err := writeFile(path, f.Content); err != nil {
+			return err
 		}
+	}
+	fmt.Printf("%s: %s\n", verb, f.Path)
+	return nil
+}
 
-		// check if file exists
-		if _, err := os.Stat(f.Path); err == nil {
-			fmt.Printf("File already exists, skipping: %s\n", f.Path)
-			continue
+func printNextSteps(res *Resource, regen bool) {
+	if regen {
+		fmt.Println(`
+Existing migrations were left as they are; add a migration for any column
+changes before running the tests.`)
+	}
+	fmt.Printf(`
+Done! The handler is mounted under /api/%[1]s via internal/handler/resources_gen.go.
+
+Run: go test ./internal/handler/ -run %[2]s
+and regenerate the TypeScript client from the repo root: bun run generate:api
+`, res.Path(), res.Pascal())
+}
+
+// loadResource builds the resource from the -schema file and/or the -name
+// and -field flags; flags override the file.
+func loadResource(name, schema string, fields []string) (*Resource, error) {
+	res := &Resource{Name: name}
+	if schema != "" {
+		var err error
+		if res, err = LoadSchema(schema); err != nil {
+			return nil, err
 		}
-
-		file, err := os.Create(f.Path)
-		if err != nil {
-			fmt.Printf("Error creating file %s: %v\n", f.Path, err)
-			continue
+		if name != "" {
+			res.Name = name
 		}
-		defer func() {
-			if err := file.Close(); err != nil {
-				fmt.Printf("Error closing file %s: %v\n", f.Path, err)
-			}
-		}()
+	}
+	if res.Name == "" {
+		return nil, errors.New("resource name is required")
+	}
 
-		if err := tmpl.Execute(file, data); err != nil {
-			fmt.Printf("Error executing template for %s: %v\n", f.Path, err)
-			continue
+	base, err := NewResource(res.Name)
+	if err != nil {
+		return nil, err
+	}
+	res.Name = base.Name
+
+	if len(fields) > 0 {
+		res.Fields = nil
+		for _, spec := range fields {
+			f, err := ParseField(spec)
+			if err != nil {
+				return nil, err
+			}
+			res.Fields = append(res.Fields, f)
 		}
-
-		fmt.Printf("Created: %s\n", f.Path)
 	}
-
-	fmt.Println("\nDone! Don't forget to wire up the new handler in cmd/server/main.go")
+	return res, nil
 }
 
-func toPascalCase(s string) string {
-	runes := []rune(s)
-	if len(runes) > 0 {
-		runes[0] = unicode.ToUpper(runes[0])
+// writeFile writes content to path, creating parent directories.
+func writeFile(path string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
 	}
-	return string(runes)
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
 }
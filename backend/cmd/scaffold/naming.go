@@ -0,0 +1,120 @@
+package main
+
+import (
+	"go/token"
+	"strings"
+	"unicode"
+)
+
+// toSnake converts "LineItem", "line-item" or "lineItem" to "line_item".
+func toSnake(s string) string {
+	var b strings.Builder
+	runes := []rune(strings.TrimSpace(s))
+	for i, r := range runes {
+		switch {
+		case r == '-' || r == ' ':
+			b.WriteRune('_')
+		case unicode.IsUpper(r):
+			if i > 0 && runes[i-1] != '_' && runes[i-1] != '-' && !unicode.IsUpper(runes[i-1]) {
+				b.WriteRune('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// toPascal converts snake_case to PascalCase using sqlc's initialisms, so
+// generated names match the generated store ("user_id" becomes UserID).
+func toPascal(s string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(s, "_") {
+		if part == "id" {
+			b.WriteString("ID")
+			continue
+		}
+		b.WriteString(upperFirst(part))
+	}
+	return b.String()
+}
+
+// toCamel converts snake_case to lowerCamelCase ("user_id" becomes userId).
+func toCamel(s string) string {
+	parts := strings.Split(s, "_")
+	for i := 1; i < len(parts); i++ {
+		parts[i] = upperFirst(parts[i])
+	}
+	return strings.Join(parts, "")
+}
+
+// goVar converts snake_case to a Go local variable name with sqlc's
+// initialisms ("owner_id" becomes ownerID), escaping keywords.
+func goVar(s string) string {
+	name := toPascal(s)
+	if strings.HasPrefix(name, "ID") {
+		name = "id" + name[2:]
+	} else {
+		name = lowerFirst(name)
+	}
+	return goIdent(name)
+}
+
+func upperFirst(s string) string {
+	runes := []rune(s)
+	if len(runes) > 0 {
+		runes[0] = unicode.ToUpper(runes[0])
+	}
+	return string(runes)
+}
+
+func lowerFirst(s string) string {
+	runes := []rune(s)
+	if len(runes) > 0 {
+		runes[0] = unicode.ToLower(runes[0])
+	}
+	return string(runes)
+}
+
+// pluralize returns the English plural of a snake_case name, inflecting
+// only its last word.
+func pluralize(s string) string {
+	prefix, word := "", s
+	if i := strings.LastIndex(s, "_"); i >= 0 {
+		prefix, word = s[:i+1], s[i+1:]
+	}
+
+	switch {
+	case strings.HasSuffix(word, "y") && len(word) > 1 && !strings.ContainsRune("aeiou", rune(word[len(word)-2])):
+		word = word[:len(word)-1] + "ies"
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		word += "es"
+	default:
+		word += "s"
+	}
+	return prefix + word
+}
+
+// singularize reverses pluralize for the forms it produces.
+func singularize(s string) string {
+	for _, candidate := range []string{
+		strings.TrimSuffix(s, "ies") + "y",
+		strings.TrimSuffix(s, "es"),
+		strings.TrimSuffix(s, "s"),
+	} {
+		if candidate != s && pluralize(candidate) == s {
+			return candidate
+		}
+	}
+	return s
+}
+
+// goIdent escapes Go keywords the way sqlc does, e.g. type becomes type_.
+func goIdent(s string) string {
+	if token.IsKeyword(s) {
+		return s + "_"
+	}
+	return s
+}
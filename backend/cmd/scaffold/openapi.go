@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// UpdateOpenAPI inserts the resource's paths, id parameter and schemas into
+// the OpenAPI spec src. It edits the text rather than re-encoding the YAML
+// so that comments, quoting, blank lines and key order are kept: paths are
+// appended to paths, the parameter to components.parameters and the schemas
+// after the last *ListResponse schema.
+func UpdateOpenAPI(src []byte, res *Resource) ([]byte, error) {
+	if regexp.MustCompile(`(?m)^  /api/` + regexp.QuoteMeta(res.Path()) + `:`).Match(src) {
+		return nil, fmt.Errorf("openapi.yaml already has /api/%s", res.Path())
+	}
+	if regexp.MustCompile(`(?m)^    ` + res.Pascal() + `:`).Match(src) {
+		return nil, fmt.Errorf("openapi.yaml already has a %s schema", res.Pascal())
+	}
+
+	var blocks [3][]byte
+	for i, name := range []string{"openapi_paths.yaml.tmpl", "openapi_parameters.yaml.tmpl", "openapi_schemas.yaml.tmpl"} {
+		b, err := render(name, res)
+		if err != nil {
+			return nil, err
+		}
+		blocks[i] = b
+	}
+
+	lines := strings.SplitAfter(string(src), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	paths, ok := section(lines, 0, len(lines), "paths", 0)
+	if !ok {
+		return nil, fmt.Errorf("openapi.yaml has no paths")
+	}
+	lines = insertBlock(lines, paths.end, blocks[0])
+
+	components, ok := section(lines, 0, len(lines), "components", 0)
+	if !ok {
+		return nil, fmt.Errorf("openapi.yaml has no components")
+	}
+	params, ok := section(lines, components.start+1, components.end, "parameters", 2)
+	if !ok {
+		return nil, fmt.Errorf("openapi.yaml has no components.parameters")
+	}
+	lines = insertBlock(lines, params.end, blocks[1])
+
+	components, _ = section(lines, 0, len(lines), "components", 0)
+	schemas, ok := section(lines, components.start+1, components.end, "schemas", 2)
+	if !ok {
+		return nil, fmt.Errorf("openapi.yaml has no components.schemas")
+	}
+	at := schemas.end
+	for i := schemas.start + 1; i < schemas.end; i++ {
+		if key, indent := keyAt(lines[i]); indent == 4 && strings.HasSuffix(key, "ListResponse") {
+			at = blockEnd(lines, i, schemas.end, 4)
+		}
+	}
+	lines = insertBlock(lines, at, blocks[2])
+
+	out := []byte(strings.Join(lines, ""))
+	var spec struct {
+		Paths map[string]any `yaml:"paths"`
+	}
+	if err := yaml.Unmarshal(out, &spec); err != nil {
+		return nil, fmt.Errorf("updated openapi.yaml does not parse: %w", err)
+	}
+	if _, ok := spec.Paths["/api/"+res.Path()+"/{id}"]; !ok {
+		return nil, fmt.Errorf("updated openapi.yaml is missing /api/%s/{id}", res.Path())
+	}
+	return out, nil
+}
+
+// RemoveOpenAPI deletes the paths, parameters and schemas UpdateOpenAPI
+// added for res from the OpenAPI spec src, leaving everything else as it
+// was. The paths are found by the resource's route, nested under a parent
+// or not, so res needs no fields.
+func RemoveOpenAPI(src []byte, res *Resource) []byte {
+	lines := strings.SplitAfter(string(src), "\n")
+
+	pathRe := regexp.MustCompile(`^/api/([\w-]+/\{\w+\}/)?` + regexp.QuoteMeta(res.Route()) + `(/\{id\})?$`)
+	for {
+		paths, ok := section(lines, 0, len(lines), "paths", 0)
+		if !ok {
+			break
+		}
+		found := false
+		for i := paths.start + 1; i < paths.end; i++ {
+			if key, indent := keyAt(lines[i]); indent == 2 && pathRe.MatchString(key) {
+				lines = slices.Delete(lines, i, blockEnd(lines, i, paths.end, 2))
+				found = true
+				break
+			}
+		}
+		if !found {
+			break
+		}
+	}
+
+keys:
+	for _, k := range []struct {
+		parent string
+		name   string
+	}{
+		{"components.parameters", res.Pascal() + "IdParam"},
+		{"components.parameters", res.Pascal() + "ParentIdParam"},
+		{"components.schemas", res.Pascal()},
+		{"components.schemas", "Create" + res.Pascal() + "Request"},
+		{"components.schemas", "Update" + res.Pascal() + "Request"},
+		{"components.schemas", res.Pascal() + "ListResponse"},
+	} {
+		from, to, indent := 0, len(lines), 0
+		for _, parent := range strings.Split(k.parent, ".") {
+			s, ok := section(lines, from, to, parent, indent)
+			if !ok {
+				continue keys
+			}
+			from, to, indent = s.start+1, s.end, indent+2
+		}
+		if s, ok := section(lines, from, to, k.name, indent); ok {
+			lines = slices.Delete(lines, s.start, s.end)
+		}
+	}
+	return []byte(strings.Join(lines, ""))
+}
+
+type span struct{ start, end int }
+
+// section finds the mapping key name at indent within lines[from:to] and
+// returns the lines it spans, up to the next key at the same or lower
+// indentation.
+func section(lines []string, from, to int, name string, indent int) (span, bool) {
+	for i := from; i < to; i++ {
+		if key, n := keyAt(lines[i]); n == indent && key == name {
+			return span{i, blockEnd(lines, i, to, indent)}, true
+		}
+	}
+	return span{}, false
+}
+
+// blockEnd returns the index of the first key after start, and before to,
+// indented at most indent.
+func blockEnd(lines []string, start, to, indent int) int {
+	for i := start + 1; i < to; i++ {
+		if _, n := keyAt(lines[i]); n >= 0 && n <= indent {
+			return i
+		}
+	}
+	return to
+}
+
+// keyAt returns the mapping key on line and its indentation, or -1 for
+// lines that are blank, comments or list items.
+func keyAt(line string) (string, int) {
+	trimmed := strings.TrimLeft(line, " ")
+	if trimmed == "" || trimmed == "\n" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "-") {
+		return "", -1
+	}
+	key, _, _ := strings.Cut(trimmed, ":")
+	return strings.Trim(key, `"'`), len(line) - len(trimmed)
+}
+
+// insertBlock inserts block before lines[at], separated from its neighbours
+// by a blank line.
+func insertBlock(lines []string, at int, block []byte) []string {
+	text := string(block)
+	if at > 0 && strings.TrimSpace(lines[at-1]) != "" {
+		if !strings.HasSuffix(lines[at-1], "\n") {
+			text = "\n" + text
+		}
+		text = "\n" + text
+	}
+	if at < len(lines) && strings.TrimSpace(lines[at]) != "" {
+		text += "\n"
+	}
+	out := make([]string, 0, len(lines)+1)
+	out = append(out, lines[:at]...)
+	out = append(out, text)
+	return append(out, lines[at:]...)
+}
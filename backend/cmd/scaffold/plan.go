@@ -0,0 +1,353 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// File is a change the scaffold makes. Files with Update set already exist
+// and are rewritten, files with Delete set are removed, and all others must
+// not exist yet.
+type File struct {
+	Path    string
+	Content []byte
+	Update  bool
+	Delete  bool
+}
+
+// Plan returns every file generated for res in the backend directory dir.
+// It fails without side effects if the resource already exists, unless
+// regen is set: then generated files are rewritten, the marked sections of
+// the service, handler and handler test are replaced (see MergeRegions) and
+// existing migrations are left alone.
+func Plan(dir string, res *Resource, regen bool) ([]File, error) {
+	files := []File{}
+	exists := func(path string) bool {
+		_, err := os.Stat(filepath.Join(dir, path))
+		return err == nil
+	}
+	add := func(path string, content []byte, update bool) {
+		files = append(files, File{Path: path, Content: content, Update: update || regen && exists(path)})
+	}
+
+	migrations, err := tableMigrations(dir, res.Table())
+	if err != nil {
+		return nil, err
+	}
+	if !regen || len(migrations) == 0 {
+		version, err := nextMigration(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, dialect := range []string{"sqlite", "postgres"} {
+			timestamp := "DATETIME"
+			if dialect == "postgres" {
+				timestamp = "TIMESTAMPTZ"
+			}
+			content, err := render("migration.sql.tmpl", struct {
+				*Resource
+				Dialect, Timestamp string
+			}{res, dialect, timestamp})
+			if err != nil {
+				return nil, err
+			}
+			add(fmt.Sprintf("migrations/%s/%03d_%s.sql", dialect, version, res.Table()), content, false)
+		}
+	}
+
+	queries := res.Queries()
+	add("query/"+res.Table()+".sql", []byte(queryHeader+QueryFile(queries)), false)
+
+	storeDir := filepath.Join(dir, "internal/store")
+	storeName := res.Table() + ".sql.go"
+	storeGo, err := StoreFile(SQLCVersion(storeDir), res.Table()+".sql", queries, map[string][]string{res.Pascal(): res.Columns()})
+	if err != nil {
+		return nil, fmt.Errorf("generate store: %w", err)
+	}
+	add("internal/store/"+storeName, storeGo, false)
+
+	models, err := os.ReadFile(filepath.Join(storeDir, "models.go"))
+	if err != nil {
+		return nil, err
+	}
+	if !regen && regexp.MustCompile(`(?m)^type `+res.Pascal()+` struct`).Match(models) {
+		return nil, fmt.Errorf("store model %s already exists", res.Pascal())
+	}
+	models, err = UpdateModels(models, res.Pascal(), ModelStruct(res.Pascal(), res.Columns(), res.ModelTypes()))
+	if err != nil {
+		return nil, fmt.Errorf("update models.go: %w", err)
+	}
+	add("internal/store/models.go", models, true)
+
+	dbGo, querierGo, err := StoreIndex(storeDir, map[string][]byte{storeName: storeGo})
+	if err != nil {
+		return nil, fmt.Errorf("update store index: %w", err)
+	}
+	add("internal/store/db.go", dbGo, true)
+	add("internal/store/querier.go", querierGo, true)
+
+	spec, err := os.ReadFile(filepath.Join(dir, "api/openapi.yaml"))
+	if err == nil {
+		if regen {
+			spec = RemoveOpenAPI(spec, res)
+		}
+		spec, err = UpdateOpenAPI(spec, res)
+		if err != nil {
+			return nil, err
+		}
+		add("api/openapi.yaml", spec, true)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	wiring, err := Wiring(dir, res.Pascal(), "")
+	if err != nil {
+		return nil, fmt.Errorf("update handler wiring: %w", err)
+	}
+	add("internal/handler/resources_gen.go", wiring, true)
+
+	for _, t := range []struct {
+		tmpl, path string
+		merge      bool
+	}{
+		{"service.go.tmpl", "internal/service/" + res.Name + "_service.go", true},
+		{"handler.go.tmpl", "internal/handler/" + res.Name + "_handler.go", true},
+		{"handler_test.go.tmpl", "internal/handler/" + res.Name + "_handler_test.go", true},
+		{"fixture.go.tmpl", "internal/store/storetest/" + res.Name + ".go", false},
+	} {
+		content, err := render(t.tmpl, res)
+		if err != nil {
+			return nil, err
+		}
+		if regen && t.merge && exists(t.path) {
+			existing, err := os.ReadFile(filepath.Join(dir, t.path))
+			if err != nil {
+				return nil, err
+			}
+			if content, err = MergeRegions(existing, content); err != nil {
+				return nil, fmt.Errorf("%s: %w", t.path, err)
+			}
+		}
+		add(t.path, content, false)
+	}
+
+	for _, f := range files {
+		if !f.Update && exists(f.Path) {
+			return nil, fmt.Errorf("%s already exists (use -regen to update it)", f.Path)
+		}
+	}
+	return files, nil
+}
+
+// queryHeader starts the generated query file, which -regen rewrites.
+const queryHeader = `-- Generated by cmd/scaffold; -regen rewrites this file. Add custom queries
+-- in another file under query/.
+
+`
+
+// Remove returns the changes that undo Plan for the resource name: its
+// files are deleted and the shared files it was added to are rewritten
+// without it. Its migrations are deleted if they are the newest ones, as
+// they have most likely never been deployed; otherwise a migration that
+// drops the table is added.
+func Remove(dir, name string) ([]File, error) {
+	res, err := NewResource(name)
+	if err != nil {
+		return nil, err
+	}
+	table := res.Table()
+
+	tables, err := existingTables(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !tables[table] {
+		return nil, fmt.Errorf("no migration creates table %s", table)
+	}
+	if refs, err := referencingMigrations(dir, table); err != nil {
+		return nil, err
+	} else if len(refs) > 0 {
+		return nil, fmt.Errorf("%s is referenced by %s; remove those first", table, strings.Join(refs, ", "))
+	}
+
+	var files []File
+	del := func(path string) {
+		if _, err := os.Stat(filepath.Join(dir, path)); err == nil {
+			files = append(files, File{Path: path, Delete: true})
+		}
+	}
+	update := func(path string, content []byte) {
+		files = append(files, File{Path: path, Content: content, Update: true})
+	}
+
+	migrations, err := tableMigrations(dir, table)
+	if err != nil {
+		return nil, err
+	}
+	next, err := nextMigration(dir)
+	if err != nil {
+		return nil, err
+	}
+	latest := true
+	for _, m := range migrations {
+		if n, _ := migrationVersion(filepath.Base(m)); n != next-1 {
+			latest = false
+		}
+	}
+	if latest {
+		for _, m := range migrations {
+			del(m)
+		}
+	} else {
+		for _, dialect := range []string{"sqlite", "postgres"} {
+			files = append(files, File{
+				Path:    fmt.Sprintf("migrations/%s/%03d_drop_%s.sql", dialect, next, table),
+				Content: []byte(fmt.Sprintf("-- Drop %s table\nDROP TABLE IF EXISTS %s;\n", table, table)),
+			})
+		}
+	}
+
+	for _, path := range []string{
+		"query/" + table + ".sql",
+		"internal/store/" + table + ".sql.go",
+		"internal/store/storetest/" + res.Name + ".go",
+		"internal/service/" + res.Name + "_service.go",
+		"internal/handler/" + res.Name + "_handler.go",
+		"internal/handler/" + res.Name + "_handler_test.go",
+	} {
+		del(path)
+	}
+
+	storeDir := filepath.Join(dir, "internal/store")
+	models, err := os.ReadFile(filepath.Join(storeDir, "models.go"))
+	if err != nil {
+		return nil, err
+	}
+	if models, err = UpdateModels(models, res.Pascal(), ""); err != nil {
+		return nil, fmt.Errorf("update models.go: %w", err)
+	}
+	update("internal/store/models.go", models)
+
+	dbGo, querierGo, err := StoreIndex(storeDir, map[string][]byte{table + ".sql.go": nil})
+	if err != nil {
+		return nil, fmt.Errorf("update store index: %w", err)
+	}
+	update("internal/store/db.go", dbGo)
+	update("internal/store/querier.go", querierGo)
+
+	if spec, err := os.ReadFile(filepath.Join(dir, "api/openapi.yaml")); err == nil {
+		update("api/openapi.yaml", RemoveOpenAPI(spec, res))
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	wiring, err := Wiring(dir, "", res.Pascal())
+	if err != nil {
+		return nil, fmt.Errorf("update handler wiring: %w", err)
+	}
+	update("internal/handler/resources_gen.go", wiring)
+
+	return files, nil
+}
+
+// tableMigrations returns the migrations, relative to dir, that create
+// table in either dialect.
+func tableMigrations(dir, table string) ([]string, error) {
+	name := regexp.MustCompile(`^\d+_` + regexp.QuoteMeta(table) + `\.sql$`)
+	var paths []string
+	for _, dialect := range []string{"sqlite", "postgres"} {
+		entries, err := os.ReadDir(filepath.Join(dir, "migrations", dialect))
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			if name.MatchString(e.Name()) {
+				paths = append(paths, "migrations/"+dialect+"/"+e.Name())
+			}
+		}
+	}
+	return paths, nil
+}
+
+// referencingMigrations returns the SQLite migrations that declare a
+// foreign key to table.
+func referencingMigrations(dir, table string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "migrations/sqlite/*.sql"))
+	if err != nil {
+		return nil, err
+	}
+	ref := regexp.MustCompile(`(?i)REFERENCES\s+` + regexp.QuoteMeta(table) + `\s*\(`)
+	var refs []string
+	for _, p := range paths {
+		src, err := os.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		if ref.Match(src) {
+			refs = append(refs, filepath.Base(p))
+		}
+	}
+	slices.Sort(refs)
+	return refs, nil
+}
+
+// nextMigration returns the number after the highest existing migration.
+func nextMigration(dir string) (int, error) {
+	latest := 0
+	for _, dialect := range []string{"sqlite", "postgres"} {
+		entries, err := os.ReadDir(filepath.Join(dir, "migrations", dialect))
+		if err != nil {
+			return 0, fmt.Errorf("read migrations (run from the backend directory): %w", err)
+		}
+		for _, e := range entries {
+			if !strings.Contains(e.Name(), "_") || !strings.HasSuffix(e.Name(), ".sql") {
+				continue
+			}
+			n, err := migrationVersion(e.Name())
+			if err != nil {
+				return 0, err
+			}
+			latest = max(latest, n)
+		}
+	}
+	return latest + 1, nil
+}
+
+// migrationVersion returns the number a migration filename starts with.
+func migrationVersion(name string) (int, error) {
+	prefix, _, _ := strings.Cut(name, "_")
+	n, err := strconv.Atoi(prefix)
+	if err != nil {
+		return 0, fmt.Errorf("invalid migration filename %s", name)
+	}
+	return n, nil
+}
+
+var resourceEntryRe = regexp.MustCompile(`New(\w+)Handler\(service\.New\w+Service\(db, queries\)\)`)
+
+// Wiring returns internal/handler/resources_gen.go listing the resources
+// already wired in dir plus add, minus remove.
+func Wiring(dir, add, remove string) ([]byte, error) {
+	src, err := os.ReadFile(filepath.Join(dir, "internal/handler/resources_gen.go"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	var names []string
+	for _, m := range resourceEntryRe.FindAllSubmatch(src, -1) {
+		if name := string(m[1]); name != remove && name != add {
+			names = append(names, name)
+		}
+	}
+	if add != "" {
+		names = append(names, add)
+	}
+	slices.Sort(names)
+	return render("resources_gen.go.tmpl", names)
+}
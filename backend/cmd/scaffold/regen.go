@@ -0,0 +1,203 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Generated service, handler and test files divide their code into named
+// sections:
+//
+//	// scaffold:begin create
+//	func (s *SeasonService) Create(...) { ... }
+//	// scaffold:end create
+//
+// Regeneration replaces each section with its new version and keeps
+// everything outside the markers, so hand-written functions survive. Deleting
+// a section's markers takes ownership of it: the section is then neither
+// replaced nor added again.
+var regionRe = regexp.MustCompile(`(?m)^[ \t]*// scaffold:(begin|end) (\w+)[ \t]*\n`)
+
+type region struct {
+	name string // "" for code outside any section
+	text string
+}
+
+// parseRegions splits src into sections and the code between them.
+func parseRegions(src string) ([]region, error) {
+	var regions []region
+	pos := 0
+	open := ""
+	start := 0
+	for _, m := range regionRe.FindAllStringSubmatchIndex(src, -1) {
+		kind, name := src[m[2]:m[3]], src[m[4]:m[5]]
+		switch {
+		case kind == "begin" && open == "":
+			regions = append(regions, region{text: src[pos:m[0]]})
+			open, start = name, m[0]
+		case kind == "end" && name == open:
+			regions = append(regions, region{name: name, text: src[start:m[1]]})
+			open, pos = "", m[1]
+		default:
+			return nil, fmt.Errorf("unexpected scaffold:%s %s", kind, name)
+		}
+	}
+	if open != "" {
+		return nil, fmt.Errorf("scaffold:begin %s has no end", open)
+	}
+	return append(regions, region{text: src[pos:]}), nil
+}
+
+// MergeRegions returns the Go source existing with its sections replaced
+// by those in generated. Sections that are no longer generated are dropped;
+// new ones are appended unless existing already declares the same thing
+// outside the markers. The imports section keeps any import still used by
+// the hand-written code.
+func MergeRegions(existing, generated []byte) ([]byte, error) {
+	old, err := parseRegions(string(existing))
+	if err != nil {
+		return nil, fmt.Errorf("existing file: %w", err)
+	}
+	gen, err := parseRegions(string(generated))
+	if err != nil {
+		return nil, fmt.Errorf("generated file: %w", err)
+	}
+
+	genText := map[string]string{}
+	for _, r := range gen {
+		if r.name != "" {
+			genText[r.name] = r.text
+		}
+	}
+
+	var out strings.Builder
+	seen := map[string]bool{}
+	var oldImports string
+	for _, r := range old {
+		switch {
+		case r.name == "":
+			out.WriteString(r.text)
+		case genText[r.name] != "":
+			if r.name == "imports" {
+				oldImports = r.text
+			}
+			out.WriteString(genText[r.name])
+		}
+		seen[r.name] = true
+	}
+	for _, r := range gen {
+		if r.name == "" || seen[r.name] {
+			continue
+		}
+		if decl := firstDecl(r.text); decl != "" && regexp.MustCompile(`(?m)^`+regexp.QuoteMeta(decl)+`\b`).MatchString(out.String()) {
+			continue // owned: markers were removed from this section
+		}
+		out.WriteString("\n" + r.text)
+	}
+
+	merged := mergeImports(out.String(), oldImports, genText["imports"])
+	src, err := format.Source([]byte(merged))
+	if err != nil {
+		return nil, fmt.Errorf("format merged file: %w", err)
+	}
+	return src, nil
+}
+
+var declRe = regexp.MustCompile(`(?m)^(func (\([^)]*\) )?\w+|(type|var|const) \w+)`)
+
+// firstDecl returns the start of the first declaration in a section up to
+// its name, e.g. "func (s *SeasonService) Get".
+func firstDecl(text string) string {
+	return declRe.FindString(text)
+}
+
+var importLineRe = regexp.MustCompile(`(?m)^\s*(\w+ )?("[^"]+")\s*$`)
+
+// mergeImports rewrites the generated imports section in src to also
+// import the packages from oldImports that the rest of the file uses.
+func mergeImports(src, oldImports, genImports string) string {
+	if oldImports == "" || genImports == "" {
+		return src
+	}
+
+	type spec struct{ alias, path string }
+	parse := func(text string) []spec {
+		var specs []spec
+		for _, m := range importLineRe.FindAllStringSubmatch(text, -1) {
+			p, _ := strconv.Unquote(m[2])
+			specs = append(specs, spec{strings.TrimSpace(m[1]), p})
+		}
+		return specs
+	}
+	gen := parse(genImports)
+	extra := slices.DeleteFunc(parse(oldImports), func(s spec) bool { return slices.Contains(gen, s) })
+	if len(extra) == 0 {
+		return src
+	}
+
+	used := usedPackages(src)
+	var std, other []string
+	for _, s := range append(gen, extra...) {
+		name := s.alias
+		if name == "" {
+			name = importName(s.path)
+		}
+		if !slices.Contains(gen, s) && !used[name] {
+			continue
+		}
+		line := "\t" + strings.TrimSpace(s.alias+" "+strconv.Quote(s.path)) + "\n"
+		if strings.Contains(strings.Split(s.path, "/")[0], ".") {
+			other = append(other, line)
+		} else {
+			std = append(std, line)
+		}
+	}
+	slices.Sort(std)
+	slices.Sort(other)
+	block := "// scaffold:begin imports\nimport (\n" + strings.Join(std, "")
+	if len(std) > 0 && len(other) > 0 {
+		block += "\n"
+	}
+	block += strings.Join(other, "") + ")\n// scaffold:end imports\n"
+	return strings.Replace(src, genImports, block, 1)
+}
+
+// usedPackages returns the identifiers used as the left side of a selector
+// outside the import declarations, i.e. the package names src refers to.
+func usedPackages(src string) map[string]bool {
+	used := map[string]bool{}
+	file, err := parser.ParseFile(token.NewFileSet(), "", src, parser.SkipObjectResolution)
+	if err != nil {
+		return used
+	}
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok {
+				used[id.Name] = true
+			}
+		}
+		return true
+	})
+	return used
+}
+
+var versionSuffixRe = regexp.MustCompile(`^v\d+$|\.v\d+$`)
+
+// importName returns the default package name for an import path, e.g.
+// chi for github.com/go-chi/chi/v5 and yaml for gopkg.in/yaml.v3.
+func importName(p string) string {
+	base := path.Base(p)
+	if versionSuffixRe.MatchString(base) && strings.HasPrefix(base, "v") {
+		base = path.Base(path.Dir(p))
+	}
+	base = versionSuffixRe.ReplaceAllString(base, "")
+	return strings.ReplaceAll(base, "-", "")
+}
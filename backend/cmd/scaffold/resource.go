@@ -0,0 +1,455 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Resource describes the entity being scaffolded. Names are derived from
+// a singular snake_case Name, e.g. "line_item".
+//
+// A resource with a Parent belongs to a record of the parent table: it gets
+// a non-null foreign key to it, its routes are nested under the parent's
+// (/api/users/{userId}/line-items) and it is only visible through the
+// parent it belongs to.
+type Resource struct {
+	Name           string  `yaml:"name"`
+	Parent         string  `yaml:"parent"`           // table or resource name
+	ParentOnDelete string  `yaml:"parent_on_delete"` // cascade (default) or restrict
+	Fields         []Field `yaml:"fields"`
+}
+
+var identRe = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// reserved holds names that clash with existing resources or with packages
+// the generated code imports.
+var reserved = map[string]bool{
+	"user": true, "item": true, "admin": true, "backup": true, "health": true,
+	"apierror": true, "chi": true, "context": true, "errors": true, "handler": true,
+	"http": true, "json": true, "service": true, "slices": true, "slog": true,
+	"sql": true, "store": true, "strconv": true, "time": true, "uuid": true,
+}
+
+// defaultFields is used when no fields are given.
+var defaultFields = []Field{{Name: "name", Type: "string"}}
+
+// NewResource validates name and returns a resource with the default
+// fields.
+func NewResource(name string) (*Resource, error) {
+	name = toSnake(name)
+	if !identRe.MatchString(name) {
+		return nil, fmt.Errorf("invalid resource name %q: use letters, digits and underscores, starting with a letter", name)
+	}
+	if reserved[name] || token.IsKeyword(toCamel(name)) {
+		return nil, fmt.Errorf("resource name %q is reserved", name)
+	}
+
+	return &Resource{
+		Name:   name,
+		Fields: slices.Clone(defaultFields),
+	}, nil
+}
+
+// Validate checks the field definitions, fills in defaults and resolves
+// foreign keys against the tables created by the migrations in dir.
+func (r *Resource) Validate(dir string) error {
+	if len(r.Fields) == 0 {
+		r.Fields = slices.Clone(defaultFields)
+	}
+
+	tables, err := existingTables(dir)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	if r.Parent != "" && r.ParentField() == nil {
+		table := r.Parent
+		if !tables[table] {
+			table = pluralize(toSnake(r.Parent))
+		}
+		onDelete := strings.ToLower(strings.ReplaceAll(r.ParentOnDelete, "_", " "))
+		switch {
+		case !tables[table]:
+			errs = append(errs, fmt.Errorf("parent: unknown table %q", r.Parent))
+		case table == r.Table():
+			errs = append(errs, errors.New("parent: a resource cannot be its own parent"))
+		case onDelete == "set null":
+			errs = append(errs, errors.New("parent: on_delete set null is not allowed, every record needs a parent"))
+		default:
+			r.Fields = append([]Field{{
+				Name:       singularize(table) + "_id",
+				Type:       "ref",
+				References: table,
+				OnDelete:   onDelete,
+				parent:     true,
+			}}, r.Fields...)
+		}
+	} else if r.ParentOnDelete != "" && r.Parent == "" {
+		errs = append(errs, errors.New("parent_on_delete needs a parent"))
+	}
+	parentKey := ""
+	if p := r.ParentField(); p != nil {
+		parentKey = p.Name
+	}
+
+	seen := map[string]bool{}
+	for i := range r.Fields {
+		f := &r.Fields[i]
+		f.Name = toSnake(f.Name)
+		if f.Type == "" {
+			f.Type = "string"
+		}
+		fail := func(format string, args ...any) {
+			errs = append(errs, fmt.Errorf("field %s: %s", f.Name, fmt.Sprintf(format, args...)))
+		}
+
+		switch {
+		case !identRe.MatchString(f.Name):
+			fail("invalid name")
+		case f.Name == "id" || f.Name == "created_at" || f.Name == "updated_at":
+			fail("is added automatically")
+		case seen[f.Name] && f.Name == parentKey:
+			fail("is added for the parent")
+		case seen[f.Name]:
+			fail("is defined twice")
+		}
+		seen[f.Name] = true
+
+		if _, ok := fieldTypes[f.Type]; !ok {
+			fail("unknown type %q (want string, int, float, bool, time, enum or ref)", f.Type)
+			continue
+		}
+
+		if f.IsEnum() {
+			if len(f.Values) == 0 {
+				fail("enum needs values")
+			}
+			if f.Default != "" && !slices.Contains(f.Values, f.Default) {
+				fail("default %q is not one of the values", f.Default)
+			}
+		} else if len(f.Values) > 0 {
+			fail("values are only allowed for enum fields")
+		}
+
+		if f.IsRef() {
+			table := f.References
+			if !tables[table] {
+				table = pluralize(toSnake(f.References))
+			}
+			if !tables[table] {
+				fail("references unknown table %q", f.References)
+			}
+			f.References = table
+			f.refModel = toPascal(singularize(table))
+			if f.OnDelete == "" {
+				f.OnDelete = "cascade"
+				if f.Nullable {
+					f.OnDelete = "set null"
+				}
+			}
+			f.OnDelete = strings.ToLower(f.OnDelete)
+			switch f.OnDelete {
+			case "cascade", "restrict":
+			case "set null":
+				if !f.Nullable {
+					fail("on_delete set null requires nullable")
+				}
+			default:
+				fail("invalid on_delete %q (want cascade, restrict or set null)", f.OnDelete)
+			}
+			if f.Default != "" {
+				fail("references cannot have a default")
+			}
+		} else if f.References != "" || f.OnDelete != "" {
+			fail("references and on_delete are only allowed for ref fields")
+		}
+
+		if f.Default != "" {
+			if err := checkDefault(*f); err != nil {
+				fail("%v", err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func checkDefault(f Field) error {
+	var err error
+	switch f.Type {
+	case "int":
+		_, err = fmt.Sscanf(f.Default, "%d", new(int64))
+	case "float":
+		_, err = fmt.Sscanf(f.Default, "%g", new(float64))
+	case "bool":
+		if f.Default != "true" && f.Default != "false" {
+			err = errors.New("want true or false")
+		}
+	case "time":
+		if f.Default != "now" {
+			err = errors.New(`only "now" is supported`)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("invalid default %q: %v", f.Default, err)
+	}
+	return nil
+}
+
+var createTableRe = regexp.MustCompile(`(?i)CREATE TABLE (?:IF NOT EXISTS )?(\w+)`)
+
+// existingTables returns the tables created by the SQLite migrations.
+func existingTables(dir string) (map[string]bool, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "migrations/sqlite/*.sql"))
+	if err != nil {
+		return nil, err
+	}
+	tables := map[string]bool{}
+	for _, p := range paths {
+		src, err := os.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range createTableRe.FindAllSubmatch(src, -1) {
+			tables[string(m[1])] = true
+		}
+	}
+	return tables, nil
+}
+
+// Pascal returns the Go type name, e.g. LineItem.
+func (r *Resource) Pascal() string { return toPascal(r.Name) }
+
+// Camel returns the lowerCamel name, e.g. lineItem.
+func (r *Resource) Camel() string { return goVar(r.Name) }
+
+// Human returns the name for messages, e.g. "line item".
+func (r *Resource) Human() string { return strings.ReplaceAll(r.Name, "_", " ") }
+
+// HumanTitle returns Human with the first letter capitalised.
+func (r *Resource) HumanTitle() string { return upperFirst(r.Human()) }
+
+// A returns the singular name with its indefinite article, e.g. "an order".
+func (r *Resource) A() string {
+	if strings.ContainsRune("aeiou", rune(r.Name[0])) {
+		return "an " + r.Human()
+	}
+	return "a " + r.Human()
+}
+
+// Table returns the table name, e.g. line_items.
+func (r *Resource) Table() string { return pluralize(r.Name) }
+
+// PluralPascal returns the plural Go name used in query names, e.g.
+// LineItems.
+func (r *Resource) PluralPascal() string { return toPascal(r.Table()) }
+
+// PluralHuman returns the plural name for messages, e.g. "line items".
+func (r *Resource) PluralHuman() string { return strings.ReplaceAll(r.Table(), "_", " ") }
+
+// Tag returns the OpenAPI tag, e.g. "Line Items".
+func (r *Resource) Tag() string {
+	words := strings.Split(r.Table(), "_")
+	for i, w := range words {
+		words[i] = upperFirst(w)
+	}
+	return strings.Join(words, " ")
+}
+
+// Route returns the URL path segment, e.g. line-items.
+func (r *Resource) Route() string { return strings.ReplaceAll(r.Table(), "_", "-") }
+
+// Path returns the collection path under /api, e.g. line-items or, for a
+// resource with a parent, users/{userId}/line-items.
+func (r *Resource) Path() string {
+	if p := r.ParentField(); p != nil {
+		return fmt.Sprintf("%s/{%s}/%s", p.RefRoute(), p.JSONName(), r.Route())
+	}
+	return r.Route()
+}
+
+// ParentField returns the foreign key to the parent, or nil if the
+// resource has none. It is the first field once Validate has run.
+func (r *Resource) ParentField() *Field {
+	if len(r.Fields) > 0 && r.Fields[0].parent {
+		return &r.Fields[0]
+	}
+	return nil
+}
+
+// BodyFields returns the fields set through request bodies: all but the
+// parent key, which comes from the URL and never changes.
+func (r *Resource) BodyFields() []Field {
+	if r.ParentField() != nil {
+		return r.Fields[1:]
+	}
+	return r.Fields
+}
+
+// Columns returns every column in table order.
+func (r *Resource) Columns() []string {
+	cols := []string{"id"}
+	for _, f := range r.Fields {
+		cols = append(cols, f.Name)
+	}
+	return append(cols, "created_at", "updated_at")
+}
+
+// HasRequired reports whether any field must be present in create
+// requests.
+func (r *Resource) HasRequired() bool { return slices.ContainsFunc(r.BodyFields(), Field.Required) }
+
+// HasEnum reports whether any field is an enum.
+func (r *Resource) HasEnum() bool { return slices.ContainsFunc(r.Fields, Field.IsEnum) }
+
+// HasRef reports whether any field other than the parent key is a foreign
+// key.
+func (r *Resource) HasRef() bool { return slices.ContainsFunc(r.BodyFields(), Field.IsRef) }
+
+// HasUnique reports whether any field is unique.
+func (r *Resource) HasUnique() bool {
+	return slices.ContainsFunc(r.Fields, func(f Field) bool { return f.Unique })
+}
+
+// HasTime reports whether any field is a time.
+func (r *Resource) HasTime() bool {
+	return slices.ContainsFunc(r.Fields, func(f Field) bool { return f.Type == "time" })
+}
+
+// HasRequiredTime reports whether any non-null field is a time, which
+// fixtures must fill in.
+func (r *Resource) HasRequiredTime() bool {
+	return slices.ContainsFunc(r.Fields, func(f Field) bool { return f.Type == "time" && !f.Nullable })
+}
+
+// UniqueLabels returns the unique fields for messages, e.g. "name or code".
+func (r *Resource) UniqueLabels() string {
+	var labels []string
+	for _, f := range r.Fields {
+		if f.Unique {
+			labels = append(labels, strings.ToLower(f.Label()))
+		}
+	}
+	return strings.Join(labels, " or ")
+}
+
+// Queries returns the CRUD and count queries for the resource, plus a
+// lookup for each unique field.
+func (r *Resource) Queries() []Query {
+	p, pp, table, model := r.Pascal(), r.PluralPascal(), r.Table(), r.Pascal()
+
+	var cols, marks, sets []string
+	create := []Param{{Name: "id", Type: "string"}}
+	var update []Param
+	for _, f := range r.Fields {
+		cols = append(cols, f.Name)
+		marks = append(marks, "?")
+		create = append(create, Param{Name: f.Name, Type: f.StoreType()})
+		if !f.parent {
+			sets = append(sets, fmt.Sprintf("%s = COALESCE(?, %s)", f.Name, f.Name))
+			update = append(update, Param{Name: f.Name, Type: f.StoreType()})
+		}
+	}
+	update = append(update, Param{Name: "id", Type: "string"})
+	page := []Param{{Name: "limit", Type: "int64"}, {Name: "offset", Type: "int64"}}
+
+	// A child resource is listed and counted per parent.
+	var where string
+	var scope []Param
+	if p := r.ParentField(); p != nil {
+		where = " WHERE " + p.Name + " = ?"
+		scope = []Param{{Name: p.Name, Type: "string"}}
+	}
+
+	queries := []Query{
+		{
+			Name: "Create" + p, Cmd: ":one", Model: model, Params: create,
+			SQL: fmt.Sprintf("INSERT INTO %s (id, %s, created_at, updated_at)\nVALUES (?, %s, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)\nRETURNING *;",
+				table, strings.Join(cols, ", "), strings.Join(marks, ", ")),
+		},
+		{
+			Name: "Get" + p, Cmd: ":one", Model: model, Params: []Param{{Name: "id", Type: "string"}},
+			SQL: fmt.Sprintf("SELECT * FROM %s WHERE id = ? LIMIT 1;", table),
+		},
+	}
+	for _, f := range r.Fields {
+		if f.Unique {
+			queries = append(queries, Query{
+				Name: "Get" + p + "By" + f.GoName(), Cmd: ":one", Model: model,
+				Params: []Param{{Name: f.Name, Type: f.StoreType()}},
+				SQL:    fmt.Sprintf("SELECT * FROM %s WHERE %s = ? LIMIT 1;", table, f.Name),
+			})
+		}
+	}
+	return append(queries,
+		Query{
+			Name: "List" + pp, Cmd: ":many", Model: model, Params: append(scope, page...),
+			SQL: fmt.Sprintf("SELECT * FROM %s%s ORDER BY created_at DESC LIMIT ? OFFSET ?;", table, where),
+		},
+		Query{
+			Name: "Count" + pp, Cmd: ":one", Params: scope,
+			SQL: fmt.Sprintf("SELECT COUNT(*) FROM %s%s;", table, where),
+		},
+		Query{
+			Name: "Update" + p, Cmd: ":one", Model: model, Params: update,
+			SQL: fmt.Sprintf("UPDATE %s\nSET %s,\n    updated_at = CURRENT_TIMESTAMP\nWHERE id = ?\nRETURNING *;",
+				table, strings.Join(sets, ",\n    ")),
+		},
+		Query{
+			Name: "Delete" + p, Cmd: ":exec", Params: []Param{{Name: "id", Type: "string"}},
+			SQL: fmt.Sprintf("DELETE FROM %s WHERE id = ?;", table),
+		},
+	)
+}
+
+// ModelTypes returns the sqlc Go type of every column.
+func (r *Resource) ModelTypes() map[string]string {
+	types := map[string]string{
+		"id":         "string",
+		"created_at": "sql.NullTime",
+		"updated_at": "sql.NullTime",
+	}
+	for _, f := range r.Fields {
+		types[f.Name] = f.StoreType()
+	}
+	return types
+}
+
+// CheckFields returns the fields the service checks before writing:
+// foreign keys other than the parent key, which is checked separately, and
+// unique fields.
+func (r *Resource) CheckFields() []Field {
+	var fields []Field
+	for _, f := range r.Fields {
+		if f.IsRef() && !f.parent || f.Unique {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// CheckArgs returns the CheckFields as call arguments, e.g.
+// "params.OwnerID, params.Code".
+func (r *Resource) CheckArgs(prefix string) string {
+	var args []string
+	for _, f := range r.CheckFields() {
+		args = append(args, prefix+f.GoName())
+	}
+	return strings.Join(args, ", ")
+}
+
+// CheckParams returns the CheckFields as parameters, e.g.
+// "ownerID string, code string".
+func (r *Resource) CheckParams() string {
+	var params []string
+	for _, f := range r.CheckFields() {
+		params = append(params, goVar(f.Name)+" "+f.StoreType())
+	}
+	return strings.Join(params, ", ")
+}
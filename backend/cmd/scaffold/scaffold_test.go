@@ -0,0 +1,778 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const storeDir = "../../internal/store"
+
+func TestNaming(t *testing.T) {
+	tests := []struct {
+		in, snake, pascal, table, route string
+	}{
+		{"season", "season", "Season", "seasons", "seasons"},
+		{"LineItem", "line_item", "LineItem", "line_items", "line-items"},
+		{"line-item", "line_item", "LineItem", "line_items", "line-items"},
+		{"category", "category", "Category", "categories", "categories"},
+		{"day", "day", "Day", "days", "days"},
+		{"box", "box", "Box", "boxes", "boxes"},
+		{"user_id_map", "user_id_map", "UserIDMap", "user_id_maps", "user-id-maps"},
+	}
+	for _, tt := range tests {
+		res, err := NewResource(tt.in)
+		if err != nil {
+			t.Fatalf("NewResource(%q): %v", tt.in, err)
+		}
+		got := []string{res.Name, res.Pascal(), res.Table(), res.Route()}
+		want := []string{tt.snake, tt.pascal, tt.table, tt.route}
+		if strings.Join(got, " ") != strings.Join(want, " ") {
+			t.Errorf("NewResource(%q) = %v, want %v", tt.in, got, want)
+		}
+	}
+
+	for _, bad := range []string{"", "1st", "item", "service", "type", "bad$name"} {
+		if _, err := NewResource(bad); err == nil {
+			t.Errorf("NewResource(%q) should fail", bad)
+		}
+	}
+}
+
+// The store index must match sqlc's output exactly so `make gen-sql` after
+// scaffolding is a no-op.
+func TestStoreIndexMatchesSQLC(t *testing.T) {
+	dbGo, querierGo, err := StoreIndex(storeDir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertFile(t, filepath.Join(storeDir, "db.go"), dbGo)
+	assertFile(t, filepath.Join(storeDir, "querier.go"), querierGo)
+
+	models, err := os.ReadFile(filepath.Join(storeDir, "models.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	columns, types := sqlcModel(t, "Item")
+	got, err := UpdateModels(models, "Item", ModelStruct("Item", columns, types))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertFile(t, filepath.Join(storeDir, "models.go"), got)
+}
+
+// StoreFile must reproduce sqlc's output for the queries in items.sql,
+// given the shapes sqlc derived for them.
+func TestStoreFileMatchesSQLC(t *testing.T) {
+	columns, _ := sqlcModel(t, "Item")
+	queries := sqlcQueries(t, "items.sql")
+	if len(queries) == 0 {
+		t.Fatal("no queries in items.sql")
+	}
+
+	got, err := StoreFile(SQLCVersion(storeDir), "items.sql", queries, map[string][]string{"Item": columns})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertFile(t, filepath.Join(storeDir, "items.sql.go"), got)
+}
+
+var (
+	structFieldRe = regexp.MustCompile("(?m)^\\t\\w+\\s+(\\S+)\\s+`json:\"(\\w+)\"`$")
+	paramsRe      = regexp.MustCompile(`(?ms)^type (\w+)Params struct \{\n.*?^\}\n`)
+)
+
+// sqlcModel returns the columns of a model in sqlc's models.go, in table
+// order, and their Go types.
+func sqlcModel(t *testing.T, model string) ([]string, map[string]string) {
+	t.Helper()
+	src, err := os.ReadFile(filepath.Join(storeDir, "models.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range modelRe.FindAllSubmatch(src, -1) {
+		if string(m[1]) != model {
+			continue
+		}
+		var columns []string
+		types := map[string]string{}
+		for _, f := range structFieldRe.FindAllSubmatch(m[0], -1) {
+			columns = append(columns, string(f[2]))
+			types[string(f[2])] = string(f[1])
+		}
+		return columns, types
+	}
+	t.Fatalf("models.go has no %s", model)
+	return nil, nil
+}
+
+// sqlcQueries reads the queries in query/<source>, taking their parameters
+// and row types from the Go code sqlc generated for it.
+func sqlcQueries(t *testing.T, source string) []Query {
+	t.Helper()
+	src, err := os.ReadFile(filepath.Join("../../query", source))
+	if err != nil {
+		t.Fatal(err)
+	}
+	generated, err := os.ReadFile(filepath.Join(storeDir, source+".go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params := map[string][]Param{}
+	for _, m := range paramsRe.FindAllSubmatch(generated, -1) {
+		for _, f := range structFieldRe.FindAllSubmatch(m[0], -1) {
+			params[string(m[1])] = append(params[string(m[1])], Param{Name: string(f[2]), Type: string(f[1])})
+		}
+	}
+	methods := map[string][]string{}
+	for _, m := range methodRe.FindAllSubmatch(generated, -1) {
+		methods[string(m[1])] = []string{string(m[2]), string(m[3])}
+	}
+
+	var queries []Query
+	for _, block := range strings.Split(string(src), "-- name: ")[1:] {
+		header, sql, _ := strings.Cut(block, "\n")
+		fields := strings.Fields(header)
+		q := Query{Name: fields[0], Cmd: fields[1], SQL: strings.TrimSpace(sql)}
+		method, ok := methods[q.Name]
+		if !ok {
+			t.Fatalf("%s.go has no method %s", source, q.Name)
+		}
+
+		// The arguments are ctx and either a Params struct or one value.
+		args := strings.Split(strings.Trim(method[0], "()"), ", ")[1:]
+		if len(args) == 1 && strings.HasSuffix(args[0], " "+q.Name+"Params") {
+			q.Params = params[q.Name]
+		} else if len(args) == 1 {
+			name, typ, _ := strings.Cut(args[0], " ")
+			q.Params = []Param{{Name: toSnake(name), Type: typ}}
+		}
+
+		if model := strings.TrimPrefix(strings.TrimSuffix(strings.TrimPrefix(method[1], "("), ", error)"), "[]"); model != "int64" && model != "error" {
+			q.Model = model
+		}
+		queries = append(queries, q)
+	}
+	return queries
+}
+
+// querier.go imports database/sql and time only when a method needs them,
+// as sqlc does.
+func TestStoreIndexImports(t *testing.T) {
+	dir := t.TempDir()
+	db, err := os.ReadFile(filepath.Join(storeDir, "db.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writeFile(filepath.Join(dir, "db.go"), db); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		method   string
+		sql, tim bool
+	}{
+		{"func (q *Queries) CountThings(ctx context.Context) (int64, error) {", false, false},
+		{"func (q *Queries) GetThingNote(ctx context.Context, id string) (sql.NullString, error) {", true, false},
+		{"func (q *Queries) ListThingsSince(ctx context.Context, since time.Time) ([]Thing, error) {", false, true},
+	} {
+		_, querierGo, err := StoreIndex(dir, map[string][]byte{"things.sql.go": []byte(tt.method + "\n")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := bytes.Contains(querierGo, []byte(`"database/sql"`)); got != tt.sql {
+			t.Errorf("%s: imports database/sql = %v, want %v", tt.method, got, tt.sql)
+		}
+		if got := bytes.Contains(querierGo, []byte(`"time"`)); got != tt.tim {
+			t.Errorf("%s: imports time = %v, want %v", tt.method, got, tt.tim)
+		}
+	}
+}
+
+func TestParseField(t *testing.T) {
+	tests := []struct {
+		spec string
+		want Field
+	}{
+		{"title", Field{Name: "title", Type: "string"}},
+		{"count:int:default=3", Field{Name: "count", Type: "int", Default: "3"}},
+		{"code:string:unique:nullable", Field{Name: "code", Type: "string", Unique: true, Nullable: true}},
+		{"status:enum(draft|done):default=draft", Field{Name: "status", Type: "enum", Values: []string{"draft", "done"}, Default: "draft"}},
+		{"owner_id:ref(users):nullable:on_delete=set_null", Field{Name: "owner_id", Type: "ref", References: "users", Nullable: true, OnDelete: "set null"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseField(tt.spec)
+		if err != nil {
+			t.Errorf("ParseField(%q): %v", tt.spec, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseField(%q) = %+v, want %+v", tt.spec, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"status:enum(a|b", "x:string(1)", "x:int:indexed"} {
+		if _, err := ParseField(bad); err == nil {
+			t.Errorf("ParseField(%q) should fail", bad)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		field Field
+		err   string
+	}{
+		{Field{Name: "id"}, "added automatically"},
+		{Field{Name: "size", Type: "decimal"}, "unknown type"},
+		{Field{Name: "status", Type: "enum"}, "enum needs values"},
+		{Field{Name: "status", Type: "enum", Values: []string{"a"}, Default: "b"}, "not one of the values"},
+		{Field{Name: "count", Type: "int", Default: "many"}, "invalid default"},
+		{Field{Name: "team_id", Type: "ref", References: "teams"}, "unknown table"},
+		{Field{Name: "owner_id", Type: "ref", References: "users", OnDelete: "set null"}, "requires nullable"},
+		{Field{Name: "title", References: "users"}, "only allowed for ref fields"},
+	}
+	for _, tt := range tests {
+		res := &Resource{Name: "project", Fields: []Field{tt.field}}
+		if err := res.Validate("../.."); err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("Validate(%+v) = %v, want %q", tt.field, err, tt.err)
+		}
+	}
+
+	res := &Resource{Name: "project", Fields: []Field{
+		{Name: "owner_id", Type: "ref", References: "user"},
+		{Name: "item_id", Type: "ref", References: "items", Nullable: true},
+	}}
+	if err := res.Validate("../.."); err != nil {
+		t.Fatal(err)
+	}
+	owner, item := res.Fields[0], res.Fields[1]
+	if owner.References != "users" || owner.RefModel() != "User" || owner.OnDelete != "cascade" {
+		t.Errorf("owner_id resolved to %+v", owner)
+	}
+	if item.OnDelete != "set null" {
+		t.Errorf("nullable ref on_delete = %q, want set null", item.OnDelete)
+	}
+	if got, want := owner.Column("sqlite"), "owner_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE"; got != want {
+		t.Errorf("Column = %q, want %q", got, want)
+	}
+}
+
+func TestPlan(t *testing.T) {
+	dir := t.TempDir()
+	copyDir(t, "../../migrations", filepath.Join(dir, "migrations"))
+	copyDir(t, storeDir, filepath.Join(dir, "internal/store"))
+	copyDir(t, "../../api", filepath.Join(dir, "api"))
+	wiring, err := os.ReadFile("../../internal/handler/resources_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writeFile(filepath.Join(dir, "internal/handler/resources_gen.go"), wiring); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := NewResource("line_item")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Fields = nil
+	for _, spec := range []string{"sku:string:unique", "quantity:int:default=1", "state:enum(open|shipped):default=open", "item_id:ref(items)", "note:string:nullable"} {
+		f, err := ParseField(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Fields = append(res.Fields, f)
+	}
+	if err := res.Validate(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	version, err := nextMigration(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqliteMigration := fmt.Sprintf("migrations/sqlite/%03d_line_items.sql", version)
+	postgresMigration := fmt.Sprintf("migrations/postgres/%03d_line_items.sql", version)
+
+	files, err := Plan(dir, res, false)
+	if err != nil {
+		t.Fatalf("Plan: %v", err)
+	}
+
+	var paths []string
+	for _, f := range files {
+		paths = append(paths, f.Path)
+		if err := writeFile(filepath.Join(dir, f.Path), f.Content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, want := range []string{
+		sqliteMigration,
+		postgresMigration,
+		"query/line_items.sql",
+		"internal/store/line_items.sql.go",
+		"internal/store/querier.go",
+		"internal/service/line_item_service.go",
+		"internal/handler/line_item_handler.go",
+		"internal/handler/line_item_handler_test.go",
+		"internal/store/storetest/line_item.go",
+		"api/openapi.yaml",
+		"internal/handler/resources_gen.go",
+	} {
+		if !strings.Contains(strings.Join(paths, "\n"), want) {
+			t.Errorf("Plan did not generate %s", want)
+		}
+	}
+
+	migration, _ := os.ReadFile(filepath.Join(dir, postgresMigration))
+	for _, want := range []string{
+		"    sku TEXT NOT NULL UNIQUE,\n",
+		"    quantity BIGINT NOT NULL DEFAULT 1,\n",
+		"    state TEXT NOT NULL DEFAULT 'open' CHECK(state IN ('open', 'shipped')),\n",
+		"    item_id TEXT NOT NULL REFERENCES items(id) ON DELETE CASCADE,\n",
+		"    note TEXT,\n",
+		"CREATE INDEX IF NOT EXISTS idx_line_items_item_id ON line_items(item_id);",
+	} {
+		if !bytes.Contains(migration, []byte(want)) {
+			t.Errorf("migration is missing %q:\n%s", want, migration)
+		}
+	}
+
+	spec, _ := os.ReadFile(filepath.Join(dir, "api/openapi.yaml"))
+	if want := "        note:\n          type: [string, \"null\"]\n"; !bytes.Contains(spec, []byte(want)) {
+		t.Errorf("openapi.yaml is missing the nullable response type %q", want)
+	}
+
+	handlerTest, _ := os.ReadFile(filepath.Join(dir, "internal/handler/line_item_handler_test.go"))
+	if want := "\tbase := \"/" + res.Route() + "\"\n"; !bytes.Contains(handlerTest, []byte(want)) {
+		t.Errorf("handler test is missing %q", want)
+	}
+
+	querier, _ := os.ReadFile(filepath.Join(dir, "internal/store/querier.go"))
+	for _, want := range []string{
+		"\tListLineItems(ctx context.Context, arg ListLineItemsParams) ([]LineItem, error)\n",
+		"\tGetLineItemBySku(ctx context.Context, sku string) (LineItem, error)\n",
+	} {
+		if !bytes.Contains(querier, []byte(want)) {
+			t.Errorf("querier.go is missing %q", want)
+		}
+	}
+
+	if _, err := Plan(dir, res, false); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("second Plan error = %v, want already exists", err)
+	}
+
+	// Regenerate with an extra field, keeping a hand-written method.
+	servicePath := filepath.Join(dir, "internal/service/line_item_service.go")
+	service, _ := os.ReadFile(servicePath)
+	custom := "\n// Ship marks a line item as shipped.\nfunc (s *LineItemService) Ship(ctx context.Context, id string) error {\n\treturn nil\n}\n"
+	if err := writeFile(servicePath, append(service, custom...)); err != nil {
+		t.Fatal(err)
+	}
+	price, _ := ParseField("price:float:nullable")
+	res.Fields = append(res.Fields, price)
+	if err := res.Validate(dir); err != nil {
+		t.Fatal(err)
+	}
+	files, err = Plan(dir, res, true)
+	if err != nil {
+		t.Fatalf("regen Plan: %v", err)
+	}
+	for _, f := range files {
+		if strings.HasPrefix(f.Path, "migrations/") {
+			t.Errorf("regen rewrote migration %s", f.Path)
+		}
+		if !f.Update {
+			t.Errorf("regen should only update files, got new file %s", f.Path)
+		}
+		if err := writeFile(filepath.Join(dir, f.Path), f.Content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	service, _ = os.ReadFile(servicePath)
+	service = regexp.MustCompile(`[ \t]+`).ReplaceAll(service, []byte(" "))
+	for _, want := range []string{"func (s *LineItemService) Ship(", "Price *float64"} {
+		if !bytes.Contains(service, []byte(want)) {
+			t.Errorf("regenerated service is missing %q", want)
+		}
+	}
+
+	// Removing the resource restores the shared files, once nothing refers
+	// to it.
+	ref := filepath.Join(dir, "migrations/sqlite/999_refs.sql")
+	if err := writeFile(ref, []byte("CREATE TABLE refs (line_item_id TEXT REFERENCES line_items(id));\n")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Remove(dir, "line_item"); err == nil || !strings.Contains(err.Error(), "999_refs.sql") {
+		t.Errorf("Remove of a referenced table error = %v, want referenced by 999_refs.sql", err)
+	}
+	if err := os.Remove(ref); err != nil {
+		t.Fatal(err)
+	}
+	files, err = Remove(dir, "line_item")
+	if err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	for _, f := range files {
+		path := filepath.Join(dir, f.Path)
+		if f.Delete {
+			err = os.Remove(path)
+		} else {
+			err = writeFile(path, f.Content)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, path := range []string{sqliteMigration, "internal/service/line_item_service.go", "internal/store/line_items.sql.go"} {
+		if _, err := os.Stat(filepath.Join(dir, path)); err == nil {
+			t.Errorf("Remove left %s", path)
+		}
+	}
+	for _, path := range []string{"internal/store/models.go", "internal/store/querier.go", "internal/store/db.go", "api/openapi.yaml", "internal/handler/resources_gen.go"} {
+		got, _ := os.ReadFile(filepath.Join(dir, path))
+		assertFile(t, filepath.Join("../..", path), got)
+	}
+}
+
+// The generated code must build, vet and pass its own tests, for a
+// top-level resource and for one nested under a parent.
+func TestGeneratedCodeBuilds(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds a copy of the module")
+	}
+	dir := t.TempDir()
+	copyDir(t, "../..", dir)
+
+	for _, res := range []*Resource{
+		{Name: "line_item"},
+		{Name: "note", Parent: "items"},
+	} {
+		for _, spec := range []string{"sku:string:unique", "quantity:int:default=1", "state:enum(open|shipped):default=open", "owner_id:ref(users):nullable", "due_at:time:nullable", "price:float:nullable", "active:bool:default=true"} {
+			f, err := ParseField(spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res.Fields = append(res.Fields, f)
+		}
+		if err := res.Validate(dir); err != nil {
+			t.Fatal(err)
+		}
+		files, err := Plan(dir, res, false)
+		if err != nil {
+			t.Fatalf("Plan %s: %v", res.Name, err)
+		}
+		for _, f := range files {
+			if err := writeFile(filepath.Join(dir, f.Path), f.Content); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	for _, args := range [][]string{
+		{"build", "./..."},
+		{"vet", "./..."},
+		{"test", "-count=1", "-run", "^Test(LineItem|Note)", "./internal/handler"},
+	} {
+		cmd := exec.Command("go", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Errorf("go %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+}
+
+func TestMergeRegions(t *testing.T) {
+	existing := `package demo
+
+// scaffold:begin imports
+import (
+	"fmt"
+	"strings"
+)
+// scaffold:end imports
+
+// scaffold:begin get
+
+// Get is generated.
+func Get() string { return fmt.Sprint("old") }
+// scaffold:end get
+
+// Shout is hand-written.
+func Shout(s string) string { return strings.ToUpper(s) }
+
+// List was generated, but its markers were removed to keep local edits.
+func List() []string { return nil }
+
+// scaffold:begin stale
+func Stale() {}
+// scaffold:end stale
+`
+	generated := `package demo
+
+// scaffold:begin imports
+import (
+	"fmt"
+)
+// scaffold:end imports
+
+// scaffold:begin get
+
+// Get is generated.
+func Get() string { return fmt.Sprint("new") }
+// scaffold:end get
+
+// scaffold:begin list
+func List() []string { return []string{} }
+// scaffold:end list
+
+// scaffold:begin count
+func Count() int { return 0 }
+// scaffold:end count
+`
+	got, err := MergeRegions([]byte(existing), []byte(generated))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"strings"`, `fmt.Sprint("new")`, "func Shout(", "func List() []string { return nil }", "func Count() int"} {
+		if !strings.Contains(string(got), want) {
+			t.Errorf("merged file is missing %q:\n%s", want, got)
+		}
+	}
+	for _, unwanted := range []string{`fmt.Sprint("old")`, "Stale", "return []string{}"} {
+		if strings.Contains(string(got), unwanted) {
+			t.Errorf("merged file still has %q:\n%s", unwanted, got)
+		}
+	}
+
+	if _, err := MergeRegions([]byte("package demo\n// scaffold:begin get\n"), []byte(generated)); err == nil {
+		t.Error("unterminated section should fail")
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	old := "a\nb\nc\nd\ne\nf\ng\nh\ni\n"
+	new := "a\nb\nc\nd\nE\nf\ng\nh\ni\nj\n"
+	want := `--- a/x.txt
++++ b/x.txt
+@@ -2,8 +2,9 @@
+ b
+ c
+ d
+-e
++E
+ f
+ g
+ h
+ i
++j
+`
+	if got := UnifiedDiff("x.txt", []byte(old), []byte(new)); got != want {
+		t.Errorf("UnifiedDiff =\n%s\nwant\n%s", got, want)
+	}
+	if got := UnifiedDiff("x.txt", []byte(old), []byte(old)); got != "" {
+		t.Errorf("diff of equal files = %q", got)
+	}
+	if got := UnifiedDiff("x.txt", nil, []byte("a\n")); !strings.HasPrefix(got, "--- /dev/null\n+++ b/x.txt\n@@ -0,0 +1 @@\n+a\n") {
+		t.Errorf("diff of a new file = %q", got)
+	}
+}
+
+func TestWiring(t *testing.T) {
+	got, err := Wiring("../..", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertFile(t, "../../internal/handler/resources_gen.go", got)
+
+	dir := t.TempDir()
+	for _, step := range []struct{ add, remove, want string }{
+		{"Season", "", "Season"},
+		{"Award", "", "Award Season"},
+		{"", "Season", "Award"},
+	} {
+		got, err := Wiring(dir, step.add, step.remove)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := writeFile(filepath.Join(dir, "internal/handler/resources_gen.go"), got); err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, m := range resourceEntryRe.FindAllSubmatch(got, -1) {
+			names = append(names, string(m[1]))
+		}
+		if strings.Join(names, " ") != step.want {
+			t.Errorf("after +%q -%q: wired %v, want %s", step.add, step.remove, names, step.want)
+		}
+	}
+}
+
+func TestUpdateOpenAPI(t *testing.T) {
+	src, err := os.ReadFile("../../api/openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := &Resource{Name: "project", Fields: []Field{{Name: "title", Unique: true}}}
+	if err := res.Validate("../.."); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := UpdateOpenAPI(src, res)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every original line is kept, in order.
+	orig := strings.SplitAfter(string(src), "\n")
+	i := 0
+	for _, line := range strings.SplitAfter(string(got), "\n") {
+		if i < len(orig) && line == orig[i] {
+			i++
+		}
+	}
+	if i != len(orig) {
+		t.Errorf("original line %d %q was not preserved", i+1, orig[i])
+	}
+
+	var spec struct {
+		Paths      map[string]map[string]any
+		Components struct {
+			Parameters map[string]any
+			Schemas    map[string]any
+		}
+	}
+	if err := yaml.Unmarshal(got, &spec); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{"/api/projects", "/api/projects/{id}"} {
+		if spec.Paths[path] == nil {
+			t.Errorf("missing path %s", path)
+		}
+	}
+	if spec.Components.Parameters["ProjectIdParam"] == nil {
+		t.Error("missing ProjectIdParam")
+	}
+	for _, name := range []string{"Project", "CreateProjectRequest", "UpdateProjectRequest", "ProjectListResponse"} {
+		if spec.Components.Schemas[name] == nil {
+			t.Errorf("missing schema %s", name)
+		}
+	}
+	if !strings.Contains(string(got), "    ItemListResponse:") || strings.Index(string(got), "    ProjectListResponse:") < strings.Index(string(got), "    ItemListResponse:") {
+		t.Error("schemas should follow the existing list responses")
+	}
+
+	if _, err := UpdateOpenAPI(got, res); err == nil {
+		t.Error("second UpdateOpenAPI should fail")
+	}
+	if removed := RemoveOpenAPI(got, res); !bytes.Equal(removed, src) {
+		t.Errorf("RemoveOpenAPI did not restore the spec:\n%s", UnifiedDiff("openapi.yaml", src, removed))
+	}
+}
+
+func TestParent(t *testing.T) {
+	res := &Resource{Name: "note", Parent: "user", ParentOnDelete: "restrict", Fields: []Field{{Name: "title"}}}
+	if err := res.Validate("../.."); err != nil {
+		t.Fatal(err)
+	}
+
+	parent := res.ParentField()
+	if parent == nil || parent.Name != "user_id" || parent.References != "users" || parent.RefModel() != "User" {
+		t.Fatalf("ParentField = %+v", parent)
+	}
+	if got, want := parent.Column("sqlite"), "user_id TEXT NOT NULL REFERENCES users(id) ON DELETE RESTRICT"; got != want {
+		t.Errorf("Column = %q, want %q", got, want)
+	}
+	if got, want := res.Path(), "users/{userId}/notes"; got != want {
+		t.Errorf("Path = %q, want %q", got, want)
+	}
+	if len(res.BodyFields()) != 1 || len(res.CheckFields()) != 0 {
+		t.Errorf("BodyFields = %v, CheckFields = %v; the parent key is neither", res.BodyFields(), res.CheckFields())
+	}
+
+	// Validating again does not add a second key.
+	if err := res.Validate("../.."); err != nil || len(res.Fields) != 2 {
+		t.Errorf("second Validate: %v, fields %v", err, res.Fields)
+	}
+
+	queries := map[string]string{}
+	for _, q := range res.Queries() {
+		queries[q.Name] = q.SQL
+	}
+	for name, want := range map[string]string{
+		"ListNotes":  "SELECT * FROM notes WHERE user_id = ? ORDER BY created_at DESC LIMIT ? OFFSET ?;",
+		"CountNotes": "SELECT COUNT(*) FROM notes WHERE user_id = ?;",
+		"UpdateNote": "UPDATE notes\nSET title = COALESCE(?, title),\n    updated_at = CURRENT_TIMESTAMP\nWHERE id = ?\nRETURNING *;",
+	} {
+		if queries[name] != want {
+			t.Errorf("%s = %q, want %q", name, queries[name], want)
+		}
+	}
+
+	src, err := os.ReadFile("../../api/openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec, err := UpdateOpenAPI(src, res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"  /api/users/{userId}/notes:\n", "  /api/users/{userId}/notes/{id}:\n", "    NoteParentIdParam:\n      name: userId\n"} {
+		if !bytes.Contains(spec, []byte(want)) {
+			t.Errorf("openapi.yaml is missing %q", want)
+		}
+	}
+	if removed := RemoveOpenAPI(spec, &Resource{Name: "note"}); !bytes.Equal(removed, src) {
+		t.Errorf("RemoveOpenAPI did not restore the spec:\n%s", UnifiedDiff("openapi.yaml", src, removed))
+	}
+
+	for _, bad := range []*Resource{
+		{Name: "note", Parent: "nothing"},
+		{Name: "note", Parent: "notes"},
+		{Name: "note", Parent: "users", ParentOnDelete: "set null"},
+		{Name: "note", ParentOnDelete: "cascade"},
+		{Name: "note", Parent: "users", Fields: []Field{{Name: "user_id"}}},
+	} {
+		if err := bad.Validate("../.."); err == nil {
+			t.Errorf("Validate(%+v) should fail", bad)
+		}
+	}
+}
+
+func assertFile(t *testing.T, path string, got []byte) {
+	t.Helper()
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("generated %s differs from the checked-in file:\n%s", filepath.Base(path), got)
+	}
+}
+
+func copyDir(t *testing.T, src, dest string) {
+	t.Helper()
+	err := filepath.WalkDir(src, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, _ := filepath.Rel(src, path)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return writeFile(filepath.Join(dest, rel), content)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
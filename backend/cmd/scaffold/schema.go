@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fieldFlags collects repeated -field flags.
+type fieldFlags []string
+
+func (f *fieldFlags) String() string     { return strings.Join(*f, " ") }
+func (f *fieldFlags) Set(v string) error { *f = append(*f, v); return nil }
+
+// ParseField parses a command-line field definition:
+//
+//	name[:type][:option...]
+//
+// where type is string (the default), int, float, bool, time,
+// enum(a|b|c) or ref(table), and the options are nullable, unique,
+// default=<value> and on_delete=cascade|restrict|set_null.
+func ParseField(spec string) (Field, error) {
+	parts := strings.Split(spec, ":")
+	f := Field{Name: parts[0], Type: "string"}
+
+	if len(parts) > 1 {
+		typ := parts[1]
+		if name, arg, ok := strings.Cut(typ, "("); ok {
+			if !strings.HasSuffix(arg, ")") {
+				return f, fmt.Errorf("field %q: unterminated %s(", spec, name)
+			}
+			arg = strings.TrimSuffix(arg, ")")
+			switch name {
+			case "enum":
+				f.Values = strings.Split(arg, "|")
+			case "ref":
+				f.References = arg
+			default:
+				return f, fmt.Errorf("field %q: type %s takes no arguments", spec, name)
+			}
+			typ = name
+		}
+		f.Type = typ
+	}
+
+	for _, opt := range parts[min(len(parts), 2):] {
+		key, value, _ := strings.Cut(opt, "=")
+		switch key {
+		case "nullable":
+			f.Nullable = true
+		case "unique":
+			f.Unique = true
+		case "default":
+			f.Default = value
+		case "on_delete", "ondelete":
+			f.OnDelete = strings.ReplaceAll(value, "_", " ")
+		default:
+			return f, fmt.Errorf("field %q: unknown option %q", spec, opt)
+		}
+	}
+	return f, nil
+}
+
+// LoadSchema reads a resource definition from a YAML file:
+//
+//	name: project
+//	fields:
+//	  - name: title
+//	    type: string
+//	    unique: true
+//	  - name: status
+//	    type: enum
+//	    values: [draft, active, archived]
+//	    default: draft
+//	  - name: owner_id
+//	    type: ref
+//	    references: users
+//	    on_delete: cascade
+//
+// A nested resource also sets parent and, optionally, parent_on_delete:
+//
+//	name: note
+//	parent: users
+//	parent_on_delete: restrict
+func LoadSchema(path string) (*Resource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var res Resource
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&res); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return &res, nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var fixtureExts = []string{".json", ".yaml", ".yml"}
+
+// LoadFixtures reads the datasets in path, a fixture file or a directory of
+// them, and merges them in file name order.
+func LoadFixtures(path string) (*Dataset, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		files = nil
+		for _, e := range entries {
+			if !e.IsDir() && slices.Contains(fixtureExts, filepath.Ext(e.Name())) {
+				files = append(files, filepath.Join(path, e.Name()))
+			}
+		}
+	}
+
+	d := &Dataset{}
+	for _, file := range files {
+		f, err := loadFixtureFile(file)
+		if err != nil {
+			return nil, err
+		}
+		d.Users = append(d.Users, f.Users...)
+	}
+	return d, nil
+}
+
+func loadFixtureFile(path string) (*Dataset, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var d Dataset
+	switch ext := filepath.Ext(path); ext {
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&d)
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&d)
+	default:
+		return nil, fmt.Errorf("%s: fixtures must be %s files", path, strings.Join(fixtureExts, ", "))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &d, nil
+}
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/keel/api/internal/rank"
+	"github.com/keel/api/internal/store"
+)
+
+// Dataset is a set of users and their items. Generated data and fixture
+// files share this shape.
+type Dataset struct {
+	Users []UserSeed `json:"users" yaml:"users"`
+}
+
+// UserSeed is a user to seed. ID defaults to one derived from the email and
+// Role to "user".
+type UserSeed struct {
+	ID    string     `json:"id,omitempty" yaml:"id,omitempty"`
+	Email string     `json:"email" yaml:"email"`
+	Name  string     `json:"name" yaml:"name"`
+	Role  string     `json:"role,omitempty" yaml:"role,omitempty"`
+	Items []ItemSeed `json:"items,omitempty" yaml:"items,omitempty"`
+}
+
+// ItemSeed is an item owned by the enclosing user. ID defaults to one
+// derived from the owner and the item's position, Status to "pending".
+type ItemSeed struct {
+	ID          string `json:"id,omitempty" yaml:"id,omitempty"`
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Status      string `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+// Size is how much data Generate produces.
+type Size struct {
+	Users int
+	// MaxItems is the most items one user gets; each gets 0 to MaxItems.
+	MaxItems int
+}
+
+// Sizes are the sizes accepted by -size. "none" seeds fixtures only.
+var Sizes = map[string]Size{
+	"none":   {},
+	"small":  {Users: 10, MaxItems: 5},
+	"medium": {Users: 100, MaxItems: 10},
+	"large":  {Users: 1000, MaxItems: 25},
+}
+
+var (
+	itemStatuses = []string{"pending", "in_progress", "completed"}
+	userRoles    = []string{"user", "admin"}
+
+	// seedNamespace derives stable IDs, so re-seeding updates rows in place
+	// instead of adding new ones.
+	seedNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://github.com/keel/api/cmd/seed"))
+)
+
+var (
+	firstNames = []string{
+		"Ada", "Alan", "Barbara", "Claude", "Donald", "Edsger", "Frances", "Grace",
+		"Hedy", "Ivan", "Jean", "Ken", "Katherine", "Linus", "Margaret", "Niklaus",
+		"Radia", "Rob", "Shafi", "Tim", "Vera", "Vint", "Whitfield", "Yukihiro",
+	}
+	lastNames = []string{
+		"Lovelace", "Turing", "Liskov", "Shannon", "Knuth", "Dijkstra", "Allen",
+		"Hopper", "Lamarr", "Sutherland", "Sammet", "Thompson", "Johnson",
+		"Torvalds", "Hamilton", "Wirth", "Perlman", "Pike", "Goldwasser",
+		"Berners-Lee", "Cerf", "Molnar", "Diffie", "Matsumoto", "Ritchie",
+	}
+	verbs = []string{
+		"Review", "Draft", "Update", "Fix", "Plan", "Write", "Refactor", "Test",
+		"Schedule", "Publish", "Archive", "Prepare", "Migrate", "Document",
+	}
+	nouns = []string{
+		"release notes", "onboarding guide", "billing page", "search index",
+		"quarterly report", "login flow", "team offsite", "API docs",
+		"database backups", "design review", "support queue", "roadmap",
+		"dashboard", "invoice template", "landing page", "error budget",
+	}
+	details = []string{
+		"Blocked on feedback from the design team.",
+		"Needs a second pair of eyes before Friday.",
+		"Follow up with support about the open tickets.",
+		"Split into smaller tasks if this drags on.",
+		"Check the numbers against last quarter.",
+		"Low priority, pick up when things are quiet.",
+	}
+)
+
+// Generate returns the size's users and items. The same seed and size
+// always give the same data, IDs included.
+func Generate(seed uint64, size Size) *Dataset {
+	r := rand.New(rand.NewPCG(seed, seed))
+	d := &Dataset{Users: make([]UserSeed, size.Users)}
+
+	for i := range d.Users {
+		first := pick(r, firstNames)
+		last := pick(r, lastNames)
+		u := UserSeed{
+			// The index keeps emails unique when names repeat.
+			Email: fmt.Sprintf("%s.%s.%d@example.com", strings.ToLower(first), strings.ToLower(last), i+1),
+			Name:  first + " " + last,
+			Role:  "user",
+		}
+		if r.IntN(10) == 0 {
+			u.Role = "admin"
+		}
+
+		u.Items = make([]ItemSeed, r.IntN(size.MaxItems+1))
+		for j := range u.Items {
+			item := ItemSeed{
+				Title:  pick(r, verbs) + " " + pick(r, nouns),
+				Status: pick(r, itemStatuses),
+			}
+			if r.IntN(2) == 0 {
+				item.Description = pick(r, details)
+			}
+			u.Items[j] = item
+		}
+		d.Users[i] = u
+	}
+	return d
+}
+
+func pick(r *rand.Rand, s []string) string {
+	return s[r.IntN(len(s))]
+}
+
+// normalize fills in defaults and derived IDs and checks the dataset.
+func (d *Dataset) normalize() error {
+	emails := make(map[string]bool)
+	for i := range d.Users {
+		u := &d.Users[i]
+		if u.Email == "" || u.Name == "" {
+			return fmt.Errorf("user %d: email and name are required", i+1)
+		}
+		if emails[u.Email] {
+			return fmt.Errorf("user %s appears twice", u.Email)
+		}
+		emails[u.Email] = true
+
+		if u.ID == "" {
+			u.ID = uuid.NewSHA1(seedNamespace, []byte("user:"+u.Email)).String()
+		}
+		if u.Role == "" {
+			u.Role = "user"
+		}
+		if !slices.Contains(userRoles, u.Role) {
+			return fmt.Errorf("user %s: role must be one of %s", u.Email, strings.Join(userRoles, ", "))
+		}
+
+		for j := range u.Items {
+			item := &u.Items[j]
+			if item.Title == "" {
+				return fmt.Errorf("user %s, item %d: title is required", u.Email, j+1)
+			}
+			if item.ID == "" {
+				item.ID = uuid.NewSHA1(seedNamespace, fmt.Appendf(nil, "item:%s:%d", u.ID, j)).String()
+			}
+			if item.Status == "" {
+				item.Status = "pending"
+			}
+			if !slices.Contains(itemStatuses, item.Status) {
+				return fmt.Errorf("user %s, item %q: status must be one of %s", u.Email, item.Title, strings.Join(itemStatuses, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// Result counts the rows Apply wrote.
+type Result struct {
+	UsersCreated, UsersUpdated int
+	ItemsCreated, ItemsUpdated int
+}
+
+// Apply writes the dataset in one transaction. Rows that already exist are
+// updated to match, so applying the same dataset twice leaves the database
+// as it was after the first time. Other rows are not touched.
+func Apply(ctx context.Context, db *sql.DB, queries store.Store, d *Dataset) (*Result, error) {
+	if err := d.normalize(); err != nil {
+		return nil, err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+	q := queries.InTx(tx)
+
+	var res Result
+	for _, u := range d.Users {
+		created, err := applyUser(ctx, q, u)
+		if err != nil {
+			return nil, fmt.Errorf("user %s: %w", u.Email, err)
+		}
+		if created {
+			res.UsersCreated++
+		} else {
+			res.UsersUpdated++
+		}
+
+		for _, item := range u.Items {
+			created, err := applyItem(ctx, q, u.ID, item)
+			if err != nil {
+				return nil, fmt.Errorf("user %s, item %q: %w", u.Email, item.Title, err)
+			}
+			if created {
+				res.ItemsCreated++
+			} else {
+				res.ItemsUpdated++
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// applyUser creates or updates u, reporting whether it was created.
+func applyUser(ctx context.Context, q store.Store, u UserSeed) (bool, error) {
+	byEmail, err := q.GetUserByEmail(ctx, u.Email)
+	switch {
+	case err == nil && byEmail.ID != u.ID:
+		return false, fmt.Errorf("email is already used by user %s", byEmail.ID)
+	case err != nil && !errors.Is(err, sql.ErrNoRows):
+		return false, err
+	}
+
+	_, err = q.GetUser(ctx, u.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		_, err = q.CreateUser(ctx, store.CreateUserParams{ID: u.ID, Email: u.Email, Name: u.Name, Role: u.Role})
+		return true, err
+	}
+	if err != nil {
+		return false, err
+	}
+	_, err = q.UpdateUser(ctx, store.UpdateUserParams{ID: u.ID, Email: u.Email, Name: u.Name, Role: u.Role})
+	return false, err
+}
+
+// applyItem creates or updates item, reporting whether it was created.
+func applyItem(ctx context.Context, q store.Store, userID string, item ItemSeed) (bool, error) {
+	existing, err := q.GetItem(ctx, item.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		// New items go last in the manual order
+		last, err := q.GetLastRankedItem(ctx)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return false, err
+		}
+		itemRank, err := rank.Between(last.Rank, "")
+		if err != nil {
+			return false, err
+		}
+		_, err = q.CreateItem(ctx, store.CreateItemParams{
+			ID:          item.ID,
+			UserID:      userID,
+			Title:       item.Title,
+			Description: sql.NullString{String: item.Description, Valid: item.Description != ""},
+			Status:      item.Status,
+			Priority:    "normal",
+			Rank:        itemRank,
+		})
+		return true, err
+	}
+	if err != nil {
+		return false, err
+	}
+	if existing.UserID != userID {
+		return false, fmt.Errorf("item %s belongs to another user", item.ID)
+	}
+
+	_, err = q.UpdateItem(ctx, store.UpdateItemParams{
+		ID:          item.ID,
+		Title:       item.Title,
+		Description: sql.NullString{String: item.Description, Valid: item.Description != ""},
+		Status:      item.Status,
+		DueAt:       existing.DueAt,
+		Priority:    existing.Priority,
+		AssigneeID:  existing.AssigneeID,
+	})
+	return false, err
+}
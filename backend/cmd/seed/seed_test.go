@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/keel/api/internal/database"
+	"github.com/keel/api/internal/store"
+	"github.com/keel/api/internal/store/storetest"
+)
+
+func TestGenerate(t *testing.T) {
+	a := Generate(7, Sizes["medium"])
+	if !reflect.DeepEqual(a, Generate(7, Sizes["medium"])) {
+		t.Error("same seed gave different data")
+	}
+	if reflect.DeepEqual(a, Generate(8, Sizes["medium"])) {
+		t.Error("different seeds gave the same data")
+	}
+	if len(a.Users) != 100 {
+		t.Errorf("medium has %d users, want 100", len(a.Users))
+	}
+	if err := a.normalize(); err != nil {
+		t.Errorf("generated data is invalid: %v", err)
+	}
+	if n := len(Generate(7, Sizes["none"]).Users); n != 0 {
+		t.Errorf("none has %d users", n)
+	}
+}
+
+func TestApply(t *testing.T) {
+	storetest.ForEachDialect(t, func(t *testing.T, db *database.DB, s store.Store) {
+		ctx := context.Background()
+		unrelated := storetest.CreateItem(t, s)
+
+		res, err := Apply(ctx, db.Writer, s, Generate(1, Sizes["small"]))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.UsersCreated != 10 || res.UsersUpdated != 0 || res.ItemsUpdated != 0 {
+			t.Errorf("first run = %+v", res)
+		}
+		users, items := counts(t, s)
+
+		// Seeding again updates the same rows.
+		again, err := Apply(ctx, db.Writer, s, Generate(1, Sizes["small"]))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := Result{UsersUpdated: res.UsersCreated, ItemsUpdated: res.ItemsCreated}
+		if *again != want {
+			t.Errorf("second run = %+v, want %+v", again, want)
+		}
+		if u, i := counts(t, s); u != users || i != items {
+			t.Errorf("second run changed counts from %d/%d to %d/%d", users, items, u, i)
+		}
+
+		if _, err := s.GetItem(ctx, unrelated.ID); err != nil {
+			t.Errorf("unrelated item: %v", err)
+		}
+
+		// A taken email fails the whole run.
+		taken := &Dataset{Users: []UserSeed{
+			{Email: "fresh@example.com", Name: "Fresh"},
+			{Email: unrelated.UserID + "@example.com", Name: "Taken"},
+		}}
+		if _, err := Apply(ctx, db.Writer, s, taken); err == nil || !strings.Contains(err.Error(), "already used") {
+			t.Errorf("taken email: err = %v", err)
+		}
+		if _, err := s.GetUserByEmail(ctx, "fresh@example.com"); err == nil {
+			t.Error("failed run was not rolled back")
+		}
+	})
+}
+
+func counts(t *testing.T, s store.Store) (users, items int64) {
+	t.Helper()
+	users, err := s.CountUsers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	items, err = s.CountItems(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return users, items
+}
+
+func TestLoadFixtures(t *testing.T) {
+	d, err := LoadFixtures("../../fixtures")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Users) == 0 {
+		t.Fatal("no users in fixtures/")
+	}
+	if err := d.normalize(); err != nil {
+		t.Errorf("fixtures/ is invalid: %v", err)
+	}
+
+	dir := t.TempDir()
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("b.yaml", "users:\n  - email: b@example.com\n    name: B\n")
+	write("a.json", `{"users": [{"email": "a@example.com", "name": "A", "items": [{"title": "First"}]}]}`)
+	write("notes.txt", "ignored")
+
+	d, err = LoadFixtures(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Users) != 2 || d.Users[0].Email != "a@example.com" || len(d.Users[0].Items) != 1 || d.Users[1].Email != "b@example.com" {
+		t.Errorf("loaded %+v", d.Users)
+	}
+
+	write("typo.yaml", "users:\n  - email: c@example.com\n    nmae: C\n")
+	if _, err := LoadFixtures(filepath.Join(dir, "typo.yaml")); err == nil {
+		t.Error("unknown field was accepted")
+	}
+}
@@ -2,149 +2,144 @@ package main
 
 import (
 	"context"
-	"database/sql"
-	"encoding/json"
 	"errors"
+	"flag"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
-	"github.com/go-chi/chi/v5"
-	chimiddleware "github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/cors"
+	"github.com/keel/api/internal/app"
 	"github.com/keel/api/internal/config"
 	"github.com/keel/api/internal/database"
-	"github.com/keel/api/internal/handler"
-	"github.com/keel/api/internal/middleware"
-	"github.com/keel/api/internal/service"
-	"github.com/keel/api/internal/store"
 	"github.com/keel/api/migrations"
-	_ "github.com/mattn/go-sqlite3"
 	"golang.org/x/sync/errgroup"
 )
 
+// logLevel is shared by the default logger so the level can be changed on
+// config reload.
+var logLevel = new(slog.LevelVar)
+
 func main() {
 	// Setup structured logging
-	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	logOpts := &slog.HandlerOptions{Level: logLevel}
+	logger := slog.New(slog.NewTextHandler(os.Stdout, logOpts))
 	if os.Getenv("GO_ENV") == "production" {
-		logger = slog.New(slog.NewJSONHandler(os.Stdout, nil))
+		logger = slog.New(slog.NewJSONHandler(os.Stdout, logOpts))
 	}
 	slog.SetDefault(logger)
 
-	if err := run(context.Background()); err != nil {
+	configPath := flag.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML config file")
+	printConfig := flag.Bool("print-config", false, "print the effective configuration with secrets redacted and exit")
+	flag.Parse()
+
+	// Load configuration
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if *printConfig {
+		if err := cfg.Redacted().WriteYAML(os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := run(context.Background(), cfg, *configPath); err != nil {
 		slog.Error("application exited with error", "error", err)
 		os.Exit(1)
 	}
 }
 
-func run(ctx context.Context) error {
+func run(ctx context.Context, cfg *config.Config, configPath string) error {
 	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
-	// Load configuration
-	cfg := config.Load()
+	logLevel.Set(cfg.SlogLevel())
+
 	slog.Info("starting application", "app_name", cfg.AppName)
 
 	// Database connection (Optional)
-	var db *sql.DB
-	var queries *store.Queries
-	if cfg.DatabaseURL != "" {
-		slog.Info("connecting to database", "url", cfg.DatabaseURL)
-		var err error
-		db, err = sql.Open("sqlite3", cfg.DatabaseURL)
+	var db *database.DB
+	if cfg.Database.URL != "" {
+		slog.Info("connecting to database", "url", cfg.Redacted().Database.URL)
+		conn, err := database.Open(cfg.Database)
 		if err != nil {
 			return errors.New("failed to connect to database: " + err.Error())
 		}
 		defer func() {
-			if err := db.Close(); err != nil {
+			if err := conn.Close(); err != nil {
 				slog.Error("error closing database", "error", err)
 			}
 		}()
 
 		// Run migrations
-		if err := runMigrations(db); err != nil {
+		if err := runMigrations(conn); err != nil {
 			return errors.New("failed to run migrations: " + err.Error())
 		}
-
-		// Initialize store
-		queries = store.New(db)
+		db = conn
 	} else {
 		slog.Info("database url not set, skipping database connection")
 	}
 
-	// Initialize services
-	userService := service.NewUserService(db, queries)
-	itemService := service.NewItemService(db, queries)
-
-	// Initialize handlers
-	userHandler := handler.NewUserHandler(userService)
-	itemHandler := handler.NewItemHandler(itemService)
-
-	// Router setup
-	r := chi.NewRouter()
-
-	// Global middleware
-	r.Use(chimiddleware.RequestID)
-	r.Use(chimiddleware.RealIP)
-	r.Use(chimiddleware.Logger) // Chi's default logger is okay, but we could wrap slog
-	r.Use(chimiddleware.Recoverer)
-	r.Use(middleware.RequestID)
-	r.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   cfg.CorsOrigins,
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-Request-ID"},
-		ExposedHeaders:   []string{"X-Request-ID"},
-		AllowCredentials: true,
-		MaxAge:           300,
-	}))
-
-	// Routes
-	r.Route("/api", func(r chi.Router) {
-		userHandler.RegisterRoutes(r)
-		itemHandler.RegisterRoutes(r)
-	})
-
-	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		resp := map[string]string{
-			"status":   "ok",
-			"app_name": cfg.AppName,
-		}
-		if err := json.NewEncoder(w).Encode(resp); err != nil {
-			slog.Error("failed to write health response", "error", err)
-		}
-	})
+	server := app.NewServer(cfg, db, app.Options{})
 
 	// Server
 	srv := &http.Server{
-		Addr:         ":" + cfg.Port,
-		Handler:      r,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+		Addr:         cfg.Addr(),
+		Handler:      server,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+		IdleTimeout:  cfg.Server.IdleTimeout,
 	}
 
 	g, gCtx := errgroup.WithContext(ctx)
 
 	// Server goroutine
 	g.Go(func() error {
-		slog.Info("server starting", "port", cfg.Port)
+		slog.Info("server starting", "port", cfg.Server.Port)
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			return err
 		}
 		return nil
 	})
 
+	// Background jobs
+	for _, job := range server.Jobs() {
+		g.Go(func() error { return job(gCtx) })
+	}
+
+	// Config reload goroutine
+	g.Go(func() error {
+		hup := make(chan os.Signal, 1)
+		signal.Notify(hup, syscall.SIGHUP)
+		defer signal.Stop(hup)
+
+		current := cfg
+		for {
+			select {
+			case <-gCtx.Done():
+				return nil
+			case <-hup:
+				current = reloadConfig(current, configPath, func(next *config.Config) {
+					logLevel.Set(next.SlogLevel())
+					server.Reload(next)
+				})
+			}
+		}
+	})
+
 	// Shutdown goroutine
 	g.Go(func() error {
 		<-gCtx.Done()
 		slog.Info("server shutting down")
 
-		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 		defer shutdownCancel()
 
 		if err := srv.Shutdown(shutdownCtx); err != nil {
@@ -157,6 +152,35 @@ func run(ctx context.Context) error {
 	return g.Wait()
 }
 
-func runMigrations(db *sql.DB) error {
-	return database.RunMigrations(db, migrations.FS, ".")
+// reloadConfig re-reads the configuration and, if it is valid, applies the
+// reloadable settings via apply. It returns the configuration now in effect.
+func reloadConfig(current *config.Config, path string, apply func(*config.Config)) *config.Config {
+	slog.Info("reloading configuration", "path", path)
+
+	next, err := config.Load(path)
+	if err != nil {
+		slog.Error("configuration reload failed, keeping current settings", "error", err)
+		return current
+	}
+
+	changes := config.Diff(current, next)
+	if len(changes) == 0 {
+		slog.Info("configuration unchanged")
+		return current
+	}
+	for _, c := range changes {
+		if c.Reloadable {
+			slog.Info("configuration changed", "setting", c.Path, "old", c.Old, "new", c.New)
+		} else {
+			slog.Warn("configuration change requires restart, ignoring", "setting", c.Path, "old", c.Old, "new", c.New)
+		}
+	}
+
+	merged := current.Merge(next)
+	apply(merged)
+	return merged
+}
+
+func runMigrations(db *database.DB) error {
+	return database.RunMigrations(db.Writer, migrations.FS, string(db.Dialect))
 }
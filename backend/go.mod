@@ -14,3 +14,15 @@ require (
 require github.com/joho/godotenv v1.5.1
 
 require golang.org/x/sync v0.19.0
+
+require (
+	github.com/jackc/pgx/v5 v5.8.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/jackc/pgpassfile v1.0.0 // indirect
+	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
+	github.com/jackc/puddle/v2 v2.2.2 // indirect
+	golang.org/x/text v0.29.0 // indirect
+)
@@ -16,6 +16,10 @@ const (
 	CodeNotFound        ErrorCode = "NOT_FOUND"
 	CodeConflict        ErrorCode = "CONFLICT"
 	CodeUnauthorized    ErrorCode = "UNAUTHORIZED"
+	CodeForbidden       ErrorCode = "FORBIDDEN"
+	CodeTooLarge        ErrorCode = "PAYLOAD_TOO_LARGE"
+	CodeUnsupportedType ErrorCode = "UNSUPPORTED_MEDIA_TYPE"
+	CodeRateLimited     ErrorCode = "RATE_LIMITED"
 	CodeInternalError   ErrorCode = "INTERNAL_ERROR"
 )
 
@@ -83,6 +87,38 @@ func Unauthorized(w http.ResponseWriter, r *http.Request, message string) {
 	Write(w, r, http.StatusUnauthorized, CodeUnauthorized, message, nil)
 }
 
+// Forbidden writes a 403 error response.
+func Forbidden(w http.ResponseWriter, r *http.Request, message string) {
+	if message == "" {
+		message = "Forbidden"
+	}
+	Write(w, r, http.StatusForbidden, CodeForbidden, message, nil)
+}
+
+// PayloadTooLarge writes a 413 error response.
+func PayloadTooLarge(w http.ResponseWriter, r *http.Request, message string) {
+	if message == "" {
+		message = "Payload too large"
+	}
+	Write(w, r, http.StatusRequestEntityTooLarge, CodeTooLarge, message, nil)
+}
+
+// UnsupportedMediaType writes a 415 error response.
+func UnsupportedMediaType(w http.ResponseWriter, r *http.Request, message string, details interface{}) {
+	if message == "" {
+		message = "Unsupported media type"
+	}
+	Write(w, r, http.StatusUnsupportedMediaType, CodeUnsupportedType, message, details)
+}
+
+// TooManyRequests writes a 429 error response.
+func TooManyRequests(w http.ResponseWriter, r *http.Request, message string) {
+	if message == "" {
+		message = "Too many requests"
+	}
+	Write(w, r, http.StatusTooManyRequests, CodeRateLimited, message, nil)
+}
+
 // InternalError writes a 500 error response.
 func InternalError(w http.ResponseWriter, r *http.Request, message string) {
 	if message == "" {
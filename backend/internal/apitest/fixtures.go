@@ -0,0 +1,141 @@
+package apitest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/keel/api/internal/rank"
+	"github.com/keel/api/internal/store"
+)
+
+// UserBuilder builds a user fixture. Fixtures are written to the store
+// directly, so tests of one route do not depend on another.
+type UserBuilder struct {
+	s      *Server
+	params store.CreateUserParams
+}
+
+// User starts a user with a unique email and the "user" role.
+func (s *Server) User() *UserBuilder {
+	id := uuid.New().String()
+	return &UserBuilder{s: s, params: store.CreateUserParams{
+		ID:    id,
+		Email: id + "@example.com",
+		Name:  "User " + id[:8],
+		Role:  "user",
+	}}
+}
+
+// Email sets the user's email.
+func (b *UserBuilder) Email(email string) *UserBuilder {
+	b.params.Email = email
+	return b
+}
+
+// Name sets the user's name.
+func (b *UserBuilder) Name(name string) *UserBuilder {
+	b.params.Name = name
+	return b
+}
+
+// Role sets the user's role.
+func (b *UserBuilder) Role(role string) *UserBuilder {
+	b.params.Role = role
+	return b
+}
+
+// Create inserts the user and fails the test on error.
+func (b *UserBuilder) Create() store.User {
+	b.s.t.Helper()
+	user, err := b.s.Store.CreateUser(context.Background(), b.params)
+	if err != nil {
+		b.s.t.Fatalf("create user: %v", err)
+	}
+	return user
+}
+
+// ItemBuilder builds an item fixture.
+type ItemBuilder struct {
+	s      *Server
+	params store.CreateItemParams
+}
+
+// Item starts a pending item of normal priority. Unless Owner is set, Create also creates a
+// user to own it.
+func (s *Server) Item() *ItemBuilder {
+	id := uuid.New().String()
+	return &ItemBuilder{s: s, params: store.CreateItemParams{
+		ID:       id,
+		Title:    "Item " + id[:8],
+		Status:   "pending",
+		Priority: "normal",
+	}}
+}
+
+// Owner sets the ID of the user owning the item.
+func (b *ItemBuilder) Owner(userID string) *ItemBuilder {
+	b.params.UserID = userID
+	return b
+}
+
+// Title sets the item's title.
+func (b *ItemBuilder) Title(title string) *ItemBuilder {
+	b.params.Title = title
+	return b
+}
+
+// Description sets the item's description.
+func (b *ItemBuilder) Description(description string) *ItemBuilder {
+	b.params.Description = sql.NullString{String: description, Valid: description != ""}
+	return b
+}
+
+// Status sets the item's status.
+func (b *ItemBuilder) Status(status string) *ItemBuilder {
+	b.params.Status = status
+	return b
+}
+
+// Priority sets the item's priority.
+func (b *ItemBuilder) Priority(priority string) *ItemBuilder {
+	b.params.Priority = priority
+	return b
+}
+
+// DueAt sets when the item is due.
+func (b *ItemBuilder) DueAt(due time.Time) *ItemBuilder {
+	b.params.DueAt = sql.NullTime{Time: due.UTC(), Valid: true}
+	return b
+}
+
+// Assignee sets the ID of the user the item is assigned to.
+func (b *ItemBuilder) Assignee(userID string) *ItemBuilder {
+	b.params.AssigneeID = sql.NullString{String: userID, Valid: true}
+	return b
+}
+
+// Create inserts the item and fails the test on error.
+func (b *ItemBuilder) Create() store.Item {
+	b.s.t.Helper()
+	if b.params.UserID == "" {
+		b.params.UserID = b.s.User().Create().ID
+	}
+	if b.params.Rank == "" {
+		// Last in the manual order, as ItemService.Create does
+		last, err := b.s.Store.GetLastRankedItem(context.Background())
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			b.s.t.Fatalf("create item: %v", err)
+		}
+		if b.params.Rank, err = rank.Between(last.Rank, ""); err != nil {
+			b.s.t.Fatalf("create item: %v", err)
+		}
+	}
+	item, err := b.s.Store.CreateItem(context.Background(), b.params)
+	if err != nil {
+		b.s.t.Fatalf("create item: %v", err)
+	}
+	return item
+}
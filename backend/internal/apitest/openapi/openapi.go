@@ -0,0 +1,403 @@
+// Package openapi loads an OpenAPI 3.1 document and validates JSON values
+// against its schemas, for contract tests that check the handlers match
+// api/openapi.yaml.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+// Spec is the part of an OpenAPI document the contract tests use.
+// References ($ref) are resolved when loading.
+type Spec struct {
+	Paths      map[string]*PathItem `yaml:"paths"`
+	Components struct {
+		Parameters map[string]*Parameter `yaml:"parameters"`
+		Responses  map[string]*Response  `yaml:"responses"`
+		Schemas    map[string]*Schema    `yaml:"schemas"`
+	} `yaml:"components"`
+}
+
+// PathItem holds the operations on one path.
+type PathItem struct {
+	Parameters []*Parameter `yaml:"parameters"`
+	Get        *Operation   `yaml:"get"`
+	Post       *Operation   `yaml:"post"`
+	Put        *Operation   `yaml:"put"`
+	Patch      *Operation   `yaml:"patch"`
+	Delete     *Operation   `yaml:"delete"`
+}
+
+// Operation is one method on one path.
+type Operation struct {
+	Method      string                `yaml:"-"`
+	Path        string                `yaml:"-"`
+	OperationID string                `yaml:"operationId"`
+	Parameters  []*Parameter          `yaml:"parameters"`
+	RequestBody *RequestBody          `yaml:"requestBody"`
+	Responses   map[string]*Response  `yaml:"responses"`
+	Security    []map[string][]string `yaml:"security"`
+}
+
+// Parameter is a path or query parameter.
+type Parameter struct {
+	Ref      string  `yaml:"$ref"`
+	Name     string  `yaml:"name"`
+	In       string  `yaml:"in"`
+	Required bool    `yaml:"required"`
+	Schema   *Schema `yaml:"schema"`
+}
+
+// RequestBody is an operation's request body.
+type RequestBody struct {
+	Required bool                  `yaml:"required"`
+	Content  map[string]*MediaType `yaml:"content"`
+}
+
+// Response is a declared response.
+type Response struct {
+	Ref     string                `yaml:"$ref"`
+	Content map[string]*MediaType `yaml:"content"`
+}
+
+// MediaType is the body of a request or response in one content type.
+type MediaType struct {
+	Schema *Schema `yaml:"schema"`
+}
+
+// Schema is a JSON Schema. Only the keywords the spec uses are supported.
+type Schema struct {
+	Ref        string             `yaml:"$ref"`
+	Type       Types              `yaml:"type"`
+	Format     string             `yaml:"format"`
+	Enum       []any              `yaml:"enum"`
+	Required   []string           `yaml:"required"`
+	Properties map[string]*Schema `yaml:"properties"`
+	Items      *Schema            `yaml:"items"`
+	// OneOf lists alternatives a value must match exactly one of, such as
+	// a $ref and {type: "null"} for a nullable object.
+	OneOf     []*Schema `yaml:"oneOf"`
+	MinLength *int      `yaml:"minLength"`
+	Minimum   *float64  `yaml:"minimum"`
+	Maximum   *float64  `yaml:"maximum"`
+	Default   any       `yaml:"default"`
+}
+
+// Types is a schema's type: one name, or a list such as [string, "null"]
+// for a nullable value. Empty allows any type.
+type Types []string
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (t *Types) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind == yaml.ScalarNode {
+		*t = Types{n.Value}
+		return nil
+	}
+	var list []string
+	if err := n.Decode(&list); err != nil {
+		return err
+	}
+	*t = list
+	return nil
+}
+
+// Has reports whether the schema allows values of type name.
+func (t Types) Has(name string) bool {
+	return slices.Contains(t, name)
+}
+
+// Load reads and resolves the OpenAPI document at path.
+func Load(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var spec Spec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := spec.resolve(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &spec, nil
+}
+
+// resolve replaces references with their targets and fills in each
+// operation's method, path and path-level parameters.
+func (s *Spec) resolve() error {
+	r := resolver{spec: s, done: make(map[*Schema]bool)}
+	for _, sch := range s.Components.Schemas {
+		r.schema(sch)
+	}
+	for path, item := range s.Paths {
+		for method, op := range item.operations() {
+			op.Method, op.Path = method, path
+			params := slices.Concat(item.Parameters, op.Parameters)
+			op.Parameters = make([]*Parameter, len(params))
+			for i, p := range params {
+				op.Parameters[i] = r.parameter(p)
+			}
+			if op.RequestBody != nil {
+				for _, mt := range op.RequestBody.Content {
+					mt.Schema = r.schema(mt.Schema)
+				}
+			}
+			for code, resp := range op.Responses {
+				op.Responses[code] = r.response(resp)
+			}
+		}
+	}
+	return r.err
+}
+
+func (p *PathItem) operations() map[string]*Operation {
+	ops := make(map[string]*Operation)
+	for method, op := range map[string]*Operation{
+		"GET": p.Get, "POST": p.Post, "PUT": p.Put, "PATCH": p.Patch, "DELETE": p.Delete,
+	} {
+		if op != nil {
+			ops[method] = op
+		}
+	}
+	return ops
+}
+
+type resolver struct {
+	spec *Spec
+	done map[*Schema]bool
+	err  error
+}
+
+func (r *resolver) target(ref, kind string) string {
+	prefix := "#/components/" + kind + "/"
+	if !strings.HasPrefix(ref, prefix) && r.err == nil {
+		r.err = fmt.Errorf("unsupported reference %q", ref)
+	}
+	return strings.TrimPrefix(ref, prefix)
+}
+
+func (r *resolver) parameter(p *Parameter) *Parameter {
+	if p.Ref != "" {
+		name := r.target(p.Ref, "parameters")
+		if p = r.spec.Components.Parameters[name]; p == nil {
+			r.fail("parameter", name)
+			return &Parameter{}
+		}
+	}
+	p.Schema = r.schema(p.Schema)
+	return p
+}
+
+func (r *resolver) response(resp *Response) *Response {
+	if resp.Ref != "" {
+		name := r.target(resp.Ref, "responses")
+		if resp = r.spec.Components.Responses[name]; resp == nil {
+			r.fail("response", name)
+			return &Response{}
+		}
+	}
+	for _, mt := range resp.Content {
+		mt.Schema = r.schema(mt.Schema)
+	}
+	return resp
+}
+
+func (r *resolver) schema(s *Schema) *Schema {
+	if s == nil {
+		return nil
+	}
+	if s.Ref != "" {
+		name := r.target(s.Ref, "schemas")
+		target := r.spec.Components.Schemas[name]
+		if target == nil {
+			r.fail("schema", name)
+			return &Schema{}
+		}
+		return r.schema(target)
+	}
+	if r.done[s] {
+		return s
+	}
+	r.done[s] = true
+	for name, p := range s.Properties {
+		s.Properties[name] = r.schema(p)
+	}
+	s.Items = r.schema(s.Items)
+	for i, alt := range s.OneOf {
+		s.OneOf[i] = r.schema(alt)
+	}
+	return s
+}
+
+func (r *resolver) fail(kind, name string) {
+	if r.err == nil {
+		r.err = fmt.Errorf("reference to undefined %s %q", kind, name)
+	}
+}
+
+// Operations returns every operation in the spec, sorted by path and method.
+func (s *Spec) Operations() []*Operation {
+	var ops []*Operation
+	for _, item := range s.Paths {
+		for _, op := range item.operations() {
+			ops = append(ops, op)
+		}
+	}
+	sort.Slice(ops, func(i, j int) bool {
+		if ops[i].Path != ops[j].Path {
+			return ops[i].Path < ops[j].Path
+		}
+		return ops[i].Method < ops[j].Method
+	})
+	return ops
+}
+
+// BodySchema returns the JSON request body schema, or nil if the operation
+// takes no body.
+func (op *Operation) BodySchema() *Schema {
+	if op.RequestBody == nil || op.RequestBody.Content["application/json"] == nil {
+		return nil
+	}
+	return op.RequestBody.Content["application/json"].Schema
+}
+
+// FormSchema returns the multipart/form-data request body schema, or nil
+// if the operation takes no form.
+func (op *Operation) FormSchema() *Schema {
+	if op.RequestBody == nil || op.RequestBody.Content["multipart/form-data"] == nil {
+		return nil
+	}
+	return op.RequestBody.Content["multipart/form-data"].Schema
+}
+
+// Validate checks the JSON document data against the schema. Objects may
+// only have the properties their schema declares, so undocumented response
+// fields are reported too. All problems are returned, each prefixed with
+// its location.
+func (s *Schema) Validate(data []byte) []string {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return []string{fmt.Sprintf("invalid JSON: %v", err)}
+	}
+	var problems []string
+	s.validate("$", v, &problems)
+	return problems
+}
+
+func (s *Schema) validate(at string, v any, problems *[]string) {
+	fail := func(format string, args ...any) {
+		*problems = append(*problems, at+": "+fmt.Sprintf(format, args...))
+	}
+
+	if len(s.OneOf) > 0 {
+		matched := 0
+		for _, alt := range s.OneOf {
+			var altProblems []string
+			alt.validate(at, v, &altProblems)
+			if len(altProblems) == 0 {
+				matched++
+			}
+		}
+		if matched != 1 {
+			fail("matches %d of the oneOf alternatives, want 1", matched)
+			return
+		}
+	}
+
+	if t := jsonType(v); len(s.Type) > 0 && !s.Type.Has(t) && !(t == "integer" && s.Type.Has("number")) {
+		fail("got %s, want %s", t, strings.Join(s.Type, " or "))
+		return
+	}
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(e any) bool { return fmt.Sprint(e) == fmt.Sprint(v) }) {
+		fail("%v is not one of %v", v, s.Enum)
+	}
+
+	switch v := v.(type) {
+	case string:
+		if s.MinLength != nil && len([]rune(v)) < *s.MinLength {
+			fail("shorter than %d characters", *s.MinLength)
+		}
+		if err := checkFormat(s.Format, v); err != nil {
+			fail("%q is not a valid %s: %v", v, s.Format, err)
+		}
+	case float64:
+		if s.Minimum != nil && v < *s.Minimum {
+			fail("%v is less than %v", v, *s.Minimum)
+		}
+		if s.Maximum != nil && v > *s.Maximum {
+			fail("%v is greater than %v", v, *s.Maximum)
+		}
+	case []any:
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(fmt.Sprintf("%s[%d]", at, i), item, problems)
+			}
+		}
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				fail("missing required property %q", name)
+			}
+		}
+		if s.Properties == nil {
+			return
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			p, ok := s.Properties[name]
+			if !ok {
+				fail("undocumented property %q", name)
+				continue
+			}
+			p.validate(at+"."+name, v[name], problems)
+		}
+	}
+}
+
+// jsonType returns the JSON Schema type name of a decoded JSON value.
+func jsonType(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if v == float64(int64(v)) {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func checkFormat(format, v string) error {
+	var err error
+	switch format {
+	case "uuid":
+		_, err = uuid.Parse(v)
+	case "email":
+		_, err = mail.ParseAddress(v)
+	case "date-time":
+		_, err = time.Parse(time.RFC3339, v)
+	}
+	return err
+}
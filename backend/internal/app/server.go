@@ -0,0 +1,230 @@
+// Package app assembles the HTTP server: services, handlers, middleware and
+// routes. cmd/server runs it, and tests and other entry points build the
+// same handler with NewServer, so every caller gets the real middleware
+// stack.
+package app
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/cors"
+	"github.com/keel/api/internal/config"
+	"github.com/keel/api/internal/database"
+	"github.com/keel/api/internal/handler"
+	"github.com/keel/api/internal/middleware"
+	"github.com/keel/api/internal/service"
+	"github.com/keel/api/internal/storage"
+	"github.com/keel/api/internal/store"
+	"github.com/keel/api/internal/store/postgres"
+)
+
+// Options adjusts how NewServer builds the server. The zero value gives the
+// server cmd/server runs.
+type Options struct {
+	// Middleware is applied to every request after the built-in middleware,
+	// e.g. to trace requests or inject test identities.
+	Middleware []func(http.Handler) http.Handler
+
+	// DisableRequestLog turns off the per-request access log, which is
+	// mostly noise in tests.
+	DisableRequestLog bool
+}
+
+// Server is the fully wired HTTP handler.
+type Server struct {
+	router      chi.Router
+	cors        *middleware.CORS
+	rateLimiter *middleware.RateLimiter
+	jobs        []func(ctx context.Context) error
+}
+
+// NewServer builds the server for cfg on top of db, which must already be
+// migrated. A nil db serves the routes without a database, as cmd/server
+// does when no database URL is configured.
+func NewServer(cfg *config.Config, db *database.DB, opts Options) *Server {
+	var writer *sql.DB
+	var queries store.Store
+	var dbPath string
+	if db != nil {
+		// Reads and writes are routed to separate pools
+		writer = db.Writer
+		queries = NewStore(db)
+
+		if db.Dialect == database.SQLite {
+			// Empty for in-memory databases, which cannot be backed up
+			dbPath, _ = database.SQLitePath(cfg.Database.URL)
+		}
+	}
+
+	files := NewStorage(cfg.Attachments)
+
+	// Initialize services
+	userService := service.NewUserService(writer, queries, files)
+	itemRules := service.ItemRules{
+		Workflow:             service.ItemWorkflow(cfg.Items.StatusTransitions),
+		RequireChecklistDone: cfg.Items.RequireChecklistDone,
+	}
+	itemService := service.NewItemService(writer, queries, itemRules, files)
+	tagService := service.NewTagService(writer, queries)
+	commentService := service.NewCommentService(writer, queries)
+	checklistService := service.NewChecklistService(writer, queries, itemRules)
+	attachmentService := service.NewAttachmentService(writer, queries, files, service.AttachmentLimits{
+		MaxSize:      cfg.Attachments.MaxSize,
+		AllowedTypes: cfg.Attachments.AllowedTypes,
+	})
+	backupService := service.NewBackupService(dbPath, cfg.Backup.Dir, cfg.Backup.Compress)
+
+	// Initialize handlers
+	userHandler := handler.NewUserHandler(userService)
+	itemHandler := handler.NewItemHandler(itemService)
+	tagHandler := handler.NewTagHandler(tagService)
+	commentHandler := handler.NewCommentHandler(commentService)
+	checklistHandler := handler.NewChecklistHandler(checklistService)
+	attachmentHandler := handler.NewAttachmentHandler(attachmentService)
+	adminHandler := handler.NewAdminHandler(backupService)
+
+	s := &Server{
+		router: chi.NewRouter(),
+		// Middleware whose settings can be reloaded at runtime
+		cors:        middleware.NewCORS(corsOptions(cfg.CORS)),
+		rateLimiter: middleware.NewRateLimiter(cfg.RateLimit.RequestsPerSecond, cfg.RateLimit.Burst),
+	}
+	r := s.router
+
+	// Background jobs, which need a database
+	if queries != nil && cfg.Items.RankRebalanceInterval > 0 {
+		s.jobs = append(s.jobs, func(ctx context.Context) error {
+			return itemService.RebalanceRanksEvery(ctx, cfg.Items.RankRebalanceInterval)
+		})
+	}
+	if queries != nil && cfg.Items.RecurrenceInterval > 0 {
+		s.jobs = append(s.jobs, func(ctx context.Context) error {
+			return itemService.MaterializeRecurrencesEvery(ctx, cfg.Items.RecurrenceInterval)
+		})
+	}
+
+	// Global middleware
+	r.Use(chimiddleware.RequestID)
+	r.Use(chimiddleware.RealIP)
+	if !opts.DisableRequestLog {
+		r.Use(chimiddleware.Logger) // Chi's default logger is okay, but we could wrap slog
+	}
+	r.Use(chimiddleware.Recoverer)
+	r.Use(middleware.RequestID)
+	r.Use(s.cors.Handler)
+	r.Use(s.rateLimiter.Handler)
+	r.Use(opts.Middleware...)
+
+	// Routes
+	r.Route("/api", func(r chi.Router) {
+		if queries != nil {
+			r.Use(middleware.Identify(lookupUser(userService)))
+		}
+		userHandler.RegisterRoutes(r)
+		itemHandler.RegisterRoutes(r)
+		tagHandler.RegisterRoutes(r)
+		commentHandler.RegisterRoutes(r)
+		checklistHandler.RegisterRoutes(r)
+		attachmentHandler.RegisterRoutes(r)
+		for _, h := range handler.Resources(writer, queries) {
+			h.RegisterRoutes(r)
+		}
+
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.RequireAdminToken(cfg.Admin.Token))
+			adminHandler.RegisterRoutes(r)
+		})
+	})
+
+	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		resp := map[string]string{
+			"status":   "ok",
+			"app_name": cfg.AppName,
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			slog.Error("failed to write health response", "error", err)
+		}
+	})
+
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.router.ServeHTTP(w, r)
+}
+
+// Jobs returns the background jobs the server needs. Each runs until ctx
+// is done; cmd/server runs them alongside the HTTP server.
+func (s *Server) Jobs() []func(ctx context.Context) error {
+	return s.jobs
+}
+
+// Routes returns the server's routes, e.g. to list them with chi.Walk.
+func (s *Server) Routes() chi.Routes {
+	return s.router
+}
+
+// Reload applies the reloadable settings of cfg (see config.Diff) to the
+// running server.
+func (s *Server) Reload(cfg *config.Config) {
+	s.cors.Update(corsOptions(cfg.CORS))
+	s.rateLimiter.Update(cfg.RateLimit.RequestsPerSecond, cfg.RateLimit.Burst)
+}
+
+// NewStore returns the store implementation for the database's dialect.
+func NewStore(db *database.DB) store.Store {
+	if db.Dialect == database.Postgres {
+		return postgres.New(db)
+	}
+	return store.New(db)
+}
+
+// NewStorage returns the attachment storage selected by cfg.
+func NewStorage(cfg config.AttachmentsConfig) storage.Storage {
+	if cfg.Storage == "s3" {
+		return storage.NewS3(storage.S3Options{
+			Endpoint:        cfg.S3.Endpoint,
+			Region:          cfg.S3.Region,
+			Bucket:          cfg.S3.Bucket,
+			AccessKeyID:     cfg.S3.AccessKeyID,
+			SecretAccessKey: cfg.S3.SecretAccessKey,
+		})
+	}
+	return storage.NewLocal(cfg.Dir)
+}
+
+// lookupUser adapts UserService.Get to middleware.Identify.
+func lookupUser(users *service.UserService) middleware.UserLookup {
+	return func(ctx context.Context, id string) (middleware.User, bool, error) {
+		user, err := users.Get(ctx, id)
+		if errors.Is(err, service.ErrUserNotFound) {
+			return middleware.User{}, false, nil
+		}
+		if err != nil {
+			return middleware.User{}, false, err
+		}
+		return middleware.User{ID: user.ID, Role: user.Role}, true, nil
+	}
+}
+
+// corsOptions converts CORS configuration into middleware options.
+func corsOptions(c config.CORSConfig) cors.Options {
+	return cors.Options{
+		AllowedOrigins:   c.AllowedOrigins,
+		AllowedMethods:   c.AllowedMethods,
+		AllowedHeaders:   c.AllowedHeaders,
+		ExposedHeaders:   c.ExposedHeaders,
+		AllowCredentials: c.AllowCredentials,
+		MaxAge:           c.MaxAge,
+	}
+}
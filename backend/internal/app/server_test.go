@@ -0,0 +1,134 @@
+package app_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/keel/api/internal/app"
+	"github.com/keel/api/internal/config"
+	"github.com/keel/api/internal/store/storetest"
+)
+
+func newServer(t *testing.T, cfg *config.Config, opts app.Options) *app.Server {
+	t.Helper()
+	db, _ := storetest.OpenSQLite(t)
+	cfg.Database.URL = ":memory:"
+	opts.DisableRequestLog = true
+	return app.NewServer(cfg, db, opts)
+}
+
+func serve(h http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHealth(t *testing.T) {
+	cfg := config.Default()
+	cfg.AppName = "TestApp"
+
+	for name, srv := range map[string]*app.Server{
+		"database":    newServer(t, cfg, app.Options{}),
+		"no database": app.NewServer(cfg, nil, app.Options{DisableRequestLog: true}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			rec := serve(srv, httptest.NewRequest(http.MethodGet, "/health", nil))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status %d, want 200", rec.Code)
+			}
+			if rec.Header().Get("X-Request-ID") == "" {
+				t.Error("missing X-Request-ID; the middleware stack did not run")
+			}
+
+			var body map[string]string
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body["status"] != "ok" || body["app_name"] != "TestApp" {
+				t.Errorf("body = %v", body)
+			}
+		})
+	}
+}
+
+func TestRoutes(t *testing.T) {
+	cfg := config.Default()
+	cfg.Admin.Token = "secret"
+	srv := newServer(t, cfg, app.Options{})
+
+	rec := serve(srv, httptest.NewRequest(http.MethodPost, "/api/users", strings.NewReader(`{"email":"ada@example.com","name":"Ada"}`)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create user: status %d, body %s", rec.Code, rec.Body)
+	}
+	var user struct{ ID string }
+	if err := json.Unmarshal(rec.Body.Bytes(), &user); err != nil {
+		t.Fatal(err)
+	}
+
+	if rec := serve(srv, httptest.NewRequest(http.MethodGet, "/api/users/"+user.ID, nil)); rec.Code != http.StatusOK {
+		t.Errorf("get user: status %d", rec.Code)
+	}
+	if rec := serve(srv, httptest.NewRequest(http.MethodGet, "/api/items", nil)); rec.Code != http.StatusOK {
+		t.Errorf("list items: status %d", rec.Code)
+	}
+	if rec := serve(srv, httptest.NewRequest(http.MethodGet, "/api/nothing", nil)); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown route: status %d, want 404", rec.Code)
+	}
+
+	// Admin routes sit behind the admin token.
+	if rec := serve(srv, httptest.NewRequest(http.MethodPost, "/api/admin/backups", nil)); rec.Code != http.StatusUnauthorized {
+		t.Errorf("admin without token: status %d, want 401", rec.Code)
+	}
+}
+
+func TestOptionsMiddleware(t *testing.T) {
+	var seen string
+	srv := newServer(t, config.Default(), app.Options{
+		Middleware: []func(http.Handler) http.Handler{
+			func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					// Runs after the built-in middleware set the request ID.
+					seen = w.Header().Get("X-Request-ID")
+					next.ServeHTTP(w, r)
+				})
+			},
+		},
+	})
+
+	serve(srv, httptest.NewRequest(http.MethodGet, "/health", nil))
+	if seen == "" {
+		t.Error("extra middleware did not run after the built-in middleware")
+	}
+}
+
+func TestReload(t *testing.T) {
+	cfg := config.Default()
+	cfg.CORS.AllowedOrigins = []string{"https://old.example.com"}
+	srv := newServer(t, cfg, app.Options{})
+
+	allowed := func(origin string) bool {
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		req.Header.Set("Origin", origin)
+		return serve(srv, req).Header().Get("Access-Control-Allow-Origin") == origin
+	}
+	if !allowed("https://old.example.com") || allowed("https://new.example.com") {
+		t.Fatal("initial CORS origins not applied")
+	}
+
+	next := config.Default()
+	next.CORS.AllowedOrigins = []string{"https://new.example.com"}
+	next.RateLimit.RequestsPerSecond = 1
+	next.RateLimit.Burst = 1
+	srv.Reload(next)
+
+	if allowed("https://old.example.com") || !allowed("https://new.example.com") {
+		t.Error("reloaded CORS origins not applied")
+	}
+	// The burst of one was used by the request above.
+	if rec := serve(srv, httptest.NewRequest(http.MethodGet, "/health", nil)); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("after enabling the rate limit: status %d, want 429", rec.Code)
+	}
+}
@@ -0,0 +1,238 @@
+// Package backup takes and restores snapshots of a file-based SQLite
+// database.
+//
+// Snapshots use VACUUM INTO over a dedicated read-only connection, so they
+// are consistent and can be taken while the server is running without
+// blocking writers. Every snapshot is checked with PRAGMA integrity_check
+// before it is kept, and every restore is checked again before it replaces
+// the live database.
+package backup
+
+import (
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// Options controls how a snapshot is written.
+type Options struct {
+	// Compress gzips the snapshot, adding a .gz suffix.
+	Compress bool
+}
+
+// Result describes a verified snapshot.
+type Result struct {
+	Path          string
+	Size          int64
+	SchemaVersion int
+	Compressed    bool
+	CreatedAt     time.Time
+}
+
+// Snapshot writes a consistent copy of the database at dbPath into dir and
+// verifies it.
+func Snapshot(ctx context.Context, dbPath, dir string, opts Options) (*Result, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	now := time.Now().UTC()
+	name := "backup-" + now.Format("20060102T150405.000Z") + ".db"
+	tmp := filepath.Join(dir, "."+name+".tmp")
+	defer func() { _ = os.Remove(tmp) }()
+
+	if err := vacuumInto(ctx, dbPath, tmp); err != nil {
+		return nil, err
+	}
+
+	version, err := verify(ctx, tmp)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot failed verification: %w", err)
+	}
+
+	dest := filepath.Join(dir, name)
+	if opts.Compress {
+		dest += ".gz"
+		if err := gzipFile(tmp, dest); err != nil {
+			return nil, err
+		}
+	} else if err := os.Rename(tmp, dest); err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(dest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		Path:          dest,
+		Size:          info.Size(),
+		SchemaVersion: version,
+		Compressed:    opts.Compress,
+		CreatedAt:     now,
+	}, nil
+}
+
+// Restore replaces the database at dbPath with the snapshot at src. The
+// snapshot must pass integrity_check and must not have a schema version
+// newer than maxVersion, the latest migration this build knows; older
+// snapshots are brought up to date by the migrations on next start.
+//
+// The previous database, if any, is kept alongside as
+// <dbPath>.pre-restore-<timestamp>. The server must not be running.
+func Restore(ctx context.Context, src, dbPath string, maxVersion int) (*Result, error) {
+	tmp := dbPath + ".restore.tmp"
+	defer func() { _ = os.Remove(tmp) }()
+
+	compressed := strings.HasSuffix(src, ".gz")
+	var err error
+	if compressed {
+		err = gunzipFile(src, tmp)
+	} else {
+		err = copyFile(src, tmp)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := verify(ctx, tmp)
+	if err != nil {
+		return nil, fmt.Errorf("backup failed verification: %w", err)
+	}
+	if version > maxVersion {
+		return nil, fmt.Errorf("backup schema version %d is newer than this build supports (%d)", version, maxVersion)
+	}
+
+	suffix := ".pre-restore-" + time.Now().UTC().Format("20060102T150405Z")
+	for _, ext := range []string{"", "-wal", "-shm"} {
+		if err := os.Rename(dbPath+ext, dbPath+suffix+ext); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed to move aside current database: %w", err)
+		}
+	}
+
+	if err := os.Rename(tmp, dbPath); err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		Path:          dbPath,
+		Size:          info.Size(),
+		SchemaVersion: version,
+		Compressed:    compressed,
+		CreatedAt:     info.ModTime(),
+	}, nil
+}
+
+// vacuumInto copies the database at src to dest using a read-only
+// connection.
+func vacuumInto(ctx context.Context, src, dest string) error {
+	if _, err := os.Stat(src); err != nil {
+		return fmt.Errorf("database not found: %w", err)
+	}
+
+	db, err := sql.Open("sqlite3", "file:"+src+"?mode=ro")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+
+	if _, err := db.ExecContext(ctx, "VACUUM INTO ?", dest); err != nil {
+		return fmt.Errorf("failed to snapshot database: %w", err)
+	}
+	return nil
+}
+
+// verify runs integrity_check on the database at path and returns its
+// schema_migrations version.
+func verify(ctx context.Context, path string) (int, error) {
+	db, err := sql.Open("sqlite3", "file:"+path+"?mode=ro")
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = db.Close() }()
+
+	var result string
+	if err := db.QueryRowContext(ctx, "PRAGMA integrity_check").Scan(&result); err != nil {
+		return 0, fmt.Errorf("integrity check failed: %w", err)
+	}
+	if result != "ok" {
+		return 0, fmt.Errorf("integrity check failed: %s", result)
+	}
+
+	var version sql.NullInt64
+	if err := db.QueryRowContext(ctx, "SELECT MAX(version) FROM schema_migrations").Scan(&version); err != nil {
+		return 0, fmt.Errorf("not a Keel database: %w", err)
+	}
+	if !version.Valid {
+		return 0, errors.New("not a Keel database: no migrations recorded")
+	}
+	return int(version.Int64), nil
+}
+
+func gzipFile(src, dest string) error {
+	return transform(src, dest, func(w io.Writer, r io.Reader) error {
+		zw := gzip.NewWriter(w)
+		if _, err := io.Copy(zw, r); err != nil {
+			return err
+		}
+		return zw.Close()
+	})
+}
+
+func gunzipFile(src, dest string) error {
+	return transform(src, dest, func(w io.Writer, r io.Reader) error {
+		zr, err := gzip.NewReader(r)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = zr.Close() }()
+		_, err = io.Copy(w, zr)
+		return err
+	})
+}
+
+func copyFile(src, dest string) error {
+	return transform(src, dest, func(w io.Writer, r io.Reader) error {
+		_, err := io.Copy(w, r)
+		return err
+	})
+}
+
+// transform streams src through fn into dest, removing dest on failure.
+func transform(src, dest string, fn func(io.Writer, io.Reader) error) (err error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+
+	out, err := os.OpenFile(dest, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := out.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			_ = os.Remove(dest)
+		}
+	}()
+
+	return fn(out, in)
+}
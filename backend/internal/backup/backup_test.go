@@ -0,0 +1,138 @@
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/keel/api/internal/config"
+	"github.com/keel/api/internal/database"
+	"github.com/keel/api/migrations"
+)
+
+// newDatabase creates a migrated WAL-mode database with one user and
+// returns its path along with the open connection.
+func newDatabase(t *testing.T) (string, *database.DB) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "keel.db")
+
+	cfg := config.Default().Database
+	cfg.URL = "file:" + path
+	db, err := database.Open(cfg)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	if err := database.RunMigrations(db.Writer, migrations.FS, "sqlite"); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if _, err := db.Writer.Exec(`INSERT INTO users (id, email, name, role) VALUES ('u1', 'a@example.com', 'Ada', 'admin')`); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	return path, db
+}
+
+func countUsers(t *testing.T, path string) int {
+	t.Helper()
+	db, err := sql.Open("sqlite3", "file:"+path+"?mode=ro")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = db.Close() }()
+
+	var n int
+	if err := db.QueryRow("SELECT COUNT(*) FROM users").Scan(&n); err != nil {
+		t.Fatalf("count users: %v", err)
+	}
+	return n
+}
+
+func TestSnapshotAndRestore(t *testing.T) {
+	for _, compress := range []bool{false, true} {
+		name := "plain"
+		if compress {
+			name = "gzip"
+		}
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			path, db := newDatabase(t)
+			latest, err := database.LatestVersion(migrations.FS, "sqlite")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The snapshot is taken while the server's connections are open.
+			result, err := Snapshot(ctx, path, filepath.Join(t.TempDir(), "backups"), Options{Compress: compress})
+			if err != nil {
+				t.Fatalf("Snapshot: %v", err)
+			}
+			if result.SchemaVersion != latest {
+				t.Errorf("SchemaVersion = %d, want %d", result.SchemaVersion, latest)
+			}
+			if got := strings.HasSuffix(result.Path, ".gz"); got != compress {
+				t.Errorf("Path = %q, compressed suffix = %v, want %v", result.Path, got, compress)
+			}
+			if result.Size == 0 {
+				t.Error("Size should be non-zero")
+			}
+
+			// Diverge the live database, then restore the snapshot over it.
+			if _, err := db.Writer.Exec(`DELETE FROM users`); err != nil {
+				t.Fatal(err)
+			}
+			_ = db.Close()
+
+			if _, err := Restore(ctx, result.Path, path, latest); err != nil {
+				t.Fatalf("Restore: %v", err)
+			}
+			if n := countUsers(t, path); n != 1 {
+				t.Errorf("users after restore = %d, want 1", n)
+			}
+
+			kept, _ := filepath.Glob(path + ".pre-restore-*")
+			if len(kept) == 0 {
+				t.Error("previous database was not kept")
+			}
+		})
+	}
+}
+
+func TestRestoreRejectsNewerSchema(t *testing.T) {
+	ctx := context.Background()
+	path, db := newDatabase(t)
+
+	result, err := Snapshot(ctx, path, t.TempDir(), Options{})
+	if err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+	_ = db.Close()
+
+	_, err = Restore(ctx, result.Path, path, result.SchemaVersion-1)
+	if err == nil || !strings.Contains(err.Error(), "newer than this build supports") {
+		t.Fatalf("Restore error = %v, want schema version error", err)
+	}
+	if n := countUsers(t, path); n != 1 {
+		t.Errorf("live database changed after rejected restore: %d users", n)
+	}
+}
+
+func TestRestoreRejectsCorruptFile(t *testing.T) {
+	path, db := newDatabase(t)
+	_ = db.Close()
+
+	bogus := filepath.Join(t.TempDir(), "bogus.db")
+	if err := os.WriteFile(bogus, []byte("not a database"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Restore(context.Background(), bogus, path, 100); err == nil {
+		t.Fatal("Restore of a corrupt file should fail")
+	}
+	if _, err := os.Stat(path + ".restore.tmp"); !os.IsNotExist(err) {
+		t.Error("temporary restore file was not cleaned up")
+	}
+}
@@ -1,37 +1,254 @@
 package config
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"os"
-	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
 )
 
+// Config holds all runtime settings for the API server.
+//
+// Values are layered: built-in defaults, then the optional YAML config file,
+// then environment variables (including those loaded from .env). Fields with
+// an env tag can be overridden from the environment; fields with a secret tag
+// are masked by Redacted; fields with a reload tag can be changed on a running
+// server (see Diff).
 type Config struct {
-	Port        string
-	DatabaseURL string
-	AppName     string
-	CorsOrigins []string
+	AppName     string            `yaml:"app_name" env:"APP_NAME"`
+	LogLevel    string            `yaml:"log_level" env:"LOG_LEVEL" reload:"true"`
+	Server      ServerConfig      `yaml:"server"`
+	CORS        CORSConfig        `yaml:"cors"`
+	RateLimit   RateLimitConfig   `yaml:"rate_limit"`
+	Database    DatabaseConfig    `yaml:"database"`
+	Backup      BackupConfig      `yaml:"backup"`
+	Admin       AdminConfig       `yaml:"admin"`
+	Items       ItemsConfig       `yaml:"items"`
+	Attachments AttachmentsConfig `yaml:"attachments"`
 }
 
-func Load() *Config {
+// ServerConfig controls the HTTP server.
+type ServerConfig struct {
+	Port            int           `yaml:"port" env:"PORT"`
+	ReadTimeout     time.Duration `yaml:"read_timeout" env:"SERVER_READ_TIMEOUT"`
+	WriteTimeout    time.Duration `yaml:"write_timeout" env:"SERVER_WRITE_TIMEOUT"`
+	IdleTimeout     time.Duration `yaml:"idle_timeout" env:"SERVER_IDLE_TIMEOUT"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SERVER_SHUTDOWN_TIMEOUT"`
+}
+
+// CORSConfig controls the CORS middleware.
+type CORSConfig struct {
+	AllowedOrigins   []string `yaml:"allowed_origins" env:"CORS_ORIGINS" reload:"true"`
+	AllowedMethods   []string `yaml:"allowed_methods" env:"CORS_METHODS" reload:"true"`
+	AllowedHeaders   []string `yaml:"allowed_headers" env:"CORS_HEADERS" reload:"true"`
+	ExposedHeaders   []string `yaml:"exposed_headers" env:"CORS_EXPOSED_HEADERS" reload:"true"`
+	AllowCredentials bool     `yaml:"allow_credentials" env:"CORS_ALLOW_CREDENTIALS" reload:"true"`
+	MaxAge           int      `yaml:"max_age" env:"CORS_MAX_AGE" reload:"true"`
+}
+
+// RateLimitConfig controls per-client request rate limiting. A zero
+// RequestsPerSecond disables the limiter.
+type RateLimitConfig struct {
+	RequestsPerSecond float64 `yaml:"requests_per_second" env:"RATE_LIMIT_RPS" reload:"true"`
+	Burst             int     `yaml:"burst" env:"RATE_LIMIT_BURST" reload:"true"`
+}
+
+// DatabaseConfig controls the database connection. An empty URL disables the
+// database entirely.
+//
+// Writes always use a single connection; MaxOpenConns and MaxIdleConns size
+// the read pool. The remaining fields set SQLite pragmas on every connection.
+type DatabaseConfig struct {
+	URL             string        `yaml:"url" env:"DATABASE_URL" secret:"url"`
+	MaxOpenConns    int           `yaml:"max_open_conns" env:"DATABASE_MAX_OPEN_CONNS"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" env:"DATABASE_MAX_IDLE_CONNS"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"DATABASE_CONN_MAX_LIFETIME"`
+	JournalMode     string        `yaml:"journal_mode" env:"DATABASE_JOURNAL_MODE"`
+	BusyTimeout     time.Duration `yaml:"busy_timeout" env:"DATABASE_BUSY_TIMEOUT"`
+	Synchronous     string        `yaml:"synchronous" env:"DATABASE_SYNCHRONOUS"`
+	ForeignKeys     bool          `yaml:"foreign_keys" env:"DATABASE_FOREIGN_KEYS"`
+}
+
+// BackupConfig controls database snapshots taken by the admin API and CLI.
+type BackupConfig struct {
+	Dir      string `yaml:"dir" env:"BACKUP_DIR"`
+	Compress bool   `yaml:"compress" env:"BACKUP_COMPRESS"`
+}
+
+// AdminConfig controls the admin API. An empty Token disables it.
+type AdminConfig struct {
+	Token string `yaml:"token" env:"ADMIN_TOKEN" secret:"true"`
+}
+
+// ItemsConfig controls item behaviour. StatusTransitions maps each item
+// status to the statuses an item in it may move to; keeping the current
+// status is always allowed. RankRebalanceInterval is how often the server
+// checks whether item ranks have grown long enough to reissue, and
+// RecurrenceInterval how often it creates recurring items that have fallen
+// due; zero disables either check. RequireChecklistDone stops an item
+// being completed until every entry of its checklist is done, and keeps
+// the checklist of a completed item done.
+type ItemsConfig struct {
+	StatusTransitions     map[string][]string `yaml:"status_transitions"`
+	RankRebalanceInterval time.Duration       `yaml:"rank_rebalance_interval" env:"ITEMS_RANK_REBALANCE_INTERVAL"`
+	RecurrenceInterval    time.Duration       `yaml:"recurrence_interval" env:"ITEMS_RECURRENCE_INTERVAL"`
+	RequireChecklistDone  bool                `yaml:"require_checklist_done" env:"ITEMS_REQUIRE_CHECKLIST_DONE"`
+}
+
+// AttachmentsConfig controls files attached to items. Storage selects
+// where they are kept: "local" keeps them under Dir, "s3" in the bucket
+// configured under S3. Uploads larger than MaxSize bytes, or whose type
+// matches none of AllowedTypes ("image/png", or "image/*" for any image),
+// are rejected.
+type AttachmentsConfig struct {
+	Storage      string   `yaml:"storage" env:"ATTACHMENTS_STORAGE"`
+	Dir          string   `yaml:"dir" env:"ATTACHMENTS_DIR"`
+	MaxSize      int64    `yaml:"max_size" env:"ATTACHMENTS_MAX_SIZE"`
+	AllowedTypes []string `yaml:"allowed_types" env:"ATTACHMENTS_ALLOWED_TYPES"`
+	S3           S3Config `yaml:"s3"`
+}
+
+// S3Config locates the bucket of an S3-compatible service, addressed
+// path-style.
+type S3Config struct {
+	Endpoint        string `yaml:"endpoint" env:"S3_ENDPOINT"`
+	Region          string `yaml:"region" env:"S3_REGION"`
+	Bucket          string `yaml:"bucket" env:"S3_BUCKET"`
+	AccessKeyID     string `yaml:"access_key_id" env:"S3_ACCESS_KEY_ID"`
+	SecretAccessKey string `yaml:"secret_access_key" env:"S3_SECRET_ACCESS_KEY" secret:"true"`
+}
+
+// Default returns the configuration used when no file or environment
+// overrides are present.
+func Default() *Config {
+	return &Config{
+		AppName:  "Keel",
+		LogLevel: "info",
+		Server: ServerConfig{
+			Port:            8080,
+			ReadTimeout:     15 * time.Second,
+			WriteTimeout:    15 * time.Second,
+			IdleTimeout:     60 * time.Second,
+			ShutdownTimeout: 30 * time.Second,
+		},
+		CORS: CORSConfig{
+			AllowedOrigins:   []string{"http://localhost:3000"},
+			AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+			AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-Request-ID", "X-User-ID"},
+			ExposedHeaders:   []string{"X-Request-ID"},
+			AllowCredentials: true,
+			MaxAge:           300,
+		},
+		RateLimit: RateLimitConfig{
+			RequestsPerSecond: 0,
+			Burst:             20,
+		},
+		Database: DatabaseConfig{
+			URL:             "file:./data/keel.db",
+			MaxOpenConns:    10,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: time.Hour,
+			JournalMode:     "WAL",
+			BusyTimeout:     5 * time.Second,
+			Synchronous:     "NORMAL",
+			ForeignKeys:     true,
+		},
+		Backup: BackupConfig{
+			Dir:      "./data/backups",
+			Compress: true,
+		},
+		Items: ItemsConfig{
+			StatusTransitions: map[string][]string{
+				"pending":     {"in_progress", "completed"},
+				"in_progress": {"pending", "completed"},
+				"completed":   {"in_progress"},
+			},
+			RankRebalanceInterval: time.Hour,
+			RecurrenceInterval:    time.Minute,
+		},
+		Attachments: AttachmentsConfig{
+			Storage:      "local",
+			Dir:          "./data/attachments",
+			MaxSize:      10 << 20,
+			AllowedTypes: []string{"image/*", "text/plain", "text/csv", "application/pdf"},
+			S3: S3Config{
+				Region: "us-east-1",
+			},
+		},
+	}
+}
+
+// Load builds the configuration from defaults, the YAML file at path (if
+// path is non-empty) and the environment, then validates the result.
+func Load(path string) (*Config, error) {
 	// Attempt to load .env file, but don't fail if it doesn't exist (e.g. production)
 	if err := godotenv.Load(); err != nil {
 		slog.Debug("No .env file found or error loading it")
 	}
 
-	return &Config{
-		Port:        getEnv("PORT", "8080"),
-		DatabaseURL: getEnv("DATABASE_URL", "file:./data/keel.db?_foreign_keys=on"),
-		AppName:     getEnv("APP_NAME", "Keel"),
-		CorsOrigins: strings.Split(getEnv("CORS_ORIGINS", "http://localhost:3000"), ","),
+	cfg := Default()
+
+	if path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := applyEnv(cfg, os.LookupEnv); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// loadFile decodes a YAML config file over the current values. Unknown keys
+// are rejected so that typos surface at startup.
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// Addr returns the listen address for the HTTP server.
+func (c *Config) Addr() string {
+	return fmt.Sprintf(":%d", c.Server.Port)
+}
+
+// SlogLevel returns LogLevel as a slog.Level. Validate guarantees the value
+// parses, so unknown levels fall back to info.
+func (c *Config) SlogLevel() slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		return slog.LevelInfo
 	}
+	return level
 }
 
-func getEnv(key, fallback string) string {
-	if value, ok := os.LookupEnv(key); ok {
-		return value
+// WriteYAML writes the configuration as YAML. Callers printing configuration
+// for humans should pass Redacted() to avoid leaking secrets.
+func (c *Config) WriteYAML(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(c); err != nil {
+		return err
 	}
-	return fallback
+	return enc.Close()
 }
@@ -0,0 +1,211 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDefaultIsValid(t *testing.T) {
+	if err := Default().Validate(); err != nil {
+		t.Fatalf("default config should be valid: %v", err)
+	}
+}
+
+func TestFileThenEnvLayering(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keel.yaml")
+	content := `
+app_name: FromFile
+server:
+  port: 9000
+  read_timeout: 5s
+cors:
+  allowed_origins: [https://a.example, https://b.example]
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := Default()
+	if err := cfg.loadFile(path); err != nil {
+		t.Fatalf("loadFile: %v", err)
+	}
+
+	env := map[string]string{
+		"PORT":                   "9100",
+		"CORS_ALLOW_CREDENTIALS": "false",
+		"SERVER_IDLE_TIMEOUT":    "2m",
+	}
+	lookup := func(k string) (string, bool) { v, ok := env[k]; return v, ok }
+	if err := applyEnv(cfg, lookup); err != nil {
+		t.Fatalf("applyEnv: %v", err)
+	}
+
+	if cfg.AppName != "FromFile" {
+		t.Errorf("AppName = %q, want FromFile", cfg.AppName)
+	}
+	if cfg.Server.Port != 9100 {
+		t.Errorf("Port = %d, want env override 9100", cfg.Server.Port)
+	}
+	if cfg.Server.ReadTimeout != 5*time.Second {
+		t.Errorf("ReadTimeout = %s, want 5s from file", cfg.Server.ReadTimeout)
+	}
+	if cfg.Server.WriteTimeout != 15*time.Second {
+		t.Errorf("WriteTimeout = %s, want default 15s", cfg.Server.WriteTimeout)
+	}
+	if cfg.Server.IdleTimeout != 2*time.Minute {
+		t.Errorf("IdleTimeout = %s, want 2m", cfg.Server.IdleTimeout)
+	}
+	if len(cfg.CORS.AllowedOrigins) != 2 {
+		t.Errorf("AllowedOrigins = %v, want 2 entries", cfg.CORS.AllowedOrigins)
+	}
+	if cfg.CORS.AllowCredentials {
+		t.Error("AllowCredentials should be overridden to false")
+	}
+}
+
+func TestUnknownFileKeyRejected(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keel.yaml")
+	if err := os.WriteFile(path, []byte("server:\n  prot: 1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := Default().loadFile(path); err == nil {
+		t.Fatal("expected error for unknown key")
+	}
+}
+
+func TestInvalidEnvValue(t *testing.T) {
+	lookup := func(k string) (string, bool) {
+		if k == "PORT" {
+			return "eighty", true
+		}
+		return "", false
+	}
+	err := applyEnv(Default(), lookup)
+	if err == nil || !strings.Contains(err.Error(), "PORT") {
+		t.Fatalf("expected PORT parse error, got %v", err)
+	}
+}
+
+func TestValidateReportsAllProblems(t *testing.T) {
+	cfg := Default()
+	cfg.Server.Port = 0
+	cfg.Server.ReadTimeout = 0
+	cfg.CORS.AllowedMethods = []string{"GET", "FETCH"}
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	for _, want := range []string{"server.port", "server.read_timeout", `"FETCH"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %s", err, want)
+		}
+	}
+}
+
+func TestItemStatusTransitions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keel.yaml")
+	content := `
+items:
+  status_transitions:
+    pending: [in_progress, done]
+    completed: []
+  rank_rebalance_interval: -1m
+  recurrence_interval: -1m
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := Default()
+	if err := cfg.loadFile(path); err != nil {
+		t.Fatalf("loadFile: %v", err)
+	}
+
+	got := cfg.Items.StatusTransitions
+	if len(got["completed"]) != 0 {
+		t.Errorf("completed = %v, want no transitions", got["completed"])
+	}
+	if len(got["in_progress"]) != 2 {
+		t.Errorf("in_progress = %v, want the default kept", got["in_progress"])
+	}
+
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), `items.status_transitions.pending: unknown status "done"`) {
+		t.Errorf("Validate() = %v, want the unknown status reported", err)
+	}
+	for _, want := range []string{"items.rank_rebalance_interval", "items.recurrence_interval"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() = %v, want %s reported", err, want)
+		}
+	}
+}
+
+func TestAttachmentsValidation(t *testing.T) {
+	cfg := Default()
+	cfg.Attachments.Storage = "s3"
+	cfg.Attachments.MaxSize = 0
+	cfg.Attachments.AllowedTypes = []string{"image/*", "pdf"}
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	for _, want := range []string{"attachments.s3.endpoint", "attachments.s3.bucket", "attachments.max_size", `"pdf"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %s", err, want)
+		}
+	}
+
+	cfg.Attachments.Storage = "ftp"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), `attachments.storage: must be one of local, s3, got "ftp"`) {
+		t.Errorf("Validate() = %v, want the unknown storage reported", err)
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := Default()
+	cfg.Database.URL = "postgres://keel:hunter2@db:5432/keel"
+
+	out := cfg.Redacted()
+	if strings.Contains(out.Database.URL, "hunter2") {
+		t.Errorf("password leaked: %s", out.Database.URL)
+	}
+	if cfg.Database.URL != "postgres://keel:hunter2@db:5432/keel" {
+		t.Error("Redacted must not modify the original config")
+	}
+}
+
+func TestDiffAndMerge(t *testing.T) {
+	current := Default()
+	next := Default()
+	next.LogLevel = "debug"
+	next.CORS.AllowedOrigins = []string{"https://app.example"}
+	next.Server.Port = 9999
+
+	changes := Diff(current, next)
+	if len(changes) != 3 {
+		t.Fatalf("expected 3 changes, got %+v", changes)
+	}
+	reloadable := map[string]bool{}
+	for _, c := range changes {
+		reloadable[c.Path] = c.Reloadable
+	}
+	if !reloadable["log_level"] || !reloadable["cors.allowed_origins"] {
+		t.Errorf("log_level and cors.allowed_origins should be reloadable: %+v", changes)
+	}
+	if r, ok := reloadable["server.port"]; !ok || r {
+		t.Errorf("server.port should be reported as not reloadable: %+v", changes)
+	}
+
+	merged := current.Merge(next)
+	if merged.LogLevel != "debug" || merged.CORS.AllowedOrigins[0] != "https://app.example" {
+		t.Errorf("reloadable settings not applied: %+v", merged)
+	}
+	if merged.Server.Port != current.Server.Port {
+		t.Errorf("server.port changed on merge: %d", merged.Server.Port)
+	}
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// applyEnv overrides every field tagged with `env:"NAME"` whose variable is
+// set. lookup is os.LookupEnv outside of tests.
+func applyEnv(cfg *Config, lookup func(string) (string, bool)) error {
+	return walkFields(reflect.ValueOf(cfg).Elem(), "", func(_ string, field reflect.StructField, v reflect.Value) error {
+		name := field.Tag.Get("env")
+		if name == "" {
+			return nil
+		}
+		raw, ok := lookup(name)
+		if !ok {
+			return nil
+		}
+		if err := setFromString(v, raw); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		return nil
+	})
+}
+
+// walkFields calls fn for every non-struct field of v, descending into
+// nested structs. path is the dotted YAML key of the field, e.g. "server.port".
+func walkFields(v reflect.Value, prefix string, fn func(path string, field reflect.StructField, v reflect.Value) error) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fv := v.Field(i)
+		path := field.Tag.Get("yaml")
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+		if fv.Kind() == reflect.Struct {
+			if err := walkFields(fv, path, fn); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := fn(path, field, fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setFromString parses raw according to the kind of v and stores it.
+func setFromString(v reflect.Value, raw string) error {
+	raw = strings.TrimSpace(raw)
+
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", raw)
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		v.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+		v.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", raw)
+		}
+		v.SetBool(b)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported list type %s", v.Type())
+		}
+		parts := []string{}
+		for _, p := range strings.Split(raw, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				parts = append(parts, p)
+			}
+		}
+		v.Set(reflect.ValueOf(parts))
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Change describes a single setting that differs between two configurations.
+type Change struct {
+	Path       string
+	Old        string
+	New        string
+	Reloadable bool
+}
+
+// Diff compares two configurations field by field and returns every setting
+// that changed. Secret values are redacted in the result.
+func Diff(old, new *Config) []Change {
+	var changes []Change
+	walkPair(reflect.ValueOf(old.Redacted()).Elem(), reflect.ValueOf(new.Redacted()).Elem(), "",
+		func(path string, field reflect.StructField, a, b reflect.Value) {
+			if reflect.DeepEqual(a.Interface(), b.Interface()) {
+				return
+			}
+			changes = append(changes, Change{
+				Path:       path,
+				Old:        fmt.Sprint(a.Interface()),
+				New:        fmt.Sprint(b.Interface()),
+				Reloadable: field.Tag.Get("reload") == "true",
+			})
+		})
+	return changes
+}
+
+// Merge returns a copy of c with every reloadable field taken from next.
+// Settings that require a restart keep their current values.
+func (c *Config) Merge(next *Config) *Config {
+	out := *c
+	walkPair(reflect.ValueOf(&out).Elem(), reflect.ValueOf(next).Elem(), "",
+		func(_ string, field reflect.StructField, a, b reflect.Value) {
+			if field.Tag.Get("reload") == "true" {
+				a.Set(b)
+			}
+		})
+	return &out
+}
+
+// walkPair walks two values of the same struct type in lockstep, calling fn
+// for every non-struct field.
+func walkPair(a, b reflect.Value, prefix string, fn func(path string, field reflect.StructField, a, b reflect.Value)) {
+	t := a.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		path := field.Tag.Get("yaml")
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+		if a.Field(i).Kind() == reflect.Struct {
+			walkPair(a.Field(i), b.Field(i), path, fn)
+			continue
+		}
+		fn(path, field, a.Field(i), b.Field(i))
+	}
+}
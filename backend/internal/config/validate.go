@@ -0,0 +1,167 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+var validJournalModes = map[string]bool{
+	"DELETE": true, "TRUNCATE": true, "PERSIST": true, "MEMORY": true, "WAL": true, "OFF": true,
+}
+
+var validSynchronous = map[string]bool{
+	"OFF": true, "NORMAL": true, "FULL": true, "EXTRA": true,
+}
+
+// validItemStatuses mirrors the CHECK constraint on items.status.
+var validItemStatuses = map[string]bool{
+	"pending": true, "in_progress": true, "completed": true,
+}
+
+var validMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+	http.MethodOptions: true,
+}
+
+// Validate checks the configuration and returns every problem found, one per
+// line, so a misconfigured deployment can be fixed in a single pass.
+func (c *Config) Validate() error {
+	var errs []error
+	add := func(field, format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("%s: %s", field, fmt.Sprintf(format, args...)))
+	}
+
+	if strings.TrimSpace(c.AppName) == "" {
+		add("app_name", "must not be empty")
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		add("log_level", "must be one of debug, info, warn, error, got %q", c.LogLevel)
+	}
+
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		add("server.port", "must be between 1 and 65535, got %d", c.Server.Port)
+	}
+	if c.Server.ReadTimeout <= 0 {
+		add("server.read_timeout", "must be positive, got %s", c.Server.ReadTimeout)
+	}
+	if c.Server.WriteTimeout <= 0 {
+		add("server.write_timeout", "must be positive, got %s", c.Server.WriteTimeout)
+	}
+	if c.Server.IdleTimeout <= 0 {
+		add("server.idle_timeout", "must be positive, got %s", c.Server.IdleTimeout)
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		add("server.shutdown_timeout", "must be positive, got %s", c.Server.ShutdownTimeout)
+	}
+
+	if len(c.CORS.AllowedOrigins) == 0 {
+		add("cors.allowed_origins", "must contain at least one origin")
+	}
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin == "*" && c.CORS.AllowCredentials {
+			add("cors.allowed_origins", "wildcard origin cannot be combined with allow_credentials")
+		}
+	}
+	for _, m := range c.CORS.AllowedMethods {
+		if !validMethods[strings.ToUpper(m)] {
+			add("cors.allowed_methods", "unknown HTTP method %q", m)
+		}
+	}
+	if c.CORS.MaxAge < 0 {
+		add("cors.max_age", "must not be negative, got %d", c.CORS.MaxAge)
+	}
+
+	if c.RateLimit.RequestsPerSecond < 0 {
+		add("rate_limit.requests_per_second", "must not be negative, got %g", c.RateLimit.RequestsPerSecond)
+	}
+	if c.RateLimit.RequestsPerSecond > 0 && c.RateLimit.Burst < 1 {
+		add("rate_limit.burst", "must be at least 1 when rate limiting is enabled, got %d", c.RateLimit.Burst)
+	}
+
+	if c.Database.MaxOpenConns < 0 {
+		add("database.max_open_conns", "must not be negative, got %d", c.Database.MaxOpenConns)
+	}
+	if c.Database.MaxIdleConns < 0 {
+		add("database.max_idle_conns", "must not be negative, got %d", c.Database.MaxIdleConns)
+	}
+	if c.Database.MaxOpenConns > 0 && c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		add("database.max_idle_conns", "must not exceed max_open_conns (%d), got %d", c.Database.MaxOpenConns, c.Database.MaxIdleConns)
+	}
+	if c.Database.ConnMaxLifetime < 0 {
+		add("database.conn_max_lifetime", "must not be negative, got %s", c.Database.ConnMaxLifetime)
+	}
+	if !validJournalModes[strings.ToUpper(c.Database.JournalMode)] {
+		add("database.journal_mode", "must be one of DELETE, TRUNCATE, PERSIST, MEMORY, WAL, OFF, got %q", c.Database.JournalMode)
+	}
+	if !validSynchronous[strings.ToUpper(c.Database.Synchronous)] {
+		add("database.synchronous", "must be one of OFF, NORMAL, FULL, EXTRA, got %q", c.Database.Synchronous)
+	}
+	if c.Database.BusyTimeout < 0 {
+		add("database.busy_timeout", "must not be negative, got %s", c.Database.BusyTimeout)
+	}
+
+	if strings.TrimSpace(c.Backup.Dir) == "" {
+		add("backup.dir", "must not be empty")
+	}
+	if c.Admin.Token != "" && len(c.Admin.Token) < 16 {
+		add("admin.token", "must be at least 16 characters")
+	}
+
+	if c.Items.RankRebalanceInterval < 0 {
+		add("items.rank_rebalance_interval", "must not be negative, got %s", c.Items.RankRebalanceInterval)
+	}
+	if c.Items.RecurrenceInterval < 0 {
+		add("items.recurrence_interval", "must not be negative, got %s", c.Items.RecurrenceInterval)
+	}
+	for _, from := range slices.Sorted(maps.Keys(c.Items.StatusTransitions)) {
+		if !validItemStatuses[from] {
+			add("items.status_transitions", "unknown status %q", from)
+		}
+		for _, to := range c.Items.StatusTransitions[from] {
+			if !validItemStatuses[to] {
+				add("items.status_transitions."+from, "unknown status %q", to)
+			}
+		}
+	}
+
+	switch c.Attachments.Storage {
+	case "local":
+		if strings.TrimSpace(c.Attachments.Dir) == "" {
+			add("attachments.dir", "must not be empty")
+		}
+	case "s3":
+		if c.Attachments.S3.Endpoint == "" {
+			add("attachments.s3.endpoint", "must not be empty when storage is s3")
+		}
+		if c.Attachments.S3.Bucket == "" {
+			add("attachments.s3.bucket", "must not be empty when storage is s3")
+		}
+		if c.Attachments.S3.Region == "" {
+			add("attachments.s3.region", "must not be empty when storage is s3")
+		}
+	default:
+		add("attachments.storage", "must be one of local, s3, got %q", c.Attachments.Storage)
+	}
+	if c.Attachments.MaxSize < 1 {
+		add("attachments.max_size", "must be positive, got %d", c.Attachments.MaxSize)
+	}
+	for _, t := range c.Attachments.AllowedTypes {
+		if major, minor, ok := strings.Cut(t, "/"); !ok || major == "" || minor == "" {
+			add("attachments.allowed_types", "invalid MIME type %q", t)
+		}
+	}
+
+	return errors.Join(errs...)
+}
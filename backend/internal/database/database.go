@@ -0,0 +1,137 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/keel/api/internal/config"
+)
+
+// Dialect identifies a supported database engine. Its value is also the
+// name of the engine's directory in the migrations FS.
+type Dialect string
+
+const (
+	SQLite   Dialect = "sqlite"
+	Postgres Dialect = "postgres"
+)
+
+// DB is a database opened with separate connection pools for writes and
+// reads.
+//
+// SQLite allows one writer at a time. Writer is limited to a single
+// connection so concurrent writes queue inside database/sql instead of
+// failing with SQLITE_BUSY, while Reader serves concurrent reads from WAL
+// snapshots. For Postgres both fields refer to the same pool. DB implements
+// store.DBTX and routes each statement to the appropriate pool, so it can be
+// passed directly to a store constructor.
+type DB struct {
+	Writer  *sql.DB
+	Reader  *sql.DB
+	Dialect Dialect
+}
+
+// DialectFromURL selects the engine from the scheme of a database URL.
+// postgres:// and postgresql:// URLs use Postgres; file: URLs, plain paths
+// and :memory: use SQLite.
+func DialectFromURL(rawURL string) (Dialect, error) {
+	if rawURL == ":memory:" || !strings.Contains(rawURL, ":") {
+		return SQLite, nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid database url: %w", err)
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "postgres", "postgresql":
+		return Postgres, nil
+	case "file", "sqlite", "sqlite3":
+		return SQLite, nil
+	default:
+		return "", fmt.Errorf("unsupported database url scheme %q", u.Scheme)
+	}
+}
+
+// Open opens the database described by cfg using the driver selected by the
+// URL scheme.
+func Open(cfg config.DatabaseConfig) (*DB, error) {
+	dialect, err := DialectFromURL(cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	switch dialect {
+	case Postgres:
+		return openPostgres(cfg)
+	default:
+		return openSQLite(cfg)
+	}
+}
+
+// Close closes both connection pools.
+func (db *DB) Close() error {
+	err := db.Writer.Close()
+	if db.Reader != db.Writer {
+		err = errors.Join(err, db.Reader.Close())
+	}
+	return err
+}
+
+// ExecContext runs a statement on the writer.
+func (db *DB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return db.Writer.ExecContext(ctx, query, args...)
+}
+
+// PrepareContext prepares a statement on the pool that will execute it.
+func (db *DB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return db.pool(query).PrepareContext(ctx, query)
+}
+
+// QueryContext runs a query on the reader, or on the writer if it modifies
+// data (e.g. INSERT ... RETURNING).
+func (db *DB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return db.pool(query).QueryContext(ctx, query, args...)
+}
+
+// QueryRowContext runs a single-row query on the reader, or on the writer if
+// it modifies data.
+func (db *DB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return db.pool(query).QueryRowContext(ctx, query, args...)
+}
+
+// BeginTx starts a transaction on the writer.
+func (db *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return db.Writer.BeginTx(ctx, opts)
+}
+
+// pool picks the connection pool for query.
+func (db *DB) pool(query string) *sql.DB {
+	if isReadOnly(query) {
+		return db.Reader
+	}
+	return db.Writer
+}
+
+// isReadOnly reports whether query is a plain SELECT, ignoring leading
+// whitespace and SQL comments such as sqlc's "-- name:" header.
+func isReadOnly(query string) bool {
+	q := query
+	for {
+		q = strings.TrimSpace(q)
+		if !strings.HasPrefix(q, "--") {
+			break
+		}
+		nl := strings.IndexByte(q, '\n')
+		if nl < 0 {
+			return false
+		}
+		q = q[nl+1:]
+	}
+	return len(q) >= 6 && strings.EqualFold(q[:6], "SELECT")
+}
@@ -9,14 +9,18 @@ import (
 	"strings"
 )
 
-// RunMigrations executes SQL migration files from the given embed.FS
+// RunMigrations executes SQL migration files from the given embed.FS.
+//
+// The runner's own statements are written to work on both SQLite and
+// Postgres: TIMESTAMP is accepted by both, and SQLite binds positional
+// arguments to $N placeholders just as Postgres does.
 func RunMigrations(db *sql.DB, fs embed.FS, dir string) error {
-	slog.Info("Running migrations...")
+	slog.Info("Running migrations...", "dir", dir)
 
 	// ensure schema_migrations table exists
 	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
 		version INTEGER PRIMARY KEY,
-		applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 	);`)
 	if err != nil {
 		return fmt.Errorf("failed to create schema_migrations table: %w", err)
@@ -51,38 +55,44 @@ func RunMigrations(db *sql.DB, fs embed.FS, dir string) error {
 	return nil
 }
 
-func applyMigration(db *sql.DB, fs embed.FS, path string) error {
-	// Simple version extraction (assuming format 001_name.sql)
-	// mostly for display or robust tracking, but here we just check if it's run.
-	// A real implementation would parse the version number.
-	// For this template, we'll just skip if the filename exists in the table?
-	// Actually, let's just run them if they haven't been run.
-	// This simple runner assumes files are append-only and immutable.
-
-	filename := path[strings.LastIndex(path, "/")+1:]
+// LatestVersion returns the highest migration version in dir, i.e. the
+// schema version a fully migrated database has.
+func LatestVersion(fs embed.FS, dir string) (int, error) {
+	entries, err := fs.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read migrations directory: %w", err)
+	}
 
-	// Check if migration already applied
-	var exists bool
-	err := db.QueryRow("SELECT EXISTS(SELECT 1 FROM schema_migrations WHERE version = ?)", filename).Scan(&exists)
-	if err == nil && exists {
-		// Already applied
-		return nil
+	latest := 0
+	for _, entry := range entries {
+		var version int
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		if _, err := fmt.Sscanf(entry.Name(), "%d_", &version); err != nil {
+			return 0, fmt.Errorf("invalid migration filename format %s, expected NNN_name.sql: %w", entry.Name(), err)
+		}
+		if version > latest {
+			latest = version
+		}
 	}
-	// Note: The schema_migrations table created in 001_init.sql uses INTEGER version.
-	// But my code here uses filename as version?
-	// Wait, the 001_init.sql creates schema_migrations with INTEGER.
-	// Let's adjust the schema_migrations table creation in this file to use TEXT for flexibility
-	// OR parse the integer.
-	// Parsing integer is safer for ordering.
+	return latest, nil
+}
+
+func applyMigration(db *sql.DB, fs embed.FS, path string) error {
+	// Migrations are tracked by the integer prefix of their filename
+	// (001_name.sql). This simple runner assumes files are append-only and
+	// immutable.
+	filename := path[strings.LastIndex(path, "/")+1:]
 
 	var version int
-	_, err = fmt.Sscanf(filename, "%d_", &version)
+	_, err := fmt.Sscanf(filename, "%d_", &version)
 	if err != nil {
 		return fmt.Errorf("invalid migration filename format %s, expected NNN_name.sql: %w", filename, err)
 	}
 
-	// Check by integer version
-	err = db.QueryRow("SELECT EXISTS(SELECT 1 FROM schema_migrations WHERE version = ?)", version).Scan(&exists)
+	var exists bool
+	err = db.QueryRow("SELECT EXISTS(SELECT 1 FROM schema_migrations WHERE version = $1)", version).Scan(&exists)
 	if err != nil {
 		return fmt.Errorf("failed to check migration status: %w", err)
 	}
@@ -109,7 +119,7 @@ func applyMigration(db *sql.DB, fs embed.FS, path string) error {
 		return err
 	}
 
-	if _, err := tx.Exec("INSERT INTO schema_migrations (version) VALUES (?)", version); err != nil {
+	if _, err := tx.Exec("INSERT INTO schema_migrations (version) VALUES ($1)", version); err != nil {
 		return err
 	}
 
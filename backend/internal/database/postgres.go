@@ -0,0 +1,28 @@
+package database
+
+import (
+	"database/sql"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/keel/api/internal/config"
+)
+
+// openPostgres opens a Postgres connection pool. Postgres handles concurrent
+// writers itself, so Writer and Reader share one pool sized by
+// MaxOpenConns; the SQLite pragma settings are ignored.
+func openPostgres(cfg config.DatabaseConfig) (*DB, error) {
+	pool, err := sql.Open("pgx", cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+	pool.SetMaxOpenConns(cfg.MaxOpenConns)
+	pool.SetMaxIdleConns(cfg.MaxIdleConns)
+	pool.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
+	if err := pool.Ping(); err != nil {
+		_ = pool.Close()
+		return nil, err
+	}
+
+	return &DB{Writer: pool, Reader: pool, Dialect: Postgres}, nil
+}
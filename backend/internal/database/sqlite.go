@@ -0,0 +1,121 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/keel/api/internal/config"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// openSQLite opens the SQLite database described by cfg, applying the
+// configured pragmas to every connection.
+func openSQLite(cfg config.DatabaseConfig) (*DB, error) {
+	writerDSN, err := sqliteDSN(cfg, false)
+	if err != nil {
+		return nil, err
+	}
+
+	writer, err := sql.Open("sqlite3", writerDSN)
+	if err != nil {
+		return nil, err
+	}
+	writer.SetMaxOpenConns(1)
+	writer.SetMaxIdleConns(1)
+	lifetime := cfg.ConnMaxLifetime
+	if isMemory(cfg.URL) {
+		// An in-memory database lives only as long as its connection, so
+		// that connection must never be recycled.
+		lifetime = 0
+	}
+	writer.SetConnMaxLifetime(lifetime)
+
+	// Connect once so the database file exists and journal mode is set
+	// before any reader connects.
+	if err := writer.Ping(); err != nil {
+		_ = writer.Close()
+		return nil, err
+	}
+
+	// An in-memory database is private to its connection, so reads must use
+	// the writer's connection to see any data.
+	if isMemory(cfg.URL) {
+		return &DB{Writer: writer, Reader: writer, Dialect: SQLite}, nil
+	}
+
+	readerDSN, err := sqliteDSN(cfg, true)
+	if err != nil {
+		_ = writer.Close()
+		return nil, err
+	}
+
+	reader, err := sql.Open("sqlite3", readerDSN)
+	if err != nil {
+		_ = writer.Close()
+		return nil, err
+	}
+	reader.SetMaxOpenConns(cfg.MaxOpenConns)
+	reader.SetMaxIdleConns(cfg.MaxIdleConns)
+	reader.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
+	return &DB{Writer: writer, Reader: reader, Dialect: SQLite}, nil
+}
+
+// sqliteDSN builds a go-sqlite3 DSN from the configured URL and pragmas.
+// A sqlite:// scheme is accepted for symmetry with postgres:// and stripped.
+// Reader connections are opened with query_only so a misrouted write fails
+// loudly instead of contending for the write lock.
+func sqliteDSN(cfg config.DatabaseConfig, readOnly bool) (string, error) {
+	path, rawQuery, _ := strings.Cut(cfg.URL, "?")
+	for _, scheme := range []string{"sqlite://", "sqlite3://"} {
+		if strings.HasPrefix(path, scheme) {
+			path = "file:" + strings.TrimPrefix(path, scheme)
+		}
+	}
+	params, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", fmt.Errorf("invalid database url parameters: %w", err)
+	}
+
+	params.Set("_journal_mode", cfg.JournalMode)
+	params.Set("_busy_timeout", strconv.FormatInt(cfg.BusyTimeout.Milliseconds(), 10))
+	params.Set("_synchronous", cfg.Synchronous)
+	params.Set("_foreign_keys", strconv.FormatBool(cfg.ForeignKeys))
+	if readOnly {
+		params.Set("_query_only", "true")
+	} else {
+		// Take the write lock when a transaction begins rather than on its
+		// first write, avoiding deadlocks between upgrading transactions.
+		params.Set("_txlock", "immediate")
+	}
+
+	return path + "?" + params.Encode(), nil
+}
+
+// SQLitePath returns the filesystem path of the SQLite database in url.
+func SQLitePath(url string) (string, error) {
+	if isMemory(url) {
+		return "", errors.New("in-memory database has no file")
+	}
+
+	path, _, _ := strings.Cut(url, "?")
+	for _, prefix := range []string{"sqlite3://", "sqlite://", "file:"} {
+		path = strings.TrimPrefix(path, prefix)
+	}
+	if strings.HasPrefix(path, "//") {
+		path = strings.TrimPrefix(path, "//")
+	}
+	if path == "" {
+		return "", errors.New("database url has no file path")
+	}
+	return path, nil
+}
+
+// isMemory reports whether url refers to an in-memory database.
+func isMemory(url string) bool {
+	return strings.Contains(url, ":memory:") || strings.Contains(url, "mode=memory")
+}
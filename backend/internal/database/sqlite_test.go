@@ -0,0 +1,147 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keel/api/internal/config"
+)
+
+func openTestDB(t *testing.T, url string) *DB {
+	t.Helper()
+	cfg := config.Default().Database
+	cfg.URL = url
+	db, err := Open(cfg)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestOpenAppliesPragmas(t *testing.T) {
+	db := openTestDB(t, "file:"+filepath.Join(t.TempDir(), "test.db"))
+
+	var mode string
+	if err := db.Reader.QueryRow("PRAGMA journal_mode").Scan(&mode); err != nil {
+		t.Fatal(err)
+	}
+	if mode != "wal" {
+		t.Errorf("journal_mode = %q, want wal", mode)
+	}
+
+	var fk, timeout int
+	if err := db.Writer.QueryRow("PRAGMA foreign_keys").Scan(&fk); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Writer.QueryRow("PRAGMA busy_timeout").Scan(&timeout); err != nil {
+		t.Fatal(err)
+	}
+	if fk != 1 || timeout != 5000 {
+		t.Errorf("foreign_keys = %d, busy_timeout = %d; want 1, 5000", fk, timeout)
+	}
+
+	if got := db.Writer.Stats().MaxOpenConnections; got != 1 {
+		t.Errorf("writer MaxOpenConnections = %d, want 1", got)
+	}
+}
+
+func TestRouting(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t, "file:"+filepath.Join(t.TempDir(), "test.db"))
+
+	if _, err := db.ExecContext(ctx, "CREATE TABLE t (id INTEGER PRIMARY KEY, v TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+
+	// INSERT ... RETURNING goes through QueryRowContext and must reach the writer.
+	var id int
+	if err := db.QueryRowContext(ctx, "-- name: Insert :one\nINSERT INTO t (v) VALUES (?) RETURNING id", "a").Scan(&id); err != nil {
+		t.Fatalf("insert returning: %v", err)
+	}
+
+	var v string
+	if err := db.QueryRowContext(ctx, "-- name: Get :one\nSELECT v FROM t WHERE id = ?", id).Scan(&v); err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if v != "a" {
+		t.Errorf("v = %q, want a", v)
+	}
+
+	// The reader pool refuses writes outright.
+	_, err := db.Reader.ExecContext(ctx, "INSERT INTO t (v) VALUES ('b')")
+	if err == nil || !strings.Contains(err.Error(), "readonly") {
+		t.Errorf("expected readonly error from reader, got %v", err)
+	}
+}
+
+func TestInMemorySharesPool(t *testing.T) {
+	db := openTestDB(t, ":memory:")
+	if db.Reader != db.Writer {
+		t.Fatal("in-memory database should use a single pool")
+	}
+}
+
+func TestInMemoryOutlivesConnMaxLifetime(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.Default().Database
+	cfg.URL = ":memory:"
+	cfg.ConnMaxLifetime = 10 * time.Millisecond
+	db, err := Open(cfg)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	if _, err := db.ExecContext(ctx, "CREATE TABLE t (id INTEGER PRIMARY KEY)"); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * cfg.ConnMaxLifetime)
+
+	var n int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM t").Scan(&n); err != nil {
+		t.Fatalf("table gone after the connection lifetime: %v", err)
+	}
+}
+
+func TestIsReadOnly(t *testing.T) {
+	cases := map[string]bool{
+		"SELECT 1":                             true,
+		"  select * from t":                    true,
+		"-- name: X :one\nSELECT 1":            true,
+		"-- name: X :one\nINSERT INTO t ...":   false,
+		"UPDATE t SET v = 1 RETURNING *":       false,
+		"-- only a comment":                    false,
+		"WITH x AS (DELETE FROM t) SELECT 1":   false,
+		"-- a\n-- b\n  SELECT COUNT(*) FROM t": true,
+	}
+	for q, want := range cases {
+		if got := isReadOnly(q); got != want {
+			t.Errorf("isReadOnly(%q) = %v, want %v", q, got, want)
+		}
+	}
+}
+
+func TestDialectFromURL(t *testing.T) {
+	cases := map[string]Dialect{
+		":memory:":                          SQLite,
+		"./data/keel.db":                    SQLite,
+		"file:./data/keel.db?_fk=on":        SQLite,
+		"sqlite:///var/lib/keel.db":         SQLite,
+		"postgres://u:p@localhost:5432/db":  Postgres,
+		"postgresql://localhost/db?sslmode": Postgres,
+	}
+	for in, want := range cases {
+		got, err := DialectFromURL(in)
+		if err != nil || got != want {
+			t.Errorf("DialectFromURL(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+
+	if _, err := DialectFromURL("mysql://localhost/db"); err == nil {
+		t.Error("expected error for unsupported scheme")
+	}
+}
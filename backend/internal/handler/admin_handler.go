@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/keel/api/internal/apierror"
+	"github.com/keel/api/internal/service"
+)
+
+// AdminHandler handles HTTP requests for administrative operations. Routes
+// must be mounted behind admin authentication.
+type AdminHandler struct {
+	backupService *service.BackupService
+}
+
+// NewAdminHandler creates a new AdminHandler.
+func NewAdminHandler(backupService *service.BackupService) *AdminHandler {
+	return &AdminHandler{backupService: backupService}
+}
+
+// RegisterRoutes registers admin routes on the given router.
+func (h *AdminHandler) RegisterRoutes(r chi.Router) {
+	r.Post("/admin/backups", h.CreateBackup)
+}
+
+// BackupResponse describes a database snapshot.
+type BackupResponse struct {
+	Name          string `json:"name"`
+	SizeBytes     int64  `json:"sizeBytes"`
+	SchemaVersion int    `json:"schemaVersion"`
+	Compressed    bool   `json:"compressed"`
+	CreatedAt     string `json:"createdAt"`
+}
+
+// CreateBackup handles POST /api/admin/backups
+func (h *AdminHandler) CreateBackup(w http.ResponseWriter, r *http.Request) {
+	result, err := h.backupService.Create(r.Context())
+	if err != nil {
+		if errors.Is(err, service.ErrBackupUnsupported) {
+			apierror.BadRequest(w, r, "Backups are only supported for file-based SQLite databases", nil)
+			return
+		}
+		slog.Error("failed to create backup", "error", err)
+		apierror.InternalError(w, r, "Failed to create backup")
+		return
+	}
+
+	slog.Info("database backup created", "path", result.Path, "size", result.Size)
+
+	writeJSON(w, http.StatusCreated, BackupResponse{
+		Name:          filepath.Base(result.Path),
+		SizeBytes:     result.Size,
+		SchemaVersion: result.SchemaVersion,
+		Compressed:    result.Compressed,
+		CreatedAt:     result.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	})
+}
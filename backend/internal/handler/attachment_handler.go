@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/keel/api/internal/apierror"
+	"github.com/keel/api/internal/service"
+	"github.com/keel/api/internal/storage"
+)
+
+// multipartOverhead is how far a multipart upload may exceed the file size
+// limit, to make room for part headers and boundaries.
+const multipartOverhead = 64 << 10
+
+// AttachmentHandler handles HTTP requests for files attached to items.
+type AttachmentHandler struct {
+	attachmentService *service.AttachmentService
+}
+
+// NewAttachmentHandler creates a new AttachmentHandler.
+func NewAttachmentHandler(attachmentService *service.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{attachmentService: attachmentService}
+}
+
+// RegisterRoutes registers attachment routes on the given router.
+func (h *AttachmentHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/items/{itemId}/attachments", h.List)
+	r.Post("/items/{itemId}/attachments", h.Upload)
+	r.Get("/items/{itemId}/attachments/{id}", h.Get)
+	r.Get("/items/{itemId}/attachments/{id}/content", h.Download)
+	r.Delete("/items/{itemId}/attachments/{id}", h.Delete)
+}
+
+// AttachmentResponse represents an attachment in the API response.
+type AttachmentResponse struct {
+	ID          string  `json:"id"`
+	ItemID      string  `json:"itemId"`
+	UploaderID  *string `json:"uploaderId"`
+	Filename    string  `json:"filename"`
+	ContentType string  `json:"contentType"`
+	Size        int64   `json:"size"`
+	CreatedAt   string  `json:"createdAt"`
+}
+
+// AttachmentListResponse represents an item's attachments.
+type AttachmentListResponse struct {
+	Data []AttachmentResponse `json:"data"`
+}
+
+// List handles GET /api/items/{itemId}/attachments
+func (h *AttachmentHandler) List(w http.ResponseWriter, r *http.Request) {
+	itemID := chi.URLParam(r, "itemId")
+
+	attachments, err := h.attachmentService.List(r.Context(), itemID)
+	if err != nil {
+		if errors.Is(err, service.ErrItemNotFound) {
+			apierror.NotFound(w, r, "Item not found")
+			return
+		}
+		slog.Error("failed to list attachments", "error", err, "itemId", itemID)
+		apierror.InternalError(w, r, "Failed to list attachments")
+		return
+	}
+
+	response := AttachmentListResponse{Data: make([]AttachmentResponse, len(attachments))}
+	for i, a := range attachments {
+		response.Data[i] = toAttachmentResponse(&a)
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Upload handles POST /api/items/{itemId}/attachments. The file is the
+// "file" part of a multipart/form-data body and is streamed to storage.
+func (h *AttachmentHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	itemID := chi.URLParam(r, "itemId")
+	limits := h.attachmentService.Limits()
+	r.Body = http.MaxBytesReader(w, r.Body, limits.MaxSize+multipartOverhead)
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		apierror.BadRequest(w, r, "Expected a multipart/form-data body", nil)
+		return
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			apierror.ValidationError(w, r, "File is required", nil)
+			return
+		}
+		if err != nil {
+			h.writeUploadError(w, r, err, limits)
+			return
+		}
+		if part.FormName() != "file" {
+			continue
+		}
+
+		attachment, err := h.attachmentService.Upload(r.Context(), service.UploadAttachmentInput{
+			ItemID:      itemID,
+			Filename:    cleanFilename(part.FileName()),
+			ContentType: part.Header.Get("Content-Type"),
+			Content:     part,
+			UploaderID:  actorID(r),
+		})
+		if err != nil {
+			h.writeUploadError(w, r, err, limits)
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, toAttachmentResponse(attachment))
+		return
+	}
+}
+
+// writeUploadError writes the response for an error from reading or
+// storing an upload.
+func (h *AttachmentHandler) writeUploadError(w http.ResponseWriter, r *http.Request, err error, limits service.AttachmentLimits) {
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.Is(err, service.ErrItemNotFound):
+		apierror.NotFound(w, r, "Item not found")
+	case errors.Is(err, service.ErrAttachmentTooLarge), errors.As(err, &tooLarge):
+		apierror.PayloadTooLarge(w, r, fmt.Sprintf("File exceeds the limit of %d bytes", limits.MaxSize))
+	case errors.Is(err, service.ErrAttachmentTypeNotAllowed):
+		apierror.UnsupportedMediaType(w, r, "File type is not allowed", map[string]interface{}{
+			"allowed": limits.AllowedTypes,
+		})
+	default:
+		slog.Error("failed to upload attachment", "error", err, "itemId", chi.URLParam(r, "itemId"))
+		apierror.InternalError(w, r, "Failed to upload attachment")
+	}
+}
+
+// Get handles GET /api/items/{itemId}/attachments/{id}
+func (h *AttachmentHandler) Get(w http.ResponseWriter, r *http.Request) {
+	itemID := chi.URLParam(r, "itemId")
+	id := chi.URLParam(r, "id")
+
+	attachment, err := h.attachmentService.Get(r.Context(), itemID, id)
+	if err != nil {
+		writeAttachmentError(w, r, err, "get")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAttachmentResponse(attachment))
+}
+
+// Download handles GET /api/items/{itemId}/attachments/{id}/content. The
+// file is streamed from storage; Range and conditional requests are
+// supported.
+func (h *AttachmentHandler) Download(w http.ResponseWriter, r *http.Request) {
+	itemID := chi.URLParam(r, "itemId")
+	id := chi.URLParam(r, "id")
+
+	attachment, content, err := h.attachmentService.Open(r.Context(), itemID, id)
+	if err != nil {
+		writeAttachmentError(w, r, err, "download")
+		return
+	}
+	defer func() { _ = content.Close() }()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, attachment.Filename, attachment.CreatedAt, content)
+}
+
+// Delete handles DELETE /api/items/{itemId}/attachments/{id}
+func (h *AttachmentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	itemID := chi.URLParam(r, "itemId")
+	id := chi.URLParam(r, "id")
+
+	if err := h.attachmentService.Delete(r.Context(), itemID, id); err != nil {
+		writeAttachmentError(w, r, err, "delete")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeAttachmentError writes the response for an error from an attachment
+// operation; op names the operation in the log and the message.
+func writeAttachmentError(w http.ResponseWriter, r *http.Request, err error, op string) {
+	switch {
+	case errors.Is(err, service.ErrItemNotFound):
+		apierror.NotFound(w, r, "Item not found")
+	case errors.Is(err, service.ErrAttachmentNotFound), errors.Is(err, storage.ErrNotFound):
+		apierror.NotFound(w, r, "Attachment not found")
+	default:
+		slog.Error("failed to "+op+" attachment", "error", err, "id", chi.URLParam(r, "id"))
+		apierror.InternalError(w, r, "Failed to "+op+" attachment")
+	}
+}
+
+// cleanFilename reduces a client-supplied file name to its last path
+// element.
+func cleanFilename(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	if name == "." || name == "/" {
+		return "attachment"
+	}
+	return name
+}
+
+// toAttachmentResponse converts a service attachment to an API response.
+func toAttachmentResponse(a *service.Attachment) AttachmentResponse {
+	return AttachmentResponse{
+		ID:          a.ID,
+		ItemID:      a.ItemID,
+		UploaderID:  optional(a.UploaderID),
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		CreatedAt:   a.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
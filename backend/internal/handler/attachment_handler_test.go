@@ -0,0 +1,141 @@
+package handler_test
+
+import (
+	"bytes"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/keel/api/internal/apierror"
+	"github.com/keel/api/internal/apitest"
+	"github.com/keel/api/internal/app"
+	"github.com/keel/api/internal/config"
+	"github.com/keel/api/internal/handler"
+)
+
+func TestAttachments(t *testing.T) {
+	cfg := config.Default()
+	cfg.Attachments.MaxSize = 1024
+	s := apitest.NewWithConfig(t, cfg, app.Options{})
+	uploader := s.User().Create()
+	item := s.Item().Create()
+	base := "/api/items/" + item.ID + "/attachments"
+
+	var notes handler.AttachmentResponse
+	s.Upload(base, "file", `C:\Users\ada\notes.txt`, "", []byte("0123456789abcdef")).
+		Header("X-User-ID", uploader.ID).Expect(http.StatusCreated).JSON(&notes)
+	if notes.Filename != "notes.txt" || notes.ContentType != "text/plain; charset=utf-8" || notes.Size != 16 ||
+		deref(notes.UploaderID) != uploader.ID || notes.ItemID != item.ID {
+		t.Errorf("uploaded = %+v", notes)
+	}
+	var pdf handler.AttachmentResponse
+	s.Upload(base, "file", "spec.pdf", "application/pdf", []byte("%PDF-1.4")).
+		Expect(http.StatusCreated).JSON(&pdf)
+	if pdf.ContentType != "application/pdf" || pdf.UploaderID != nil {
+		t.Errorf("uploaded = %+v", pdf)
+	}
+
+	var list handler.AttachmentListResponse
+	s.Get(base).Expect(http.StatusOK).JSON(&list)
+	if len(list.Data) != 2 || list.Data[0].ID != notes.ID || list.Data[1].ID != pdf.ID {
+		t.Errorf("list = %+v", list)
+	}
+	var got handler.AttachmentResponse
+	s.Get(base + "/" + notes.ID).Expect(http.StatusOK).JSON(&got)
+	if got.ID != notes.ID || got.Filename != notes.Filename || deref(got.UploaderID) != uploader.ID {
+		t.Errorf("get = %+v, want %+v", got, notes)
+	}
+
+	res := s.Get(base + "/" + notes.ID + "/content").Expect(http.StatusOK)
+	if res.Body.String() != "0123456789abcdef" || res.Header().Get("Content-Type") != notes.ContentType ||
+		!strings.Contains(res.Header().Get("Content-Disposition"), `filename=notes.txt`) {
+		t.Errorf("download = %q, headers %v", res.Body, res.Header())
+	}
+	res = s.Get(base+"/"+notes.ID+"/content").Header("Range", "bytes=4-7").Expect(http.StatusPartialContent)
+	if res.Body.String() != "4567" || res.Header().Get("Content-Range") != "bytes 4-7/16" {
+		t.Errorf("range = %q, Content-Range %q", res.Body, res.Header().Get("Content-Range"))
+	}
+	s.Get(base+"/"+notes.ID+"/content").Header("Range", "bytes=100-").Expect(http.StatusRequestedRangeNotSatisfiable)
+
+	// Limits come from the config.
+	s.Upload(base, "file", "big.txt", "text/plain", bytes.Repeat([]byte("x"), 1025)).
+		Expect(http.StatusRequestEntityTooLarge).Error(apierror.CodeTooLarge)
+	s.Upload(base, "file", "exact.txt", "text/plain", bytes.Repeat([]byte("x"), 1024)).
+		Expect(http.StatusCreated)
+	e := s.Upload(base, "file", "run.sh", "application/x-sh", []byte("echo hi")).
+		Expect(http.StatusUnsupportedMediaType).Error(apierror.CodeUnsupportedType)
+	if e.Details == nil {
+		t.Error("unsupported type error without the allowed types")
+	}
+	s.Upload(base, "attachment", "notes.txt", "text/plain", []byte("hi")).
+		Expect(http.StatusBadRequest).Error(apierror.CodeValidationError)
+	s.Post(base, map[string]string{"file": "hi"}).Expect(http.StatusBadRequest).Error(apierror.CodeBadRequest)
+	s.Upload("/api/items/missing/attachments", "file", "a.txt", "text/plain", []byte("hi")).
+		Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+
+	s.Delete(base + "/" + pdf.ID).Expect(http.StatusNoContent)
+	s.Get(base + "/" + pdf.ID).Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+	s.Get(base + "/" + pdf.ID + "/content").Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+	s.Delete(base + "/" + pdf.ID).Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+
+	// Deleting the item removes its files.
+	s.Delete("/api/items/" + item.ID).Expect(http.StatusNoContent)
+	if files := storedFiles(t, cfg.Attachments.Dir); len(files) != 0 {
+		t.Errorf("files after deleting the item = %v", files)
+	}
+}
+
+func TestAttachmentsRemovedWithUser(t *testing.T) {
+	cfg := config.Default()
+	s := apitest.NewWithConfig(t, cfg, app.Options{})
+	owner := s.User().Create()
+	item := s.Item().Owner(owner.ID).Create()
+
+	s.Upload("/api/items/"+item.ID+"/attachments", "file", "a.txt", "text/plain", []byte("hi")).
+		Expect(http.StatusCreated)
+	if files := storedFiles(t, cfg.Attachments.Dir); len(files) != 1 {
+		t.Fatalf("files after upload = %v, want 1", files)
+	}
+
+	s.Delete("/api/users/" + owner.ID).Expect(http.StatusNoContent)
+	if files := storedFiles(t, cfg.Attachments.Dir); len(files) != 0 {
+		t.Errorf("files after deleting the owner = %v", files)
+	}
+}
+
+func TestAttachmentFileMissing(t *testing.T) {
+	cfg := config.Default()
+	s := apitest.NewWithConfig(t, cfg, app.Options{})
+	base := "/api/items/" + s.Item().Create().ID + "/attachments"
+
+	var a handler.AttachmentResponse
+	s.Upload(base, "file", "a.txt", "text/plain", []byte("hi")).Expect(http.StatusCreated).JSON(&a)
+	files := storedFiles(t, cfg.Attachments.Dir)
+	if len(files) != 1 {
+		t.Fatalf("files after upload = %v, want 1", files)
+	}
+	if err := os.Remove(files[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Get(base + "/" + a.ID + "/content").Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+}
+
+// storedFiles returns the files under dir.
+func storedFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			files = append(files, path)
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
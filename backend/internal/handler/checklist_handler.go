@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/keel/api/internal/apierror"
+	"github.com/keel/api/internal/service"
+)
+
+// ChecklistHandler handles HTTP requests for item checklists.
+type ChecklistHandler struct {
+	checklistService *service.ChecklistService
+}
+
+// NewChecklistHandler creates a new ChecklistHandler.
+func NewChecklistHandler(checklistService *service.ChecklistService) *ChecklistHandler {
+	return &ChecklistHandler{checklistService: checklistService}
+}
+
+// RegisterRoutes registers checklist routes on the given router.
+func (h *ChecklistHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/items/{itemId}/checklist", h.List)
+	r.Post("/items/{itemId}/checklist", h.Create)
+	r.Put("/items/{itemId}/checklist/{id}", h.Update)
+	r.Delete("/items/{itemId}/checklist/{id}", h.Delete)
+}
+
+// CreateChecklistEntryRequest represents the request body for adding a
+// checklist entry.
+type CreateChecklistEntryRequest struct {
+	Text string `json:"text"`
+	Done bool   `json:"done,omitempty"`
+	// Position defaults to the end of the checklist.
+	Position *int `json:"position,omitempty"`
+}
+
+// UpdateChecklistEntryRequest represents the request body for changing a
+// checklist entry.
+type UpdateChecklistEntryRequest struct {
+	Text     *string `json:"text,omitempty"`
+	Done     *bool   `json:"done,omitempty"`
+	Position *int    `json:"position,omitempty"`
+}
+
+// ChecklistEntryResponse represents a checklist entry in the API response.
+type ChecklistEntryResponse struct {
+	ID        string `json:"id"`
+	ItemID    string `json:"itemId"`
+	Text      string `json:"text"`
+	Done      bool   `json:"done"`
+	Position  int    `json:"position"`
+	CreatedAt string `json:"createdAt"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// ChecklistResponse represents an item's checklist, in order.
+type ChecklistResponse struct {
+	Data []ChecklistEntryResponse `json:"data"`
+}
+
+// List handles GET /api/items/{itemId}/checklist
+func (h *ChecklistHandler) List(w http.ResponseWriter, r *http.Request) {
+	itemID := chi.URLParam(r, "itemId")
+
+	entries, err := h.checklistService.List(r.Context(), itemID)
+	if err != nil {
+		writeChecklistError(w, r, err, "list")
+		return
+	}
+
+	response := ChecklistResponse{Data: make([]ChecklistEntryResponse, len(entries))}
+	for i, entry := range entries {
+		response.Data[i] = toChecklistEntryResponse(&entry)
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Create handles POST /api/items/{itemId}/checklist
+func (h *ChecklistHandler) Create(w http.ResponseWriter, r *http.Request) {
+	itemID := chi.URLParam(r, "itemId")
+
+	var req CreateChecklistEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.BadRequest(w, r, "Invalid request body", nil)
+		return
+	}
+	text := strings.TrimSpace(req.Text)
+	if text == "" {
+		apierror.ValidationError(w, r, "Text is required", nil)
+		return
+	}
+	if req.Position != nil && *req.Position < 0 {
+		apierror.ValidationError(w, r, positionMessage, nil)
+		return
+	}
+
+	entry, err := h.checklistService.Create(r.Context(), itemID, service.CreateChecklistEntryInput{
+		Text:     text,
+		Done:     req.Done,
+		Position: req.Position,
+	})
+	if err != nil {
+		writeChecklistError(w, r, err, "create")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toChecklistEntryResponse(entry))
+}
+
+// Update handles PUT /api/items/{itemId}/checklist/{id}. It ticks an entry
+// off or clears it with done, and moves it with position.
+func (h *ChecklistHandler) Update(w http.ResponseWriter, r *http.Request) {
+	itemID := chi.URLParam(r, "itemId")
+	id := chi.URLParam(r, "id")
+
+	var req UpdateChecklistEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.BadRequest(w, r, "Invalid request body", nil)
+		return
+	}
+	if req.Text != nil {
+		text := strings.TrimSpace(*req.Text)
+		if text == "" {
+			apierror.ValidationError(w, r, "Text cannot be empty", nil)
+			return
+		}
+		req.Text = &text
+	}
+	if req.Position != nil && *req.Position < 0 {
+		apierror.ValidationError(w, r, positionMessage, nil)
+		return
+	}
+
+	entry, err := h.checklistService.Update(r.Context(), itemID, id, service.UpdateChecklistEntryInput{
+		Text:     req.Text,
+		Done:     req.Done,
+		Position: req.Position,
+	})
+	if err != nil {
+		writeChecklistError(w, r, err, "update")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toChecklistEntryResponse(entry))
+}
+
+// Delete handles DELETE /api/items/{itemId}/checklist/{id}
+func (h *ChecklistHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	itemID := chi.URLParam(r, "itemId")
+	id := chi.URLParam(r, "id")
+
+	if err := h.checklistService.Delete(r.Context(), itemID, id); err != nil {
+		writeChecklistError(w, r, err, "delete")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+const positionMessage = "Position cannot be negative"
+
+// writeChecklistError writes the response for an error from a checklist
+// operation; op names the operation in the log and the message.
+func writeChecklistError(w http.ResponseWriter, r *http.Request, err error, op string) {
+	var incomplete *service.ChecklistIncompleteError
+	switch {
+	case errors.Is(err, service.ErrItemNotFound):
+		apierror.NotFound(w, r, "Item not found")
+	case errors.Is(err, service.ErrChecklistEntryNotFound):
+		apierror.NotFound(w, r, "Checklist entry not found")
+	case errors.As(err, &incomplete):
+		apierror.Write(w, r, http.StatusConflict, apierror.CodeConflict, "Item checklist is not done",
+			map[string]any{"remaining": incomplete.Remaining})
+	default:
+		slog.Error("failed to "+op+" checklist entry", "error", err, "itemId", chi.URLParam(r, "itemId"))
+		apierror.InternalError(w, r, "Failed to "+op+" checklist entry")
+	}
+}
+
+// toChecklistEntryResponse converts a service checklist entry to an API
+// response.
+func toChecklistEntryResponse(entry *service.ChecklistEntry) ChecklistEntryResponse {
+	return ChecklistEntryResponse{
+		ID:        entry.ID,
+		ItemID:    entry.ItemID,
+		Text:      entry.Text,
+		Done:      entry.Done,
+		Position:  entry.Position,
+		CreatedAt: entry.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt: entry.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
@@ -0,0 +1,142 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/keel/api/internal/apierror"
+	"github.com/keel/api/internal/apitest"
+	"github.com/keel/api/internal/app"
+	"github.com/keel/api/internal/config"
+	"github.com/keel/api/internal/handler"
+)
+
+// checklistTexts returns the item's checklist as "text" or "text (done)",
+// checking that positions run from 0.
+func checklistTexts(t *testing.T, s *apitest.Server, itemID string) []string {
+	t.Helper()
+	var list handler.ChecklistResponse
+	s.Get("/api/items/" + itemID + "/checklist").Expect(http.StatusOK).JSON(&list)
+	texts := make([]string, len(list.Data))
+	for i, entry := range list.Data {
+		if entry.Position != i {
+			t.Errorf("%q at index %d has position %d", entry.Text, i, entry.Position)
+		}
+		texts[i] = entry.Text
+		if entry.Done {
+			texts[i] += " (done)"
+		}
+	}
+	return texts
+}
+
+func TestChecklist(t *testing.T) {
+	s := apitest.New(t)
+	item := s.Item().Create()
+	base := "/api/items/" + item.ID + "/checklist"
+
+	ids := make(map[string]string)
+	for _, text := range []string{"pack", "book train", "water plants"} {
+		var entry handler.ChecklistEntryResponse
+		s.Post(base, map[string]any{"text": " " + text + " "}).Expect(http.StatusCreated).JSON(&entry)
+		if entry.ItemID != item.ID || entry.Text != text || entry.Done {
+			t.Errorf("created = %+v", entry)
+		}
+		ids[text] = entry.ID
+	}
+	var inserted handler.ChecklistEntryResponse
+	s.Post(base, map[string]any{"text": "check forecast", "done": true, "position": 1}).Expect(http.StatusCreated).JSON(&inserted)
+	if inserted.Position != 1 || !inserted.Done {
+		t.Errorf("inserted = %+v", inserted)
+	}
+	if got, want := checklistTexts(t, s, item.ID), []string{"pack", "check forecast (done)", "book train", "water plants"}; !slices.Equal(got, want) {
+		t.Errorf("checklist = %q, want %q", got, want)
+	}
+
+	// Toggle, then move to the front and past the end.
+	s.Put(base+"/"+ids["book train"], map[string]any{"done": true}).Expect(http.StatusOK)
+	s.Put(base+"/"+ids["water plants"], map[string]any{"position": 0}).Expect(http.StatusOK)
+	var moved handler.ChecklistEntryResponse
+	s.Put(base+"/"+ids["pack"], map[string]any{"position": 10, "text": "pack bags"}).Expect(http.StatusOK).JSON(&moved)
+	if moved.Position != 3 || moved.Text != "pack bags" {
+		t.Errorf("moved = %+v", moved)
+	}
+	if got, want := checklistTexts(t, s, item.ID), []string{"water plants", "check forecast (done)", "book train (done)", "pack bags"}; !slices.Equal(got, want) {
+		t.Errorf("checklist = %q, want %q", got, want)
+	}
+
+	var got handler.ItemResponse
+	s.Get("/api/items/" + item.ID).Expect(http.StatusOK).JSON(&got)
+	if got.Checklist != (handler.ChecklistProgressResponse{Total: 4, Done: 2}) {
+		t.Errorf("item checklist = %+v, want 4 entries, 2 done", got.Checklist)
+	}
+
+	// Removing an entry closes the gap.
+	s.Delete(base + "/" + inserted.ID).Expect(http.StatusNoContent)
+	s.Delete(base + "/" + inserted.ID).Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+	if got, want := checklistTexts(t, s, item.ID), []string{"water plants", "book train (done)", "pack bags"}; !slices.Equal(got, want) {
+		t.Errorf("checklist = %q, want %q", got, want)
+	}
+
+	s.Post(base, map[string]any{"text": " "}).Expect(http.StatusBadRequest).Error(apierror.CodeValidationError)
+	s.Post(base, map[string]any{"text": "x", "position": -1}).Expect(http.StatusBadRequest).Error(apierror.CodeValidationError)
+	s.Put(base+"/"+ids["pack"], map[string]any{"text": ""}).Expect(http.StatusBadRequest).Error(apierror.CodeValidationError)
+	s.Post("/api/items/missing/checklist", map[string]any{"text": "x"}).Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+	// An entry is only found under its own item.
+	s.Put("/api/items/"+s.Item().Create().ID+"/checklist/"+ids["pack"], map[string]any{"done": true}).
+		Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+}
+
+func TestChecklistRequiredForCompletion(t *testing.T) {
+	cfg := config.Default()
+	cfg.Items.RequireChecklistDone = true
+	s := apitest.NewWithConfig(t, cfg, app.Options{})
+	item := s.Item().Create()
+	base := "/api/items/" + item.ID + "/checklist"
+
+	var entry handler.ChecklistEntryResponse
+	s.Post(base, map[string]any{"text": "write tests"}).Expect(http.StatusCreated).JSON(&entry)
+	s.Post(base, map[string]any{"text": "write code", "done": true}).Expect(http.StatusCreated)
+
+	e := s.Put("/api/items/"+item.ID, map[string]string{"status": "completed"}).
+		Expect(http.StatusConflict).Error(apierror.CodeConflict)
+	if details, _ := e.Details.(map[string]any); fmt.Sprint(details["remaining"]) != "1" {
+		t.Errorf("details = %v, want 1 remaining", e.Details)
+	}
+	// Other changes are not held up.
+	s.Put("/api/items/"+item.ID, map[string]string{"status": "in_progress"}).Expect(http.StatusOK)
+
+	s.Put(base+"/"+entry.ID, map[string]any{"done": true}).Expect(http.StatusOK)
+	s.Put("/api/items/"+item.ID, map[string]string{"status": "completed"}).Expect(http.StatusOK)
+
+	// A completed item's checklist stays done.
+	s.Post(base, map[string]any{"text": "write docs"}).Expect(http.StatusConflict).Error(apierror.CodeConflict)
+	s.Put(base+"/"+entry.ID, map[string]any{"done": false}).Expect(http.StatusConflict).Error(apierror.CodeConflict)
+	s.Post(base, map[string]any{"text": "write docs", "done": true}).Expect(http.StatusCreated)
+	s.Put(base+"/"+entry.ID, map[string]any{"text": "write more tests", "position": 0}).Expect(http.StatusOK)
+	if got, want := checklistTexts(t, s, item.ID), []string{"write more tests (done)", "write code (done)", "write docs (done)"}; !slices.Equal(got, want) {
+		t.Errorf("checklist = %q, want %q", got, want)
+	}
+
+	// Items created from a template follow the rule too.
+	var tmpl handler.ItemTemplateResponse
+	s.Post("/api/item-templates", map[string]any{"name": "release", "title": "Release", "status": "completed", "checklist": []string{"tag", "announce"}}).
+		Expect(http.StatusCreated).JSON(&tmpl)
+	userID := s.User().Create().ID
+	e = s.Post("/api/item-templates/"+tmpl.ID+"/items", map[string]any{"userId": userID}).
+		Expect(http.StatusConflict).Error(apierror.CodeConflict)
+	if details, _ := e.Details.(map[string]any); fmt.Sprint(details["remaining"]) != "2" {
+		t.Errorf("details = %v, want 2 remaining", e.Details)
+	}
+	s.Post("/api/item-templates/"+tmpl.ID+"/items", map[string]any{"userId": userID, "status": "pending"}).
+		Expect(http.StatusCreated)
+
+	// Without the rule an open checklist does not matter.
+	s = apitest.New(t)
+	item = s.Item().Create()
+	s.Post("/api/items/"+item.ID+"/checklist", map[string]any{"text": "open"}).Expect(http.StatusCreated)
+	s.Put("/api/items/"+item.ID, map[string]string{"status": "completed"}).Expect(http.StatusOK)
+	s.Post("/api/items/"+item.ID+"/checklist", map[string]any{"text": "late"}).Expect(http.StatusCreated)
+}
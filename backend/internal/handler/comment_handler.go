@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/keel/api/internal/apierror"
+	"github.com/keel/api/internal/middleware"
+	"github.com/keel/api/internal/service"
+)
+
+// CommentHandler handles HTTP requests for comments on items.
+type CommentHandler struct {
+	commentService *service.CommentService
+}
+
+// NewCommentHandler creates a new CommentHandler.
+func NewCommentHandler(commentService *service.CommentService) *CommentHandler {
+	return &CommentHandler{commentService: commentService}
+}
+
+// RegisterRoutes registers comment routes on the given router. Creating,
+// editing and deleting a comment need a user in X-User-ID.
+func (h *CommentHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/items/{itemId}/comments", h.List)
+	r.With(middleware.RequireUser).Post("/items/{itemId}/comments", h.Create)
+	r.Get("/items/{itemId}/comments/{id}", h.Get)
+	r.With(middleware.RequireUser).Put("/items/{itemId}/comments/{id}", h.Update)
+	r.With(middleware.RequireUser).Delete("/items/{itemId}/comments/{id}", h.Delete)
+}
+
+// CommentRequest represents the request body for creating or editing a
+// comment.
+type CommentRequest struct {
+	Body string `json:"body"`
+}
+
+// CommentResponse represents a comment in the API response.
+type CommentResponse struct {
+	ID        string  `json:"id"`
+	ItemID    string  `json:"itemId"`
+	AuthorID  *string `json:"authorId"`
+	Body      string  `json:"body"`
+	CreatedAt string  `json:"createdAt"`
+	UpdatedAt string  `json:"updatedAt"`
+}
+
+// CommentListResponse represents a paginated list of comments.
+type CommentListResponse struct {
+	Data       []CommentResponse  `json:"data"`
+	Pagination PaginationResponse `json:"pagination"`
+}
+
+// List handles GET /api/items/{itemId}/comments
+func (h *CommentHandler) List(w http.ResponseWriter, r *http.Request) {
+	itemID := chi.URLParam(r, "itemId")
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit < 1 {
+		limit = 10
+	}
+
+	result, err := h.commentService.List(r.Context(), itemID, page, limit)
+	if err != nil {
+		if errors.Is(err, service.ErrItemNotFound) {
+			apierror.NotFound(w, r, "Item not found")
+			return
+		}
+		slog.Error("failed to list comments", "error", err, "itemId", itemID)
+		apierror.InternalError(w, r, "Failed to list comments")
+		return
+	}
+
+	response := CommentListResponse{
+		Data: make([]CommentResponse, len(result.Data)),
+		Pagination: PaginationResponse{
+			Page:       result.Page,
+			Limit:      result.Limit,
+			Total:      result.Total,
+			TotalPages: result.TotalPages,
+		},
+	}
+
+	for i, comment := range result.Data {
+		response.Data[i] = toCommentResponse(&comment)
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Create handles POST /api/items/{itemId}/comments. The author is the user
+// in X-User-ID.
+func (h *CommentHandler) Create(w http.ResponseWriter, r *http.Request) {
+	itemID := chi.URLParam(r, "itemId")
+
+	body, ok := decodeCommentBody(w, r)
+	if !ok {
+		return
+	}
+
+	comment, err := h.commentService.Create(r.Context(), itemID, actorID(r), body)
+	if err != nil {
+		if errors.Is(err, service.ErrItemNotFound) {
+			apierror.NotFound(w, r, "Item not found")
+			return
+		}
+		slog.Error("failed to create comment", "error", err, "itemId", itemID)
+		apierror.InternalError(w, r, "Failed to create comment")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toCommentResponse(comment))
+}
+
+// Get handles GET /api/items/{itemId}/comments/{id}
+func (h *CommentHandler) Get(w http.ResponseWriter, r *http.Request) {
+	itemID := chi.URLParam(r, "itemId")
+	id := chi.URLParam(r, "id")
+
+	comment, err := h.commentService.Get(r.Context(), itemID, id)
+	if err != nil {
+		writeCommentError(w, r, err, "get")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toCommentResponse(comment))
+}
+
+// Update handles PUT /api/items/{itemId}/comments/{id}. Only the author or
+// an admin may edit a comment.
+func (h *CommentHandler) Update(w http.ResponseWriter, r *http.Request) {
+	itemID := chi.URLParam(r, "itemId")
+	id := chi.URLParam(r, "id")
+
+	body, ok := decodeCommentBody(w, r)
+	if !ok {
+		return
+	}
+
+	comment, err := h.commentService.Update(r.Context(), itemID, id, actor(r), body)
+	if err != nil {
+		writeCommentError(w, r, err, "update")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toCommentResponse(comment))
+}
+
+// Delete handles DELETE /api/items/{itemId}/comments/{id}. Only the author
+// or an admin may delete a comment.
+func (h *CommentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	itemID := chi.URLParam(r, "itemId")
+	id := chi.URLParam(r, "id")
+
+	if err := h.commentService.Delete(r.Context(), itemID, id, actor(r)); err != nil {
+		writeCommentError(w, r, err, "delete")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeCommentBody reads a CommentRequest and returns its trimmed body. It
+// writes the error response and returns false if the request is invalid.
+func decodeCommentBody(w http.ResponseWriter, r *http.Request) (string, bool) {
+	var req CommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.BadRequest(w, r, "Invalid request body", nil)
+		return "", false
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		apierror.ValidationError(w, r, "Body is required", nil)
+		return "", false
+	}
+	return body, true
+}
+
+// writeCommentError writes the response for an error from a comment
+// operation; op names the operation in the log and the message.
+func writeCommentError(w http.ResponseWriter, r *http.Request, err error, op string) {
+	switch {
+	case errors.Is(err, service.ErrItemNotFound):
+		apierror.NotFound(w, r, "Item not found")
+	case errors.Is(err, service.ErrCommentNotFound):
+		apierror.NotFound(w, r, "Comment not found")
+	case errors.Is(err, service.ErrCommentForbidden):
+		apierror.Forbidden(w, r, "Only the author or an admin can "+op+" this comment")
+	default:
+		slog.Error("failed to "+op+" comment", "error", err, "id", chi.URLParam(r, "id"))
+		apierror.InternalError(w, r, "Failed to "+op+" comment")
+	}
+}
+
+// actor returns the user the request is made on behalf of.
+func actor(r *http.Request) service.Actor {
+	user, _ := middleware.GetUser(r.Context())
+	return service.Actor{ID: user.ID, Role: user.Role}
+}
+
+// toCommentResponse converts a service comment to an API response.
+func toCommentResponse(comment *service.Comment) CommentResponse {
+	return CommentResponse{
+		ID:        comment.ID,
+		ItemID:    comment.ItemID,
+		AuthorID:  optional(comment.AuthorID),
+		Body:      comment.Body,
+		CreatedAt: comment.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt: comment.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
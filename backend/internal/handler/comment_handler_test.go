@@ -0,0 +1,107 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/keel/api/internal/apierror"
+	"github.com/keel/api/internal/apitest"
+	"github.com/keel/api/internal/handler"
+)
+
+func TestComments(t *testing.T) {
+	s := apitest.New(t)
+	author := s.User().Create()
+	other := s.User().Create()
+	admin := s.User().Role("admin").Create()
+	item := s.Item().Create()
+	base := "/api/items/" + item.ID + "/comments"
+
+	var comment handler.CommentResponse
+	s.Post(base, map[string]string{"body": " Looks good "}).
+		Header("X-User-ID", author.ID).Expect(http.StatusCreated).JSON(&comment)
+	if comment.ID == "" || comment.ItemID != item.ID || deref(comment.AuthorID) != author.ID || comment.Body != "Looks good" {
+		t.Errorf("created = %+v", comment)
+	}
+	s.Post(base, map[string]string{"body": "anonymous"}).
+		Expect(http.StatusUnauthorized).Error(apierror.CodeUnauthorized)
+	s.Post(base, map[string]string{"body": " "}).
+		Header("X-User-ID", author.ID).Expect(http.StatusBadRequest).Error(apierror.CodeValidationError)
+	s.Post("/api/items/missing/comments", map[string]string{"body": "hi"}).
+		Header("X-User-ID", author.ID).Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+
+	var got handler.CommentResponse
+	s.Get(base + "/" + comment.ID).Expect(http.StatusOK).JSON(&got)
+	if got.ID != comment.ID || got.Body != comment.Body {
+		t.Errorf("get = %+v, want %+v", got, comment)
+	}
+	// A comment is only found under its own item.
+	s.Get("/api/items/" + s.Item().Create().ID + "/comments/" + comment.ID).
+		Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+
+	// Only the author or an admin can edit or delete.
+	s.Put(base+"/"+comment.ID, map[string]string{"body": "hijacked"}).
+		Header("X-User-ID", other.ID).Expect(http.StatusForbidden).Error(apierror.CodeForbidden)
+	s.Delete(base+"/"+comment.ID).
+		Header("X-User-ID", other.ID).Expect(http.StatusForbidden).Error(apierror.CodeForbidden)
+	s.Delete(base + "/" + comment.ID).Expect(http.StatusUnauthorized).Error(apierror.CodeUnauthorized)
+	s.Put(base+"/"+comment.ID, map[string]string{"body": "Looks great"}).
+		Header("X-User-ID", author.ID).Expect(http.StatusOK).JSON(&got)
+	if got.Body != "Looks great" || deref(got.AuthorID) != author.ID {
+		t.Errorf("edited by author = %+v", got)
+	}
+	s.Put(base+"/"+comment.ID, map[string]string{"body": "Moderated"}).
+		Header("X-User-ID", admin.ID).Expect(http.StatusOK).JSON(&got)
+	if got.Body != "Moderated" || deref(got.AuthorID) != author.ID {
+		t.Errorf("edited by admin = %+v", got)
+	}
+
+	s.Delete(base+"/"+comment.ID).Header("X-User-ID", admin.ID).Expect(http.StatusNoContent)
+	s.Get(base + "/" + comment.ID).Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+	s.Delete(base+"/"+comment.ID).
+		Header("X-User-ID", author.ID).Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+}
+
+func TestListComments(t *testing.T) {
+	s := apitest.New(t)
+	author := s.User().Create()
+	item := s.Item().Create()
+	base := "/api/items/" + item.ID + "/comments"
+
+	for i := range 3 {
+		s.Post(base, map[string]string{"body": fmt.Sprintf("comment %d", i)}).
+			Header("X-User-ID", author.ID).Expect(http.StatusCreated)
+	}
+
+	var list handler.CommentListResponse
+	s.Get(base + "?limit=2&page=2").Expect(http.StatusOK).JSON(&list)
+	if len(list.Data) != 1 || list.Data[0].Body != "comment 2" || list.Pagination.Total != 3 || list.Pagination.TotalPages != 2 {
+		t.Errorf("page 2 = %+v", list)
+	}
+	s.Get("/api/items/missing/comments").Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+
+	// Items report their comment count, singly and in lists.
+	var got handler.ItemResponse
+	s.Get("/api/items/" + item.ID).Expect(http.StatusOK).JSON(&got)
+	if got.CommentCount != 3 {
+		t.Errorf("commentCount = %d, want 3", got.CommentCount)
+	}
+	s.Item().Create()
+	var items handler.ItemListResponse
+	s.Get("/api/items").Expect(http.StatusOK).JSON(&items)
+	counts := map[string]int64{}
+	for _, it := range items.Data {
+		counts[it.ID] = it.CommentCount
+	}
+	if len(counts) != 2 || counts[item.ID] != 3 {
+		t.Errorf("list commentCounts = %v", counts)
+	}
+
+	// Deleting the author keeps the comments without them.
+	s.Delete("/api/users/" + author.ID).Expect(http.StatusNoContent)
+	s.Get(base).Expect(http.StatusOK).JSON(&list)
+	if len(list.Data) != 3 || list.Data[0].AuthorID != nil {
+		t.Errorf("comments after deleting the author = %+v", list.Data)
+	}
+}
@@ -0,0 +1,457 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/keel/api/internal/apitest"
+	"github.com/keel/api/internal/apitest/openapi"
+	"github.com/keel/api/internal/app"
+	"github.com/keel/api/internal/config"
+)
+
+// TestContract checks every operation in api/openapi.yaml against the
+// server: a valid request must get a declared success response, invalid
+// ones a declared 400, 401 or 404, and every response must match its
+// declared schema and content type. Request values are generated from the
+// schemas; uuid properties and parameters named <thing>Id refer to a
+// <thing> created through the spec's POST /api/<things> first.
+func TestContract(t *testing.T) {
+	spec, err := openapi.Load("../../api/openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Default()
+	cfg.Admin.Token = "contract-token"
+	cfg.Backup.Dir = t.TempDir()
+	c := &contract{
+		spec: spec,
+		// Backups need the database in a file.
+		s:     apitest.NewOnDisk(t, cfg, app.Options{}),
+		token: cfg.Admin.Token,
+	}
+
+	for _, op := range spec.Operations() {
+		t.Run(op.OperationID, func(t *testing.T) {
+			c.checkValid(t, op)
+			c.checkUnauthorized(t, op)
+			c.checkNotFound(t, op)
+			c.checkInvalidBodies(t, op)
+		})
+	}
+
+	t.Run("routes", func(t *testing.T) {
+		err := chi.Walk(c.s.Handler.Routes(), func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+			if c.operation(method, route) == nil {
+				t.Errorf("%s %s is served but not in openapi.yaml", method, route)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+type contract struct {
+	spec  *openapi.Spec
+	s     *apitest.Server
+	token string
+	// user is the X-User-ID sent to operations secured by userId.
+	user string
+	seq  int
+	// creating holds the collections a resource is being created in.
+	creating map[string]bool
+}
+
+func (c *contract) operation(method, path string) *openapi.Operation {
+	for _, op := range c.spec.Operations() {
+		if op.Method == method && op.Path == path {
+			return op
+		}
+	}
+	return nil
+}
+
+// checkValid sends a valid request and expects the operation's success
+// status.
+func (c *contract) checkValid(t *testing.T, op *openapi.Operation) {
+	var success int
+	for code := range op.Responses {
+		if n, _ := strconv.Atoi(code); n >= 200 && n < 300 && (success == 0 || n < success) {
+			success = n
+		}
+	}
+	if success == 0 {
+		t.Fatalf("%s %s declares no success response", op.Method, op.Path)
+	}
+
+	bodies := map[string]any{"valid request": nil}
+	if s := op.BodySchema(); s != nil {
+		minimal := c.value(t, "", s).(map[string]any)
+		for name := range minimal {
+			if !slices.Contains(s.Required, name) {
+				delete(minimal, name)
+			}
+		}
+		bodies = map[string]any{"valid request": c.value(t, "", s), "only required properties": minimal}
+	}
+	if s := op.FormSchema(); s != nil {
+		bodies = map[string]any{"valid request": c.form(t, s)}
+	}
+	for name, body := range bodies {
+		res := c.send(t, op, c.path(t, op.Path)+c.query(t, op), body, true)
+		if res.Code != success {
+			t.Errorf("%s: status %d, want %d; body %s", name, res.Code, success, res.Body)
+		}
+	}
+}
+
+// checkUnauthorized sends a request without credentials to a secured
+// operation.
+func (c *contract) checkUnauthorized(t *testing.T, op *openapi.Operation) {
+	if len(op.Security) == 0 {
+		return
+	}
+	if res := c.send(t, op, c.path(t, op.Path), nil, false); res.Code != http.StatusUnauthorized {
+		t.Errorf("without credentials: status %d, want 401", res.Code)
+	}
+}
+
+// checkNotFound replaces each path parameter in turn with an unknown ID.
+func (c *contract) checkNotFound(t *testing.T, op *openapi.Operation) {
+	if op.Responses["404"] == nil {
+		return
+	}
+	for _, p := range op.Parameters {
+		if p.In != "path" {
+			continue
+		}
+		path := c.path(t, op.Path)
+		known := strings.Split(path, "/")
+		pattern := strings.Split(op.Path, "/")
+		for i := range pattern {
+			if pattern[i] == "{"+p.Name+"}" {
+				known[i] = uuid.NewString()
+			}
+		}
+
+		var body any
+		if s := op.BodySchema(); s != nil {
+			body = c.value(t, "", s)
+		}
+		if s := op.FormSchema(); s != nil {
+			body = c.form(t, s)
+		}
+		if res := c.send(t, op, strings.Join(known, "/"), body, true); res.Code != http.StatusNotFound {
+			t.Errorf("unknown %s: status %d, want 404; body %s", p.Name, res.Code, res.Body)
+		}
+	}
+}
+
+// checkInvalidBodies sends request bodies that break the schema in one way
+// each and expects 400.
+func (c *contract) checkInvalidBodies(t *testing.T, op *openapi.Operation) {
+	schema := op.BodySchema()
+	if schema == nil {
+		return
+	}
+	if op.Responses["400"] == nil {
+		t.Errorf("%s %s takes a body but declares no 400 response", op.Method, op.Path)
+		return
+	}
+
+	valid := func() map[string]any {
+		return c.value(t, "", schema).(map[string]any)
+	}
+	cases := map[string]any{
+		"malformed JSON": "{",
+		"not an object":  `"text"`,
+	}
+	for _, name := range schema.Required {
+		body := valid()
+		delete(body, name)
+		cases["missing "+name] = body
+	}
+	for name, p := range schema.Properties {
+		body := valid()
+		body[name] = wrongType(p)
+		cases["wrong type for "+name] = body
+
+		if len(p.Enum) > 0 {
+			body := valid()
+			body[name] = "not-a-valid-value"
+			cases["invalid "+name] = body
+		}
+		if p.MinLength != nil && *p.MinLength > 0 {
+			body := valid()
+			body[name] = ""
+			cases["empty "+name] = body
+		}
+	}
+
+	for name, body := range cases {
+		if res := c.send(t, op, c.path(t, op.Path), body, true); res.Code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400; body %s", name, res.Code, res.Body)
+		}
+	}
+}
+
+// send makes the request and checks the response against the spec.
+func (c *contract) send(t *testing.T, op *openapi.Operation, path string, body any, auth bool) *apitest.Response {
+	t.Helper()
+	var req *apitest.Request
+	if f, ok := body.(form); ok {
+		req = c.s.WithT(t).Upload(path, f.field, f.filename, f.contentType, f.data)
+	} else {
+		req = c.s.WithT(t).Request(op.Method, path, body)
+	}
+	if auth {
+		c.authorize(t, op, req)
+	}
+	res := req.Do()
+
+	declared := op.Responses[strconv.Itoa(res.Code)]
+	if declared == nil {
+		t.Errorf("%s %s: undeclared status %d; body %s", op.Method, path, res.Code, res.Body)
+		return res
+	}
+
+	media := declared.Content["application/json"]
+	if media == nil {
+		if len(declared.Content) == 0 && res.Body.Len() > 0 {
+			t.Errorf("%s %s: status %d declares no body, got %s", op.Method, path, res.Code, res.Body)
+		}
+		return res
+	}
+	if ct := res.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		t.Errorf("%s %s: Content-Type %q, want application/json", op.Method, path, ct)
+	}
+	if media.Schema != nil {
+		for _, problem := range media.Schema.Validate(res.Body.Bytes()) {
+			t.Errorf("%s %s: status %d body: %s", op.Method, path, res.Code, problem)
+		}
+	}
+	return res
+}
+
+// authorize adds the credentials of each security scheme op uses.
+func (c *contract) authorize(t *testing.T, op *openapi.Operation, req *apitest.Request) {
+	t.Helper()
+	for _, requirement := range op.Security {
+		for scheme := range requirement {
+			switch scheme {
+			case "adminToken":
+				req.Header("Authorization", "Bearer "+c.token)
+			case "userId":
+				if c.user == "" {
+					c.user = c.create(t, "/api/users")
+				}
+				req.Header("X-User-ID", c.user)
+			default:
+				t.Fatalf("unknown security scheme %s", scheme)
+			}
+		}
+	}
+}
+
+var paramRe = regexp.MustCompile(`\{(\w+)\}`)
+
+// path fills in the path parameters of pattern. {id} is a new resource of
+// the collection it is under; other parameters are references.
+func (c *contract) path(t *testing.T, pattern string) string {
+	t.Helper()
+	var b strings.Builder
+	rest := pattern
+	for {
+		loc := paramRe.FindStringSubmatchIndex(rest)
+		if loc == nil {
+			b.WriteString(rest)
+			return b.String()
+		}
+		b.WriteString(rest[:loc[0]])
+		name := rest[loc[2]:loc[3]]
+		if name == "id" {
+			b.WriteString(c.create(t, strings.TrimSuffix(b.String(), "/")))
+		} else {
+			b.WriteString(c.reference(t, name))
+		}
+		rest = rest[loc[1]:]
+	}
+}
+
+// query returns a query string with a valid value for each query parameter.
+func (c *contract) query(t *testing.T, op *openapi.Operation) string {
+	q := url.Values{}
+	for _, p := range op.Parameters {
+		if p.In != "query" || p.Schema == nil {
+			continue
+		}
+		// Arrays are sent as repeated parameters
+		v := c.value(t, p.Name, p.Schema)
+		if vs, ok := v.([]any); ok {
+			for _, v := range vs {
+				q.Add(p.Name, fmt.Sprint(v))
+			}
+		} else {
+			q.Set(p.Name, fmt.Sprint(v))
+		}
+	}
+	if len(q) == 0 {
+		return ""
+	}
+	return "?" + q.Encode()
+}
+
+// create creates a resource in the collection at path, a concrete path
+// such as /api/users, and returns its ID.
+func (c *contract) create(t *testing.T, path string) string {
+	t.Helper()
+	var op *openapi.Operation
+	for _, o := range c.spec.Operations() {
+		re := "^" + paramRe.ReplaceAllString(o.Path, "[^/]+") + "$"
+		if o.Method == http.MethodPost && regexp.MustCompile(re).MatchString(path) {
+			op = o
+		}
+	}
+	if op == nil || (op.BodySchema() == nil && op.FormSchema() == nil) {
+		t.Fatalf("no POST operation to create a resource in %s", path)
+	}
+
+	if c.creating == nil {
+		c.creating = map[string]bool{}
+	}
+	c.creating[path] = true
+	defer delete(c.creating, path)
+
+	var req *apitest.Request
+	if s := op.FormSchema(); s != nil {
+		f := c.form(t, s)
+		req = c.s.WithT(t).Upload(path, f.field, f.filename, f.contentType, f.data)
+	} else {
+		req = c.s.WithT(t).Request(http.MethodPost, path, c.value(t, "", op.BodySchema()))
+	}
+	c.authorize(t, op, req)
+	var created struct{ ID string }
+	req.Expect(http.StatusCreated).JSON(&created)
+	return created.ID
+}
+
+// roles maps the <thing> of references named after the role a resource
+// plays to the resource.
+var roles = map[string]string{"assignee": "user", "parent": "item", "blocker": "item", "target": "item"}
+
+// reference creates the resource a parameter or property named <thing>Id
+// refers to and returns its ID.
+func (c *contract) reference(t *testing.T, name string) string {
+	t.Helper()
+	return c.create(t, c.collection(t, name))
+}
+
+// collection returns the path of the collection a parameter or property
+// named <thing>Id refers to.
+func (c *contract) collection(t *testing.T, name string) string {
+	t.Helper()
+	thing, ok := strings.CutSuffix(name, "Id")
+	if !ok {
+		t.Fatalf("cannot tell what %s refers to; name it <thing>Id", name)
+	}
+	if resource, ok := roles[thing]; ok {
+		thing = resource
+	}
+	for _, plural := range []string{thing + "s", thing + "es", strings.TrimSuffix(thing, "y") + "ies"} {
+		if c.operation(http.MethodPost, "/api/"+plural) != nil {
+			return "/api/" + plural
+		}
+	}
+	t.Fatalf("cannot find the collection %s refers to; expected POST /api/%ss", name, thing)
+	return ""
+}
+
+// value returns a valid value for the schema. Strings are unique, so
+// unique fields do not conflict.
+func (c *contract) value(t *testing.T, name string, s *openapi.Schema) any {
+	t.Helper()
+	c.seq++
+	switch {
+	case len(s.Enum) > 0:
+		return s.Enum[0]
+	case s.Type.Has("object"):
+		obj := make(map[string]any)
+		for prop, p := range s.Properties {
+			// An optional reference to a resource of the kind being created,
+			// such as an item's parent, would recurse forever
+			if p.Format == "uuid" && !slices.Contains(s.Required, prop) && c.creating[c.collection(t, prop)] {
+				continue
+			}
+			obj[prop] = c.value(t, prop, p)
+		}
+		return obj
+	case s.Type.Has("array"):
+		return []any{c.value(t, name, s.Items)}
+	case s.Type.Has("integer"):
+		if s.Default != nil {
+			return s.Default
+		}
+		if s.Minimum != nil {
+			return int(*s.Minimum)
+		}
+		return 1
+	case s.Type.Has("number"):
+		return 1.5
+	case s.Type.Has("boolean"):
+		return true
+	case s.Type.Has("string"):
+		switch s.Format {
+		case "uuid":
+			return c.reference(t, name)
+		case "email":
+			return fmt.Sprintf("contract-%d@example.com", c.seq)
+		case "date-time":
+			return time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC).Format(time.RFC3339)
+		}
+		return fmt.Sprintf("%s %d", name, c.seq)
+	}
+	t.Fatalf("cannot generate a value for %s of type %v", name, s.Type)
+	return nil
+}
+
+// form is a multipart/form-data body holding one file.
+type form struct {
+	field       string
+	filename    string
+	contentType string
+	data        []byte
+}
+
+// form returns a form whose file is the schema's binary property, filled
+// with text.
+func (c *contract) form(t *testing.T, s *openapi.Schema) form {
+	t.Helper()
+	for name, p := range s.Properties {
+		if p.Format == "binary" {
+			c.seq++
+			return form{field: name, filename: fmt.Sprintf("file-%d.txt", c.seq), contentType: "text/plain", data: []byte("contract test\n")}
+		}
+	}
+	t.Fatal("form schema has no binary property")
+	return form{}
+}
+
+// wrongType returns a value of a JSON type the schema does not allow.
+func wrongType(s *openapi.Schema) any {
+	if slices.ContainsFunc(s.Type, func(t string) bool { return t == "string" }) {
+		return 12345
+	}
+	return "text"
+}
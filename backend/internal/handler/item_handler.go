@@ -1,14 +1,20 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/keel/api/internal/apierror"
+	"github.com/keel/api/internal/middleware"
 	"github.com/keel/api/internal/service"
 )
 
@@ -29,14 +35,45 @@ func (h *ItemHandler) RegisterRoutes(r chi.Router) {
 	r.Get("/items/{id}", h.Get)
 	r.Put("/items/{id}", h.Update)
 	r.Delete("/items/{id}", h.Delete)
+	r.Post("/items/{id}/move", h.Move)
+	r.Get("/items/{id}/history", h.History)
+	r.Put("/items/{id}/tags/{tagId}", h.AddTag)
+	r.Delete("/items/{id}/tags/{tagId}", h.RemoveTag)
+	r.Get("/items/{id}/children", h.Children)
+	r.Get("/items/{id}/dependencies", h.Dependencies)
+	r.Put("/items/{id}/dependencies/{blockerId}", h.AddDependency)
+	r.Delete("/items/{id}/dependencies/{blockerId}", h.RemoveDependency)
+	r.Put("/items/{id}/recurrence", h.SetRecurrence)
+	r.Delete("/items/{id}/recurrence", h.RemoveRecurrence)
+	r.Post("/item-templates/{id}/items", h.CreateFromTemplate)
 }
 
 // CreateItemRequest represents the request body for creating an item.
 type CreateItemRequest struct {
-	UserID      string `json:"userId"`
-	Title       string `json:"title"`
-	Description string `json:"description,omitempty"`
-	Status      string `json:"status,omitempty"`
+	UserID      string   `json:"userId"`
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Status      string   `json:"status,omitempty"`
+	DueAt       *string  `json:"dueAt,omitempty"`
+	Priority    string   `json:"priority,omitempty"`
+	AssigneeID  string   `json:"assigneeId,omitempty"`
+	ParentID    string   `json:"parentId,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+// CreateItemFromTemplateRequest represents the request body for creating
+// an item from a template. Omitted title, description, status and tags
+// take the template's values.
+type CreateItemFromTemplateRequest struct {
+	UserID      string    `json:"userId"`
+	Title       *string   `json:"title,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	Status      *string   `json:"status,omitempty"`
+	DueAt       *string   `json:"dueAt,omitempty"`
+	Priority    string    `json:"priority,omitempty"`
+	AssigneeID  string    `json:"assigneeId,omitempty"`
+	ParentID    string    `json:"parentId,omitempty"`
+	Tags        *[]string `json:"tags,omitempty"`
 }
 
 // UpdateItemRequest represents the request body for updating an item.
@@ -44,17 +81,62 @@ type UpdateItemRequest struct {
 	Title       *string `json:"title,omitempty"`
 	Description *string `json:"description,omitempty"`
 	Status      *string `json:"status,omitempty"`
+	// DueAt, AssigneeID and ParentID are cleared by "".
+	DueAt      *string   `json:"dueAt,omitempty"`
+	Priority   *string   `json:"priority,omitempty"`
+	AssigneeID *string   `json:"assigneeId,omitempty"`
+	ParentID   *string   `json:"parentId,omitempty"`
+	Tags       *[]string `json:"tags,omitempty"`
+}
+
+// MoveItemRequest represents the request body for moving an item just
+// before or just after another.
+type MoveItemRequest struct {
+	TargetID string `json:"targetId"`
+	Position string `json:"position"`
+}
+
+// ItemRecurrenceRequest represents the request body for making an item
+// recur.
+type ItemRecurrenceRequest struct {
+	Frequency string  `json:"frequency"`
+	Cron      string  `json:"cron,omitempty"`
+	StartsAt  *string `json:"startsAt,omitempty"`
 }
 
 // ItemResponse represents an item in the API response.
 type ItemResponse struct {
-	ID          string `json:"id"`
-	UserID      string `json:"userId"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Status      string `json:"status"`
-	CreatedAt   string `json:"createdAt"`
-	UpdatedAt   string `json:"updatedAt"`
+	ID           string                    `json:"id"`
+	UserID       string                    `json:"userId"`
+	Title        string                    `json:"title"`
+	Description  string                    `json:"description"`
+	Status       string                    `json:"status"`
+	DueAt        *string                   `json:"dueAt"`
+	Priority     string                    `json:"priority"`
+	AssigneeID   *string                   `json:"assigneeId"`
+	ParentID     *string                   `json:"parentId"`
+	Progress     ItemProgressResponse      `json:"progress"`
+	Checklist    ChecklistProgressResponse `json:"checklist"`
+	Rank         string                    `json:"rank"`
+	Recurrence   *ItemRecurrenceResponse   `json:"recurrence"`
+	Tags         []string                  `json:"tags"`
+	CommentCount int64                     `json:"commentCount"`
+	CreatedAt    string                    `json:"createdAt"`
+	UpdatedAt    string                    `json:"updatedAt"`
+}
+
+// ItemProgressResponse counts an item's sub-items, at any depth, and how
+// many of them are completed.
+type ItemProgressResponse struct {
+	Total     int64 `json:"total"`
+	Completed int64 `json:"completed"`
+}
+
+// ChecklistProgressResponse counts an item's checklist entries and how
+// many of them are done.
+type ChecklistProgressResponse struct {
+	Total int64 `json:"total"`
+	Done  int64 `json:"done"`
 }
 
 // ItemListResponse represents a paginated list of items.
@@ -63,6 +145,44 @@ type ItemListResponse struct {
 	Pagination PaginationResponse `json:"pagination"`
 }
 
+// ItemStatusChangeResponse represents an entry in an item's status history.
+type ItemStatusChangeResponse struct {
+	ID         string  `json:"id"`
+	ItemID     string  `json:"itemId"`
+	FromStatus *string `json:"fromStatus"`
+	ToStatus   string  `json:"toStatus"`
+	ActorID    *string `json:"actorId"`
+	CreatedAt  string  `json:"createdAt"`
+}
+
+// ItemHistoryResponse represents an item's status history, oldest first.
+type ItemHistoryResponse struct {
+	Data []ItemStatusChangeResponse `json:"data"`
+}
+
+// ItemChildrenResponse represents an item's sub-items, oldest first.
+type ItemChildrenResponse struct {
+	Data []ItemResponse `json:"data"`
+}
+
+// ItemRecurrenceResponse represents the rule an item recurs by.
+type ItemRecurrenceResponse struct {
+	ItemID    string  `json:"itemId"`
+	Frequency string  `json:"frequency"`
+	Cron      *string `json:"cron"`
+	StartsAt  string  `json:"startsAt"`
+	NextAt    string  `json:"nextAt"`
+	CreatedAt string  `json:"createdAt"`
+	UpdatedAt string  `json:"updatedAt"`
+}
+
+// ItemDependenciesResponse represents the items an item is blocked by and
+// the items it blocks.
+type ItemDependenciesResponse struct {
+	BlockedBy []ItemResponse `json:"blockedBy"`
+	Blocks    []ItemResponse `json:"blocks"`
+}
+
 // List handles GET /api/items
 func (h *ItemHandler) List(w http.ResponseWriter, r *http.Request) {
 	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
@@ -75,9 +195,50 @@ func (h *ItemHandler) List(w http.ResponseWriter, r *http.Request) {
 		limit = 10
 	}
 
-	userID := r.URL.Query().Get("userId")
+	query := r.URL.Query()
+	filter := service.ItemListFilter{
+		UserID:     query.Get("userId"),
+		Tags:       query["tag"],
+		AssigneeID: query.Get("assigneeId"),
+		Priorities: query["priority"],
+		Order:      query.Get("order"),
+	}
+	switch query.Get("tagMatch") {
+	case "", "any":
+	case "all":
+		filter.AllTags = true
+	default:
+		apierror.ValidationError(w, r, "tagMatch must be one of: any, all", nil)
+		return
+	}
+	for _, priority := range filter.Priorities {
+		if !slices.Contains(service.ItemPriorityValues, priority) {
+			apierror.ValidationError(w, r, priorityMessage, nil)
+			return
+		}
+	}
+	if v := query.Get("dueBefore"); v != "" {
+		dueBefore, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			apierror.ValidationError(w, r, "dueBefore must be an RFC 3339 date-time", nil)
+			return
+		}
+		filter.DueBefore = dueBefore
+	}
+	if v := query.Get("overdue"); v != "" {
+		overdue, err := strconv.ParseBool(v)
+		if err != nil {
+			apierror.ValidationError(w, r, "overdue must be true or false", nil)
+			return
+		}
+		filter.Overdue = overdue
+	}
+	if filter.Order != "" && !slices.Contains(service.ItemOrderValues, filter.Order) {
+		apierror.ValidationError(w, r, "order must be one of: "+strings.Join(service.ItemOrderValues, ", "), nil)
+		return
+	}
 
-	result, err := h.itemService.List(r.Context(), userID, page, limit)
+	result, err := h.itemService.List(r.Context(), filter, page, limit)
 	if err != nil {
 		slog.Error("failed to list items", "error", err)
 		apierror.InternalError(w, r, "Failed to list items")
@@ -117,14 +278,50 @@ func (h *ItemHandler) Create(w http.ResponseWriter, r *http.Request) {
 		apierror.ValidationError(w, r, "Title is required", nil)
 		return
 	}
+	if req.Status != "" && !slices.Contains(service.ItemStatusValues, req.Status) {
+		apierror.ValidationError(w, r, "Status must be one of: pending, in_progress, completed", nil)
+		return
+	}
+	if req.Priority != "" && !slices.Contains(service.ItemPriorityValues, req.Priority) {
+		apierror.ValidationError(w, r, priorityMessage, nil)
+		return
+	}
+	var dueAt *time.Time
+	if req.DueAt != nil {
+		t, err := time.Parse(time.RFC3339, *req.DueAt)
+		if err != nil {
+			apierror.ValidationError(w, r, dueAtMessage, nil)
+			return
+		}
+		dueAt = &t
+	}
+	tags, ok := tagNames(req.Tags)
+	if !ok {
+		apierror.ValidationError(w, r, "Tag names cannot be empty", nil)
+		return
+	}
 
 	item, err := h.itemService.Create(r.Context(), service.CreateItemInput{
 		UserID:      req.UserID,
 		Title:       req.Title,
 		Description: req.Description,
 		Status:      req.Status,
+		DueAt:       dueAt,
+		Priority:    req.Priority,
+		AssigneeID:  req.AssigneeID,
+		ParentID:    req.ParentID,
+		Tags:        tags,
+		ActorID:     actorID(r),
 	})
 	if err != nil {
+		if errors.Is(err, service.ErrAssigneeNotFound) {
+			apierror.ValidationError(w, r, "Assignee not found", nil)
+			return
+		}
+		if errors.Is(err, service.ErrParentNotFound) {
+			apierror.ValidationError(w, r, "Parent item not found", nil)
+			return
+		}
 		slog.Error("failed to create item", "error", err)
 		apierror.InternalError(w, r, "Failed to create item")
 		return
@@ -155,6 +352,93 @@ func (h *ItemHandler) Get(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, toItemResponse(item))
 }
 
+// CreateFromTemplate handles POST /api/item-templates/{id}/items
+func (h *ItemHandler) CreateFromTemplate(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		apierror.BadRequest(w, r, "Item template ID is required", nil)
+		return
+	}
+
+	var req CreateItemFromTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.BadRequest(w, r, "Invalid request body", nil)
+		return
+	}
+	if req.UserID == "" {
+		apierror.ValidationError(w, r, "User ID is required", nil)
+		return
+	}
+	if req.Title != nil && *req.Title == "" {
+		apierror.ValidationError(w, r, "Title cannot be empty", nil)
+		return
+	}
+	if req.Status != nil && !slices.Contains(service.ItemStatusValues, *req.Status) {
+		apierror.ValidationError(w, r, "Status must be one of: pending, in_progress, completed", nil)
+		return
+	}
+	if req.Priority != "" && !slices.Contains(service.ItemPriorityValues, req.Priority) {
+		apierror.ValidationError(w, r, priorityMessage, nil)
+		return
+	}
+	var dueAt *time.Time
+	if req.DueAt != nil {
+		t, err := time.Parse(time.RFC3339, *req.DueAt)
+		if err != nil {
+			apierror.ValidationError(w, r, dueAtMessage, nil)
+			return
+		}
+		dueAt = &t
+	}
+	var tags *[]string
+	if req.Tags != nil {
+		names, ok := tagNames(*req.Tags)
+		if !ok {
+			apierror.ValidationError(w, r, "Tag names cannot be empty", nil)
+			return
+		}
+		tags = &names
+	}
+
+	item, err := h.itemService.CreateFromTemplate(r.Context(), id, service.CreateItemFromTemplateInput{
+		UserID:      req.UserID,
+		Title:       req.Title,
+		Description: req.Description,
+		Status:      req.Status,
+		DueAt:       dueAt,
+		Priority:    req.Priority,
+		AssigneeID:  req.AssigneeID,
+		ParentID:    req.ParentID,
+		Tags:        tags,
+		ActorID:     actorID(r),
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrItemTemplateNotFound) {
+			apierror.NotFound(w, r, "Item template not found")
+			return
+		}
+		if errors.Is(err, service.ErrAssigneeNotFound) {
+			apierror.ValidationError(w, r, "Assignee not found", nil)
+			return
+		}
+		if errors.Is(err, service.ErrParentNotFound) {
+			apierror.ValidationError(w, r, "Parent item not found", nil)
+			return
+		}
+		var incomplete *service.ChecklistIncompleteError
+		if errors.As(err, &incomplete) {
+			apierror.Write(w, r, http.StatusConflict, apierror.CodeConflict, "Item checklist is not done",
+				map[string]any{"remaining": incomplete.Remaining})
+			return
+		}
+		slog.Error("failed to create item from template", "error", err, "id", id)
+		apierror.InternalError(w, r, "Failed to create item")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toItemResponse(item))
+}
+
 // Update handles PUT /api/items/{id}
 func (h *ItemHandler) Update(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
@@ -168,17 +452,88 @@ func (h *ItemHandler) Update(w http.ResponseWriter, r *http.Request) {
 		apierror.BadRequest(w, r, "Invalid request body", nil)
 		return
 	}
+	if req.Title != nil && *req.Title == "" {
+		apierror.ValidationError(w, r, "Title cannot be empty", nil)
+		return
+	}
+	if req.Status != nil && !slices.Contains(service.ItemStatusValues, *req.Status) {
+		apierror.ValidationError(w, r, "Status must be one of: pending, in_progress, completed", nil)
+		return
+	}
+	if req.Priority != nil && !slices.Contains(service.ItemPriorityValues, *req.Priority) {
+		apierror.ValidationError(w, r, priorityMessage, nil)
+		return
+	}
+	var dueAt *time.Time
+	if req.DueAt != nil {
+		// The zero time clears the due date
+		var t time.Time
+		if *req.DueAt != "" {
+			var err error
+			if t, err = time.Parse(time.RFC3339, *req.DueAt); err != nil {
+				apierror.ValidationError(w, r, dueAtMessage, nil)
+				return
+			}
+		}
+		dueAt = &t
+	}
+	var tags *[]string
+	if req.Tags != nil {
+		names, ok := tagNames(*req.Tags)
+		if !ok {
+			apierror.ValidationError(w, r, "Tag names cannot be empty", nil)
+			return
+		}
+		tags = &names
+	}
 
 	item, err := h.itemService.Update(r.Context(), id, service.UpdateItemInput{
 		Title:       req.Title,
 		Description: req.Description,
 		Status:      req.Status,
+		DueAt:       dueAt,
+		Priority:    req.Priority,
+		AssigneeID:  req.AssigneeID,
+		ParentID:    req.ParentID,
+		Tags:        tags,
+		ActorID:     actorID(r),
 	})
 	if err != nil {
 		if errors.Is(err, service.ErrItemNotFound) {
 			apierror.NotFound(w, r, "Item not found")
 			return
 		}
+		if errors.Is(err, service.ErrAssigneeNotFound) {
+			apierror.ValidationError(w, r, "Assignee not found", nil)
+			return
+		}
+		if errors.Is(err, service.ErrParentNotFound) {
+			apierror.ValidationError(w, r, "Parent item not found", nil)
+			return
+		}
+		if errors.Is(err, service.ErrItemCycle) {
+			apierror.Write(w, r, http.StatusConflict, apierror.CodeConflict, "An item cannot be its own ancestor", nil)
+			return
+		}
+		var blocked *service.BlockedError
+		if errors.As(err, &blocked) {
+			apierror.Write(w, r, http.StatusConflict, apierror.CodeConflict, "Item is blocked by open items",
+				map[string]any{"blockers": blocked.Blockers})
+			return
+		}
+		var incomplete *service.ChecklistIncompleteError
+		if errors.As(err, &incomplete) {
+			apierror.Write(w, r, http.StatusConflict, apierror.CodeConflict, "Item checklist is not done",
+				map[string]any{"remaining": incomplete.Remaining})
+			return
+		}
+		var transition *service.StatusTransitionError
+		if errors.As(err, &transition) {
+			apierror.Write(w, r, http.StatusConflict, apierror.CodeConflict,
+				fmt.Sprintf("Cannot change status from %s to %s", transition.From, transition.To),
+				map[string]any{"allowed": transition.Allowed})
+			return
+		}
 		slog.Error("failed to update item", "error", err, "id", id)
 		apierror.InternalError(w, r, "Failed to update item")
 		return
@@ -209,15 +564,365 @@ func (h *ItemHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// Move handles POST /api/items/{id}/move
+func (h *ItemHandler) Move(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		apierror.BadRequest(w, r, "Item ID is required", nil)
+		return
+	}
+
+	var req MoveItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.BadRequest(w, r, "Invalid request body", nil)
+		return
+	}
+	if req.TargetID == "" {
+		apierror.ValidationError(w, r, "Target ID is required", nil)
+		return
+	}
+	var input service.MoveItemInput
+	switch req.Position {
+	case "before":
+		input.Before = req.TargetID
+	case "after":
+		input.After = req.TargetID
+	default:
+		apierror.ValidationError(w, r, "Position must be one of: before, after", nil)
+		return
+	}
+
+	item, err := h.itemService.Move(r.Context(), id, input)
+	if err != nil {
+		if errors.Is(err, service.ErrItemNotFound) {
+			apierror.NotFound(w, r, "Item not found")
+			return
+		}
+		if errors.Is(err, service.ErrMoveTargetNotFound) {
+			apierror.ValidationError(w, r, "Target item not found", nil)
+			return
+		}
+		slog.Error("failed to move item", "error", err, "id", id)
+		apierror.InternalError(w, r, "Failed to move item")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toItemResponse(item))
+}
+
+// History handles GET /api/items/{id}/history
+func (h *ItemHandler) History(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		apierror.BadRequest(w, r, "Item ID is required", nil)
+		return
+	}
+
+	history, err := h.itemService.History(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, service.ErrItemNotFound) {
+			apierror.NotFound(w, r, "Item not found")
+			return
+		}
+		slog.Error("failed to get item history", "error", err, "id", id)
+		apierror.InternalError(w, r, "Failed to get item history")
+		return
+	}
+
+	response := ItemHistoryResponse{Data: make([]ItemStatusChangeResponse, len(history))}
+	for i, change := range history {
+		response.Data[i] = ItemStatusChangeResponse{
+			ID:         change.ID,
+			ItemID:     change.ItemID,
+			FromStatus: optional(change.FromStatus),
+			ToStatus:   change.ToStatus,
+			ActorID:    optional(change.ActorID),
+			CreatedAt:  change.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		}
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// AddTag handles PUT /api/items/{id}/tags/{tagId}
+func (h *ItemHandler) AddTag(w http.ResponseWriter, r *http.Request) {
+	h.changeTag(w, r, "add", h.itemService.AddTag)
+}
+
+// RemoveTag handles DELETE /api/items/{id}/tags/{tagId}
+func (h *ItemHandler) RemoveTag(w http.ResponseWriter, r *http.Request) {
+	h.changeTag(w, r, "remove", h.itemService.RemoveTag)
+}
+
+// changeTag implements AddTag and RemoveTag.
+func (h *ItemHandler) changeTag(w http.ResponseWriter, r *http.Request, verb string, change func(ctx context.Context, itemID, tagID string) error) {
+	id := chi.URLParam(r, "id")
+	tagID := chi.URLParam(r, "tagId")
+	if id == "" || tagID == "" {
+		apierror.BadRequest(w, r, "Item ID and tag ID are required", nil)
+		return
+	}
+
+	if err := change(r.Context(), id, tagID); err != nil {
+		if errors.Is(err, service.ErrItemNotFound) {
+			apierror.NotFound(w, r, "Item not found")
+			return
+		}
+		if errors.Is(err, service.ErrTagNotFound) {
+			apierror.NotFound(w, r, "Tag not found")
+			return
+		}
+		slog.Error("failed to "+verb+" item tag", "error", err, "id", id, "tagId", tagID)
+		apierror.InternalError(w, r, "Failed to "+verb+" tag")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Validation messages shared by Create, Update and List.
+const (
+	priorityMessage = "Priority must be one of: low, normal, high, urgent"
+	dueAtMessage    = "dueAt must be an RFC 3339 date-time"
+)
+
+// Children handles GET /api/items/{id}/children
+func (h *ItemHandler) Children(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		apierror.BadRequest(w, r, "Item ID is required", nil)
+		return
+	}
+
+	children, err := h.itemService.Children(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, service.ErrItemNotFound) {
+			apierror.NotFound(w, r, "Item not found")
+			return
+		}
+		slog.Error("failed to list item children", "error", err, "id", id)
+		apierror.InternalError(w, r, "Failed to list sub-items")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ItemChildrenResponse{Data: toItemResponses(children)})
+}
+
+// Dependencies handles GET /api/items/{id}/dependencies
+func (h *ItemHandler) Dependencies(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		apierror.BadRequest(w, r, "Item ID is required", nil)
+		return
+	}
+
+	deps, err := h.itemService.Dependencies(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, service.ErrItemNotFound) {
+			apierror.NotFound(w, r, "Item not found")
+			return
+		}
+		slog.Error("failed to list item dependencies", "error", err, "id", id)
+		apierror.InternalError(w, r, "Failed to list dependencies")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ItemDependenciesResponse{
+		BlockedBy: toItemResponses(deps.BlockedBy),
+		Blocks:    toItemResponses(deps.Blocks),
+	})
+}
+
+// AddDependency handles PUT /api/items/{id}/dependencies/{blockerId}
+func (h *ItemHandler) AddDependency(w http.ResponseWriter, r *http.Request) {
+	h.changeDependency(w, r, "add", h.itemService.AddDependency)
+}
+
+// RemoveDependency handles DELETE /api/items/{id}/dependencies/{blockerId}
+func (h *ItemHandler) RemoveDependency(w http.ResponseWriter, r *http.Request) {
+	h.changeDependency(w, r, "remove", h.itemService.RemoveDependency)
+}
+
+// changeDependency implements AddDependency and RemoveDependency.
+func (h *ItemHandler) changeDependency(w http.ResponseWriter, r *http.Request, verb string, change func(ctx context.Context, id, blockerID string) error) {
+	id := chi.URLParam(r, "id")
+	blockerID := chi.URLParam(r, "blockerId")
+	if id == "" || blockerID == "" {
+		apierror.BadRequest(w, r, "Item ID and blocker ID are required", nil)
+		return
+	}
+
+	if err := change(r.Context(), id, blockerID); err != nil {
+		if errors.Is(err, service.ErrItemNotFound) {
+			apierror.NotFound(w, r, "Item not found")
+			return
+		}
+		if errors.Is(err, service.ErrBlockerNotFound) {
+			apierror.NotFound(w, r, "Blocking item not found")
+			return
+		}
+		if errors.Is(err, service.ErrItemCycle) {
+			apierror.Write(w, r, http.StatusConflict, apierror.CodeConflict, "An item cannot block itself, directly or through other items", nil)
+			return
+		}
+		slog.Error("failed to "+verb+" item dependency", "error", err, "id", id, "blockerId", blockerID)
+		apierror.InternalError(w, r, "Failed to "+verb+" dependency")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SetRecurrence handles PUT /api/items/{id}/recurrence
+func (h *ItemHandler) SetRecurrence(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		apierror.BadRequest(w, r, "Item ID is required", nil)
+		return
+	}
+
+	var req ItemRecurrenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.BadRequest(w, r, "Invalid request body", nil)
+		return
+	}
+	if !slices.Contains(service.ItemRecurrenceFrequencyValues, req.Frequency) {
+		apierror.ValidationError(w, r, "Frequency must be one of: daily, weekly, monthly, cron", nil)
+		return
+	}
+	if req.Frequency == "cron" && strings.TrimSpace(req.Cron) == "" {
+		apierror.ValidationError(w, r, "Cron is required for frequency cron", nil)
+		return
+	}
+	input := service.SetRecurrenceInput{Frequency: req.Frequency, Cron: strings.TrimSpace(req.Cron)}
+	if req.StartsAt != nil {
+		t, err := time.Parse(time.RFC3339, *req.StartsAt)
+		if err != nil {
+			apierror.ValidationError(w, r, "startsAt must be an RFC 3339 date-time", nil)
+			return
+		}
+		input.StartsAt = &t
+	}
+
+	rec, err := h.itemService.SetRecurrence(r.Context(), id, input)
+	if err != nil {
+		if errors.Is(err, service.ErrItemNotFound) {
+			apierror.NotFound(w, r, "Item not found")
+			return
+		}
+		var recErr *service.RecurrenceError
+		if errors.As(err, &recErr) {
+			apierror.ValidationError(w, r, "Invalid recurrence: "+recErr.Reason.Error(), nil)
+			return
+		}
+		slog.Error("failed to set item recurrence", "error", err, "id", id)
+		apierror.InternalError(w, r, "Failed to set recurrence")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toItemRecurrenceResponse(rec))
+}
+
+// RemoveRecurrence handles DELETE /api/items/{id}/recurrence
+func (h *ItemHandler) RemoveRecurrence(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		apierror.BadRequest(w, r, "Item ID is required", nil)
+		return
+	}
+
+	if err := h.itemService.RemoveRecurrence(r.Context(), id); err != nil {
+		if errors.Is(err, service.ErrItemNotFound) {
+			apierror.NotFound(w, r, "Item not found")
+			return
+		}
+		slog.Error("failed to remove item recurrence", "error", err, "id", id)
+		apierror.InternalError(w, r, "Failed to remove recurrence")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// tagNames trims the tag names in a request; ok is false if any is empty.
+func tagNames(names []string) (trimmed []string, ok bool) {
+	trimmed = make([]string, len(names))
+	for i, name := range names {
+		trimmed[i] = strings.TrimSpace(name)
+		if trimmed[i] == "" {
+			return nil, false
+		}
+	}
+	return trimmed, true
+}
+
+// actorID returns the ID of the user the request is made on behalf of, or
+// "" if there is none.
+func actorID(r *http.Request) string {
+	user, _ := middleware.GetUser(r.Context())
+	return user.ID
+}
+
+// optional returns nil for "", so the value is encoded as null.
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
 // toItemResponse converts a service item to an API response.
 func toItemResponse(item *service.Item) ItemResponse {
+	var dueAt *string
+	if item.DueAt != nil {
+		formatted := item.DueAt.Format("2006-01-02T15:04:05Z07:00")
+		dueAt = &formatted
+	}
+	var recurrence *ItemRecurrenceResponse
+	if item.Recurrence != nil {
+		r := toItemRecurrenceResponse(item.Recurrence)
+		recurrence = &r
+	}
 	return ItemResponse{
-		ID:          item.ID,
-		UserID:      item.UserID,
-		Title:       item.Title,
-		Description: item.Description,
-		Status:      item.Status,
-		CreatedAt:   item.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt:   item.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		ID:           item.ID,
+		UserID:       item.UserID,
+		Title:        item.Title,
+		Description:  item.Description,
+		Status:       item.Status,
+		DueAt:        dueAt,
+		Priority:     item.Priority,
+		AssigneeID:   optional(item.AssigneeID),
+		ParentID:     optional(item.ParentID),
+		Progress:     ItemProgressResponse{Total: item.Progress.Total, Completed: item.Progress.Completed},
+		Checklist:    ChecklistProgressResponse{Total: item.Checklist.Total, Done: item.Checklist.Completed},
+		Rank:         item.Rank,
+		Recurrence:   recurrence,
+		Tags:         item.Tags,
+		CommentCount: item.CommentCount,
+		CreatedAt:    item.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:    item.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// toItemRecurrenceResponse converts a service recurrence to an API
+// response.
+func toItemRecurrenceResponse(rec *service.ItemRecurrence) ItemRecurrenceResponse {
+	return ItemRecurrenceResponse{
+		ItemID:    rec.ItemID,
+		Frequency: rec.Frequency,
+		Cron:      optional(rec.Cron),
+		StartsAt:  rec.StartsAt.Format("2006-01-02T15:04:05Z07:00"),
+		NextAt:    rec.NextAt.Format("2006-01-02T15:04:05Z07:00"),
+		CreatedAt: rec.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt: rec.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// toItemResponses converts service items to API responses.
+func toItemResponses(items []service.Item) []ItemResponse {
+	responses := make([]ItemResponse, len(items))
+	for i := range items {
+		responses[i] = toItemResponse(&items[i])
 	}
+	return responses
 }
@@ -0,0 +1,866 @@
+package handler_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keel/api/internal/apierror"
+	"github.com/keel/api/internal/apitest"
+	"github.com/keel/api/internal/app"
+	"github.com/keel/api/internal/config"
+	"github.com/keel/api/internal/handler"
+	"github.com/keel/api/internal/rank"
+	"github.com/keel/api/internal/service"
+	"github.com/keel/api/internal/store"
+)
+
+func TestListItems(t *testing.T) {
+	s := apitest.New(t)
+	owner := s.User().Create()
+	for range 3 {
+		s.Item().Owner(owner.ID).Create()
+	}
+	s.Item().Create()
+
+	var list handler.ItemListResponse
+	s.Get("/api/items").Expect(http.StatusOK).JSON(&list)
+	if len(list.Data) != 4 || list.Pagination != (handler.PaginationResponse{Page: 1, Limit: 10, Total: 4, TotalPages: 1}) {
+		t.Errorf("all items = %+v", list)
+	}
+
+	s.Get("/api/items?userId=" + owner.ID + "&limit=2").Expect(http.StatusOK).JSON(&list)
+	if len(list.Data) != 2 || list.Pagination != (handler.PaginationResponse{Page: 1, Limit: 2, Total: 3, TotalPages: 2}) {
+		t.Errorf("owner's items = %+v", list)
+	}
+	for _, item := range list.Data {
+		if item.UserID != owner.ID {
+			t.Errorf("item %s belongs to %s, want %s", item.ID, item.UserID, owner.ID)
+		}
+	}
+
+	s.Get("/api/items?userId=missing").Expect(http.StatusOK).JSON(&list)
+	if len(list.Data) != 0 || list.Pagination.Total != 0 {
+		t.Errorf("unknown user's items = %+v", list)
+	}
+}
+
+func TestCreateItem(t *testing.T) {
+	s := apitest.New(t)
+	owner := s.User().Create()
+
+	var item handler.ItemResponse
+	s.Post("/api/items", map[string]string{"userId": owner.ID, "title": "Write tests"}).
+		Expect(http.StatusCreated).JSON(&item)
+	if item.ID == "" || item.UserID != owner.ID || item.Title != "Write tests" || item.Description != "" || item.Status != "pending" {
+		t.Errorf("created = %+v", item)
+	}
+
+	s.Post("/api/items", map[string]string{
+		"userId":      owner.ID,
+		"title":       "Ship it",
+		"description": "Before Friday",
+		"status":      "in_progress",
+	}).Expect(http.StatusCreated).JSON(&item)
+	if item.Description != "Before Friday" || item.Status != "in_progress" {
+		t.Errorf("created = %+v", item)
+	}
+
+	tests := []struct {
+		name string
+		body any
+		code apierror.ErrorCode
+	}{
+		{"malformed body", "{", apierror.CodeBadRequest},
+		{"missing user", map[string]string{"title": "Orphan"}, apierror.CodeValidationError},
+		{"missing title", map[string]string{"userId": owner.ID}, apierror.CodeValidationError},
+		{"invalid status", map[string]string{"userId": owner.ID, "title": "Ship it", "status": "done"}, apierror.CodeValidationError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s.WithT(t).Post("/api/items", tt.body).Expect(http.StatusBadRequest).Error(tt.code)
+		})
+	}
+}
+
+func TestGetItem(t *testing.T) {
+	s := apitest.New(t)
+	want := s.Item().Title("Write tests").Description("All routes").Status("completed").Create()
+
+	var item handler.ItemResponse
+	s.Get("/api/items/" + want.ID).Expect(http.StatusOK).JSON(&item)
+	if item.ID != want.ID || item.UserID != want.UserID || item.Title != "Write tests" ||
+		item.Description != "All routes" || item.Status != "completed" {
+		t.Errorf("got %+v", item)
+	}
+
+	s.Get("/api/items/missing").Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+}
+
+func TestUpdateItem(t *testing.T) {
+	s := apitest.New(t)
+	item := s.Item().Title("Write tests").Description("All routes").Create()
+
+	var got handler.ItemResponse
+	s.Put("/api/items/"+item.ID, map[string]string{"status": "in_progress"}).
+		Expect(http.StatusOK).JSON(&got)
+	if got.Title != "Write tests" || got.Description != "All routes" || got.Status != "in_progress" {
+		t.Errorf("partial update = %+v", got)
+	}
+
+	s.Put("/api/items/"+item.ID, map[string]string{"title": "Write more tests", "description": "Every route"}).
+		Expect(http.StatusOK).JSON(&got)
+	if got.Title != "Write more tests" || got.Description != "Every route" || got.Status != "in_progress" {
+		t.Errorf("update = %+v", got)
+	}
+
+	for _, body := range []map[string]string{{"title": ""}, {"status": "done"}} {
+		s.Put("/api/items/"+item.ID, body).
+			Expect(http.StatusBadRequest).Error(apierror.CodeValidationError)
+	}
+	s.Put("/api/items/"+item.ID, "{").
+		Expect(http.StatusBadRequest).Error(apierror.CodeBadRequest)
+	s.Put("/api/items/missing", map[string]string{"title": "Nothing"}).
+		Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+}
+
+func TestItemStatusWorkflow(t *testing.T) {
+	cfg := config.Default()
+	cfg.Items.StatusTransitions = map[string][]string{
+		"pending":     {"in_progress"},
+		"in_progress": {"completed"},
+	}
+	s := apitest.NewWithConfig(t, cfg, app.Options{})
+	item := s.Item().Create()
+
+	e := s.Put("/api/items/"+item.ID, map[string]string{"status": "completed"}).
+		Expect(http.StatusConflict).Error(apierror.CodeConflict)
+	if e.Message != "Cannot change status from pending to completed" {
+		t.Errorf("message = %q", e.Message)
+	}
+	if details, _ := e.Details.(map[string]any); fmt.Sprint(details["allowed"]) != "[in_progress]" {
+		t.Errorf("details = %v, want the allowed statuses", e.Details)
+	}
+
+	// Keeping the status is always allowed.
+	s.Put("/api/items/"+item.ID, map[string]string{"status": "pending", "title": "Renamed"}).Expect(http.StatusOK)
+
+	for _, status := range []string{"in_progress", "completed"} {
+		s.Put("/api/items/"+item.ID, map[string]string{"status": status}).Expect(http.StatusOK)
+	}
+
+	// completed has no transitions, so it is final.
+	s.Put("/api/items/"+item.ID, map[string]string{"status": "in_progress"}).
+		Expect(http.StatusConflict).Error(apierror.CodeConflict)
+
+	var got handler.ItemResponse
+	s.Get("/api/items/" + item.ID).Expect(http.StatusOK).JSON(&got)
+	if got.Status != "completed" || got.Title != "Renamed" {
+		t.Errorf("item = %+v", got)
+	}
+}
+
+func TestItemHistory(t *testing.T) {
+	s := apitest.New(t)
+	owner := s.User().Create()
+	actor := s.User().Create()
+
+	var item handler.ItemResponse
+	s.Post("/api/items", map[string]string{"userId": owner.ID, "title": "Ship it"}).
+		Header("X-User-ID", owner.ID).Expect(http.StatusCreated).JSON(&item)
+	s.Put("/api/items/"+item.ID, map[string]string{"status": "in_progress"}).
+		Header("X-User-ID", actor.ID).Expect(http.StatusOK)
+	// Changes that keep the status are not recorded.
+	s.Put("/api/items/"+item.ID, map[string]string{"title": "Ship it now"}).Expect(http.StatusOK)
+	s.Put("/api/items/"+item.ID, map[string]string{"status": "completed"}).Expect(http.StatusOK)
+
+	var history handler.ItemHistoryResponse
+	s.Get("/api/items/" + item.ID + "/history").Expect(http.StatusOK).JSON(&history)
+	want := []struct{ from, to, actor string }{
+		{"", "pending", owner.ID},
+		{"pending", "in_progress", actor.ID},
+		{"in_progress", "completed", ""},
+	}
+	if len(history.Data) != len(want) {
+		t.Fatalf("history = %+v, want %d entries", history.Data, len(want))
+	}
+	for i, w := range want {
+		got := history.Data[i]
+		if deref(got.FromStatus) != w.from || got.ToStatus != w.to || deref(got.ActorID) != w.actor || got.ItemID != item.ID || got.CreatedAt == "" {
+			t.Errorf("history[%d] = %+v, want %s -> %s by %q", i, got, w.from, w.to, w.actor)
+		}
+	}
+
+	// Deleting the actor keeps the entry without them.
+	s.Delete("/api/users/" + actor.ID).Expect(http.StatusNoContent)
+	s.Get("/api/items/" + item.ID + "/history").Expect(http.StatusOK).JSON(&history)
+	if len(history.Data) != 3 || history.Data[1].ActorID != nil {
+		t.Errorf("history after deleting the actor = %+v", history.Data)
+	}
+
+	s.Put("/api/items/"+item.ID, map[string]string{"status": "in_progress"}).
+		Header("X-User-ID", "missing").Expect(http.StatusUnauthorized).Error(apierror.CodeUnauthorized)
+	s.Get("/api/items/missing/history").Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+
+	// Deleting the item deletes its history.
+	s.Delete("/api/items/" + item.ID).Expect(http.StatusNoContent)
+	rows, err := s.Store.ListItemStatusHistory(context.Background(), item.ID)
+	if err != nil || len(rows) != 0 {
+		t.Errorf("history of deleted item = %+v, %v", rows, err)
+	}
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func TestItemTags(t *testing.T) {
+	s := apitest.New(t)
+	owner := s.User().Create()
+
+	var item handler.ItemResponse
+	s.Post("/api/items", map[string]any{"userId": owner.ID, "title": "Tagged", "tags": []string{"ui", "bug", "ui"}}).
+		Expect(http.StatusCreated).JSON(&item)
+	if fmt.Sprint(item.Tags) != "[bug ui]" {
+		t.Errorf("created tags = %v", item.Tags)
+	}
+
+	var tags handler.TagListResponse
+	s.Get("/api/tags").Expect(http.StatusOK).JSON(&tags)
+	if tags.Pagination.Total != 2 {
+		t.Errorf("tags created with the item = %+v", tags.Data)
+	}
+
+	// Omitting tags keeps them; an empty list clears them.
+	s.Put("/api/items/"+item.ID, map[string]any{"title": "Still tagged"}).Expect(http.StatusOK).JSON(&item)
+	if fmt.Sprint(item.Tags) != "[bug ui]" {
+		t.Errorf("tags after update without tags = %v", item.Tags)
+	}
+	s.Put("/api/items/"+item.ID, map[string]any{"tags": []string{"docs"}}).Expect(http.StatusOK).JSON(&item)
+	if fmt.Sprint(item.Tags) != "[docs]" {
+		t.Errorf("replaced tags = %v", item.Tags)
+	}
+	s.Put("/api/items/"+item.ID, map[string]any{"tags": []string{}}).Expect(http.StatusOK).JSON(&item)
+	if item.Tags == nil || len(item.Tags) != 0 {
+		t.Errorf("cleared tags = %#v", item.Tags)
+	}
+
+	var bug handler.TagResponse
+	s.Post("/api/tags", map[string]string{"name": "critical"}).Expect(http.StatusCreated).JSON(&bug)
+	for range 2 {
+		s.Put("/api/items/"+item.ID+"/tags/"+bug.ID, nil).Expect(http.StatusNoContent)
+	}
+	s.Get("/api/items/" + item.ID).Expect(http.StatusOK).JSON(&item)
+	if fmt.Sprint(item.Tags) != "[critical]" {
+		t.Errorf("attached tags = %v", item.Tags)
+	}
+	for range 2 {
+		s.Delete("/api/items/" + item.ID + "/tags/" + bug.ID).Expect(http.StatusNoContent)
+	}
+	s.Get("/api/items/" + item.ID).Expect(http.StatusOK).JSON(&item)
+	if len(item.Tags) != 0 {
+		t.Errorf("detached tags = %v", item.Tags)
+	}
+
+	s.Put("/api/items/missing/tags/"+bug.ID, nil).Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+	s.Put("/api/items/"+item.ID+"/tags/missing", nil).Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+	s.Post("/api/items", map[string]any{"userId": owner.ID, "title": "Bad", "tags": []string{" "}}).
+		Expect(http.StatusBadRequest).Error(apierror.CodeValidationError)
+	s.Put("/api/items/"+item.ID, map[string]any{"tags": []string{""}}).
+		Expect(http.StatusBadRequest).Error(apierror.CodeValidationError)
+}
+
+func TestListItemsByTag(t *testing.T) {
+	s := apitest.New(t)
+	owner := s.User().Create()
+	create := func(title string, tags ...string) {
+		s.Post("/api/items", map[string]any{"userId": owner.ID, "title": title, "tags": tags}).Expect(http.StatusCreated)
+	}
+	create("both", "bug", "ui")
+	create("bug only", "bug")
+	create("untagged")
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"tag=bug", "[both bug only]"},
+		{"tag=bug&tag=ui", "[both bug only]"},
+		{"tag=bug&tag=ui&tagMatch=any", "[both bug only]"},
+		{"tag=bug&tag=ui&tagMatch=all", "[both]"},
+		{"tag=missing", "[]"},
+		{"tagMatch=all", "[both bug only untagged]"},
+	}
+	for _, tt := range tests {
+		var list handler.ItemListResponse
+		s.Get("/api/items?" + tt.query).Expect(http.StatusOK).JSON(&list)
+		var titles []string
+		for _, item := range list.Data {
+			titles = append(titles, item.Title)
+		}
+		slices.Sort(titles)
+		if got := fmt.Sprint(titles); got != tt.want || list.Pagination.Total != int64(len(titles)) {
+			t.Errorf("%s: items = %s (total %d), want %s", tt.query, got, list.Pagination.Total, tt.want)
+		}
+	}
+
+	s.Get("/api/items?tag=bug&tagMatch=some").Expect(http.StatusBadRequest).Error(apierror.CodeValidationError)
+}
+
+func TestItemPlanning(t *testing.T) {
+	s := apitest.New(t)
+	owner := s.User().Create()
+	assignee := s.User().Create()
+
+	var item handler.ItemResponse
+	s.Post("/api/items", map[string]any{
+		"userId": owner.ID, "title": "plan", "dueAt": "2030-05-01T12:00:00+02:00", "priority": "high", "assigneeId": assignee.ID,
+	}).Expect(http.StatusCreated).JSON(&item)
+	if item.DueAt == nil || *item.DueAt != "2030-05-01T10:00:00Z" || item.Priority != "high" || item.AssigneeID == nil || *item.AssigneeID != assignee.ID {
+		t.Fatalf("created item = %+v", item)
+	}
+
+	var defaults handler.ItemResponse
+	s.Post("/api/items", map[string]any{"userId": owner.ID, "title": "plain"}).Expect(http.StatusCreated).JSON(&defaults)
+	if defaults.DueAt != nil || defaults.Priority != "normal" || defaults.AssigneeID != nil {
+		t.Errorf("defaults = %+v", defaults)
+	}
+
+	// Fields left out of an update are kept; "" clears.
+	var updated handler.ItemResponse
+	s.Put("/api/items/"+item.ID, map[string]any{"title": "renamed"}).Expect(http.StatusOK).JSON(&updated)
+	if updated.DueAt == nil || updated.Priority != "high" || updated.AssigneeID == nil {
+		t.Errorf("after a title update = %+v", updated)
+	}
+	s.Put("/api/items/"+item.ID, map[string]any{"dueAt": "", "assigneeId": "", "priority": "low"}).Expect(http.StatusOK).JSON(&updated)
+	if updated.DueAt != nil || updated.Priority != "low" || updated.AssigneeID != nil {
+		t.Errorf("after clearing = %+v", updated)
+	}
+
+	for _, body := range []map[string]any{
+		{"userId": owner.ID, "title": "x", "priority": "critical"},
+		{"userId": owner.ID, "title": "x", "dueAt": "tomorrow"},
+		{"userId": owner.ID, "title": "x", "assigneeId": "missing"},
+	} {
+		s.Post("/api/items", body).Expect(http.StatusBadRequest).Error(apierror.CodeValidationError)
+	}
+	s.Put("/api/items/"+item.ID, map[string]any{"assigneeId": "missing"}).Expect(http.StatusBadRequest).Error(apierror.CodeValidationError)
+	s.Put("/api/items/"+item.ID, map[string]any{"dueAt": "2030-05-01"}).Expect(http.StatusBadRequest).Error(apierror.CodeValidationError)
+
+	// The assignee is unassigned when deleted.
+	s.Put("/api/items/"+item.ID, map[string]any{"assigneeId": assignee.ID}).Expect(http.StatusOK)
+	s.Delete("/api/users/" + assignee.ID).Expect(http.StatusNoContent)
+	s.Get("/api/items/" + item.ID).Expect(http.StatusOK).JSON(&updated)
+	if updated.AssigneeID != nil {
+		t.Errorf("assignee after deleting the user = %v", *updated.AssigneeID)
+	}
+}
+
+func TestListItemsByPlanning(t *testing.T) {
+	s := apitest.New(t)
+	assignee := s.User().Create()
+	now := time.Now()
+	s.Item().Title("overdue").DueAt(now.Add(-time.Hour)).Priority("low").Assignee(assignee.ID).Create()
+	s.Item().Title("done late").DueAt(now.Add(-2 * time.Hour)).Status("completed").Create()
+	s.Item().Title("next week").DueAt(now.Add(7 * 24 * time.Hour)).Priority("urgent").Create()
+	s.Item().Title("someday").Priority("high").Assignee(assignee.ID).Create()
+	dueBefore := url.QueryEscape(now.Add(24 * time.Hour).Format(time.RFC3339))
+
+	tests := []struct {
+		query  string
+		want   string
+		sorted bool
+	}{
+		{"overdue=true", "[overdue]", false},
+		{"overdue=false", "[done late next week overdue someday]", false},
+		{"dueBefore=" + dueBefore, "[done late overdue]", false},
+		{"overdue=true&dueBefore=" + dueBefore, "[overdue]", false},
+		{"assigneeId=" + assignee.ID, "[overdue someday]", false},
+		{"priority=high&priority=urgent", "[next week someday]", false},
+		{"order=due", "[done late overdue next week someday]", true},
+		{"order=priority", "[next week someday done late overdue]", true},
+		{"order=due&priority=low&priority=high", "[overdue someday]", true},
+	}
+	for _, tt := range tests {
+		var list handler.ItemListResponse
+		s.Get("/api/items?" + tt.query).Expect(http.StatusOK).JSON(&list)
+		var titles []string
+		for _, item := range list.Data {
+			titles = append(titles, item.Title)
+		}
+		if !tt.sorted {
+			slices.Sort(titles)
+		}
+		if got := fmt.Sprint(titles); got != tt.want || list.Pagination.Total != int64(len(titles)) {
+			t.Errorf("%s: items = %s (total %d), want %s", tt.query, got, list.Pagination.Total, tt.want)
+		}
+	}
+
+	for _, query := range []string{"order=title", "priority=critical", "dueBefore=tomorrow", "overdue=maybe"} {
+		s.Get("/api/items?" + query).Expect(http.StatusBadRequest).Error(apierror.CodeValidationError)
+	}
+}
+
+func TestSubItems(t *testing.T) {
+	s := apitest.New(t)
+	owner := s.User().Create()
+	create := func(title, parentID string) handler.ItemResponse {
+		var item handler.ItemResponse
+		s.Post("/api/items", map[string]any{"userId": owner.ID, "title": title, "parentId": parentID}).Expect(http.StatusCreated).JSON(&item)
+		return item
+	}
+	root := create("root", "")
+	child := create("child", root.ID)
+	create("other child", root.ID)
+	grandchild := create("grandchild", child.ID)
+	if child.ParentID == nil || *child.ParentID != root.ID || root.ParentID != nil {
+		t.Fatalf("parents: root %v, child %v", root.ParentID, child.ParentID)
+	}
+
+	var children handler.ItemChildrenResponse
+	s.Get("/api/items/" + root.ID + "/children").Expect(http.StatusOK).JSON(&children)
+	var titles []string
+	for _, item := range children.Data {
+		titles = append(titles, item.Title)
+	}
+	slices.Sort(titles)
+	if fmt.Sprint(titles) != "[child other child]" {
+		t.Errorf("children = %v", titles)
+	}
+
+	// Progress rolls up from every level.
+	s.Put("/api/items/"+grandchild.ID, map[string]any{"status": "in_progress"}).Expect(http.StatusOK)
+	s.Put("/api/items/"+grandchild.ID, map[string]any{"status": "completed"}).Expect(http.StatusOK)
+	var got handler.ItemResponse
+	s.Get("/api/items/" + root.ID).Expect(http.StatusOK).JSON(&got)
+	if got.Progress != (handler.ItemProgressResponse{Total: 3, Completed: 1}) {
+		t.Errorf("root progress = %+v, want 3 total, 1 completed", got.Progress)
+	}
+	var list handler.ItemListResponse
+	s.Get("/api/items?limit=10").Expect(http.StatusOK).JSON(&list)
+	for _, item := range list.Data {
+		if item.ID == child.ID && item.Progress != (handler.ItemProgressResponse{Total: 1, Completed: 1}) {
+			t.Errorf("listed child progress = %+v", item.Progress)
+		}
+	}
+
+	// An item cannot move under itself or its descendants.
+	s.Put("/api/items/"+root.ID, map[string]any{"parentId": root.ID}).Expect(http.StatusConflict).Error(apierror.CodeConflict)
+	s.Put("/api/items/"+root.ID, map[string]any{"parentId": grandchild.ID}).Expect(http.StatusConflict).Error(apierror.CodeConflict)
+	s.Put("/api/items/"+root.ID, map[string]any{"parentId": "missing"}).Expect(http.StatusBadRequest).Error(apierror.CodeValidationError)
+	s.Post("/api/items", map[string]any{"userId": owner.ID, "title": "x", "parentId": "missing"}).Expect(http.StatusBadRequest).Error(apierror.CodeValidationError)
+
+	s.Put("/api/items/"+grandchild.ID, map[string]any{"parentId": ""}).Expect(http.StatusOK).JSON(&got)
+	if got.ParentID != nil {
+		t.Errorf("parent after detaching = %v", *got.ParentID)
+	}
+
+	// Deleting a parent keeps its children as top-level items.
+	s.Delete("/api/items/" + root.ID).Expect(http.StatusNoContent)
+	s.Get("/api/items/" + child.ID).Expect(http.StatusOK).JSON(&got)
+	if got.ParentID != nil {
+		t.Errorf("parent after deleting it = %v", *got.ParentID)
+	}
+	s.Get("/api/items/" + root.ID + "/children").Expect(http.StatusNotFound)
+}
+
+func TestItemDependencies(t *testing.T) {
+	s := apitest.New(t)
+	a := s.Item().Title("a").Create()
+	b := s.Item().Title("b").Create()
+	c := s.Item().Title("c").Status("in_progress").Create()
+
+	// c is blocked by b, which is blocked by a.
+	s.Put("/api/items/"+c.ID+"/dependencies/"+b.ID, nil).Expect(http.StatusNoContent)
+	s.Put("/api/items/"+c.ID+"/dependencies/"+b.ID, nil).Expect(http.StatusNoContent)
+	s.Put("/api/items/"+b.ID+"/dependencies/"+a.ID, nil).Expect(http.StatusNoContent)
+
+	var deps handler.ItemDependenciesResponse
+	s.Get("/api/items/" + b.ID + "/dependencies").Expect(http.StatusOK).JSON(&deps)
+	if len(deps.BlockedBy) != 1 || deps.BlockedBy[0].ID != a.ID || len(deps.Blocks) != 1 || deps.Blocks[0].ID != c.ID {
+		t.Errorf("dependencies of b = %+v", deps)
+	}
+
+	for _, pair := range [][2]string{{a.ID, c.ID}, {a.ID, b.ID}, {a.ID, a.ID}} {
+		s.Put("/api/items/"+pair[0]+"/dependencies/"+pair[1], nil).Expect(http.StatusConflict).Error(apierror.CodeConflict)
+	}
+	s.Put("/api/items/"+a.ID+"/dependencies/missing", nil).Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+	s.Put("/api/items/missing/dependencies/"+a.ID, nil).Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+
+	// c cannot be completed until b is.
+	var apiErr struct {
+		Details struct{ Blockers []string }
+	}
+	s.Put("/api/items/"+c.ID, map[string]any{"status": "completed"}).Expect(http.StatusConflict).JSON(&apiErr)
+	if !slices.Equal(apiErr.Details.Blockers, []string{b.ID}) {
+		t.Errorf("blockers = %v, want [%s]", apiErr.Details.Blockers, b.ID)
+	}
+	s.Put("/api/items/"+b.ID, map[string]any{"status": "in_progress"}).Expect(http.StatusOK)
+	s.Put("/api/items/"+b.ID, map[string]any{"status": "completed"}).Expect(http.StatusConflict)
+	s.Delete("/api/items/" + b.ID + "/dependencies/" + a.ID).Expect(http.StatusNoContent)
+	s.Put("/api/items/"+b.ID, map[string]any{"status": "completed"}).Expect(http.StatusOK)
+	s.Put("/api/items/"+c.ID, map[string]any{"status": "completed"}).Expect(http.StatusOK)
+
+	// Deleting a blocker removes its dependencies.
+	s.Delete("/api/items/" + b.ID).Expect(http.StatusNoContent)
+	s.Get("/api/items/" + c.ID + "/dependencies").Expect(http.StatusOK).JSON(&deps)
+	if len(deps.BlockedBy) != 0 || len(deps.Blocks) != 0 {
+		t.Errorf("dependencies after deleting the blocker = %+v", deps)
+	}
+}
+
+func TestMoveItem(t *testing.T) {
+	s := apitest.New(t)
+	a := s.Item().Title("a").Create()
+	b := s.Item().Title("b").Create()
+	c := s.Item().Title("c").Create()
+	d := s.Item().Title("d").Create()
+
+	order := func() string {
+		t.Helper()
+		var list handler.ItemListResponse
+		s.Get("/api/items?order=rank").Expect(http.StatusOK).JSON(&list)
+		var titles []string
+		for _, item := range list.Data {
+			titles = append(titles, item.Title)
+		}
+		return fmt.Sprint(titles)
+	}
+	if got := order(); got != "[a b c d]" {
+		t.Fatalf("initial order = %s", got)
+	}
+
+	moves := []struct {
+		item     store.Item
+		position string
+		target   store.Item
+		want     string
+	}{
+		{d, "before", a, "[d a b c]"},
+		{a, "after", c, "[d b c a]"},
+		{b, "after", a, "[d c a b]"},
+		{c, "before", c, "[d c a b]"},
+		{a, "before", b, "[d c a b]"},
+	}
+	for _, m := range moves {
+		var moved handler.ItemResponse
+		s.Post("/api/items/"+m.item.ID+"/move", map[string]any{"targetId": m.target.ID, "position": m.position}).Expect(http.StatusOK).JSON(&moved)
+		if moved.ID != m.item.ID || moved.Rank == "" {
+			t.Errorf("moved item = %+v", moved)
+		}
+		if got := order(); got != m.want {
+			t.Errorf("move %s %s %s: order = %s, want %s", m.item.Title, m.position, m.target.Title, got, m.want)
+		}
+	}
+
+	// Only the moved item changes.
+	before, err := s.Store.ListItemsByRank(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Post("/api/items/"+d.ID+"/move", map[string]any{"targetId": b.ID, "position": "after"}).Expect(http.StatusOK)
+	after, err := s.Store.ListItemsByRank(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, item := range before {
+		i := slices.IndexFunc(after, func(other store.Item) bool { return other.ID == item.ID })
+		if item.ID != d.ID && (after[i].Rank != item.Rank || after[i].UpdatedAt != item.UpdatedAt) {
+			t.Errorf("%s changed from %+v to %+v", item.Title, item, after[i])
+		}
+	}
+
+	s.Post("/api/items/"+a.ID+"/move", map[string]any{"targetId": "missing", "position": "before"}).Expect(http.StatusBadRequest).Error(apierror.CodeValidationError)
+	s.Post("/api/items/"+a.ID+"/move", map[string]any{"targetId": b.ID, "position": "above"}).Expect(http.StatusBadRequest).Error(apierror.CodeValidationError)
+	s.Post("/api/items/"+a.ID+"/move", map[string]any{"position": "before"}).Expect(http.StatusBadRequest).Error(apierror.CodeValidationError)
+	s.Post("/api/items/missing/move", map[string]any{"targetId": b.ID, "position": "before"}).Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+}
+
+func TestRebalanceItemRanks(t *testing.T) {
+	s := apitest.New(t)
+	ctx := context.Background()
+	items := []store.Item{
+		s.Item().Title("a").Create(),
+		s.Item().Title("b").Create(),
+		s.Item().Title("c").Create(),
+	}
+	items = append(items, s.Item().Title("d").Create())
+
+	// Moving into a gap between items sharing a rank rebalances first.
+	for _, item := range items[1:3] {
+		if err := s.Store.SetItemRank(ctx, store.SetItemRankParams{Rank: items[1].Rank, ID: item.ID}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s.Post("/api/items/"+items[3].ID+"/move", map[string]any{"targetId": items[1].ID, "position": "after"}).Expect(http.StatusOK)
+	ranks := func() []string {
+		t.Helper()
+		list, err := s.Store.ListItemsByRank(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ranks []string
+		for _, item := range list {
+			ranks = append(ranks, item.Rank)
+		}
+		return ranks
+	}
+	if got := ranks(); !rank.Balanced(got) {
+		t.Errorf("ranks after moving between duplicates = %q", got)
+	}
+
+	// Repeatedly splitting the same gap makes keys long until rebalanced.
+	e := s.Item().Title("e").Create()
+	for range 40 {
+		s.Post("/api/items/"+e.ID+"/move", map[string]any{"targetId": items[0].ID, "position": "after"}).Expect(http.StatusOK)
+		s.Post("/api/items/"+items[0].ID+"/move", map[string]any{"targetId": e.ID, "position": "after"}).Expect(http.StatusOK)
+	}
+	if rank.Balanced(ranks()) {
+		t.Fatalf("ranks = %q, want them too long", ranks())
+	}
+	var list handler.ItemListResponse
+	s.Get("/api/items?order=rank").Expect(http.StatusOK).JSON(&list)
+
+	itemService := service.NewItemService(s.DB.Writer, s.Store, service.ItemRules{}, nil)
+	n, err := itemService.RebalanceRanks(ctx)
+	if err != nil || n == 0 {
+		t.Fatalf("RebalanceRanks = %d, %v", n, err)
+	}
+	if got := ranks(); !rank.Balanced(got) {
+		t.Errorf("ranks after rebalancing = %q", got)
+	}
+	var rebalanced handler.ItemListResponse
+	s.Get("/api/items?order=rank").Expect(http.StatusOK).JSON(&rebalanced)
+	for i := range list.Data {
+		if list.Data[i].ID != rebalanced.Data[i].ID {
+			t.Fatalf("rebalancing changed the order")
+		}
+	}
+	if n, err := itemService.RebalanceRanks(ctx); err != nil || n != 0 {
+		t.Errorf("second RebalanceRanks = %d, %v; want 0", n, err)
+	}
+}
+
+func TestItemRecurrence(t *testing.T) {
+	s := apitest.New(t)
+	assignee := s.User().Create()
+	due := time.Now().UTC().Add(24 * time.Hour).Truncate(time.Minute)
+	var item handler.ItemResponse
+	s.Post("/api/items", map[string]any{
+		"userId":     assignee.ID,
+		"title":      "water plants",
+		"dueAt":      due.Format(time.RFC3339),
+		"priority":   "high",
+		"assigneeId": assignee.ID,
+		"tags":       []string{"home"},
+	}).Expect(http.StatusCreated).JSON(&item)
+	if item.Recurrence != nil {
+		t.Errorf("new item recurrence = %+v, want null", item.Recurrence)
+	}
+
+	// The next occurrence falls due a day after the item.
+	var rec handler.ItemRecurrenceResponse
+	s.Put("/api/items/"+item.ID+"/recurrence", map[string]any{"frequency": "daily", "cron": "ignored"}).Expect(http.StatusOK).JSON(&rec)
+	if rec.ItemID != item.ID || rec.Cron != nil || rec.StartsAt != due.Format(time.RFC3339) || rec.NextAt != due.Add(24*time.Hour).Format(time.RFC3339) {
+		t.Errorf("recurrence = %+v", rec)
+	}
+	s.Get("/api/items/" + item.ID).Expect(http.StatusOK).JSON(&item)
+	if item.Recurrence == nil || *item.Recurrence != rec {
+		t.Errorf("item recurrence = %+v, want %+v", item.Recurrence, rec)
+	}
+
+	// Completing the item creates the next occurrence, which takes the rule
+	// and starts the checklist afresh.
+	s.Post("/api/items/"+item.ID+"/checklist", map[string]any{"text": "check soil", "done": true}).Expect(http.StatusCreated)
+	s.Put("/api/items/"+item.ID, map[string]any{"status": "completed"}).Expect(http.StatusOK).JSON(&item)
+	if item.Recurrence != nil {
+		t.Errorf("completed item recurrence = %+v, want null", item.Recurrence)
+	}
+	var list handler.ItemListResponse
+	s.Get("/api/items").Expect(http.StatusOK).JSON(&list)
+	var next *handler.ItemResponse
+	for i := range list.Data {
+		if list.Data[i].ID != item.ID {
+			next = &list.Data[i]
+		}
+	}
+	if next == nil || list.Pagination.Total != 2 {
+		t.Fatalf("items after completing = %+v", list.Data)
+	}
+	if next.Title != "water plants" || next.Status != "pending" || next.Priority != "high" || *next.AssigneeID != assignee.ID ||
+		!slices.Equal(next.Tags, []string{"home"}) || *next.DueAt != rec.NextAt || next.Checklist != (handler.ChecklistProgressResponse{Total: 1}) {
+		t.Errorf("next occurrence = %+v", next)
+	}
+	if next.Recurrence == nil || next.Recurrence.ItemID != next.ID || next.Recurrence.NextAt != due.Add(48*time.Hour).Format(time.RFC3339) {
+		t.Errorf("next occurrence recurrence = %+v", next.Recurrence)
+	}
+
+	// Reopening and completing the old item again does nothing more.
+	s.Put("/api/items/"+item.ID, map[string]any{"status": "in_progress"}).Expect(http.StatusOK)
+	s.Put("/api/items/"+item.ID, map[string]any{"status": "completed"}).Expect(http.StatusOK)
+	s.Get("/api/items").Expect(http.StatusOK).JSON(&list)
+	if list.Pagination.Total != 2 {
+		t.Errorf("items after completing again = %d, want 2", list.Pagination.Total)
+	}
+
+	// A cron rule falls due at its next match.
+	s.Put("/api/items/"+next.ID+"/recurrence", map[string]any{"frequency": "cron", "cron": "30 9 * * mon"}).Expect(http.StatusOK).JSON(&rec)
+	nextAt, err := time.Parse(time.RFC3339, rec.NextAt)
+	if err != nil || *rec.Cron != "30 9 * * mon" || nextAt.Weekday() != time.Monday || nextAt.Hour() != 9 || nextAt.Minute() != 30 || !nextAt.After(due) {
+		t.Errorf("cron recurrence = %+v", rec)
+	}
+
+	s.Delete("/api/items/" + next.ID + "/recurrence").Expect(http.StatusNoContent)
+	s.Delete("/api/items/" + next.ID + "/recurrence").Expect(http.StatusNoContent)
+	s.Get("/api/items/" + next.ID).Expect(http.StatusOK).JSON(&item)
+	if item.Recurrence != nil {
+		t.Errorf("recurrence after removing = %+v, want null", item.Recurrence)
+	}
+
+	for _, body := range []map[string]any{
+		{},
+		{"frequency": "yearly"},
+		{"frequency": "cron"},
+		{"frequency": "cron", "cron": "0 9 * *"},
+		{"frequency": "cron", "cron": "0 25 * * *"},
+		{"frequency": "cron", "cron": "0 0 30 2 *"},
+		{"frequency": "daily", "startsAt": "tomorrow"},
+	} {
+		s.Put("/api/items/"+next.ID+"/recurrence", body).Expect(http.StatusBadRequest).Error(apierror.CodeValidationError)
+	}
+	s.Put("/api/items/missing/recurrence", map[string]any{"frequency": "daily"}).Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+	s.Delete("/api/items/missing/recurrence").Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+}
+
+func TestMaterializeRecurrences(t *testing.T) {
+	s := apitest.New(t)
+	ctx := context.Background()
+	item := s.Item().Title("standup").Create()
+	other := s.Item().Title("report").Create()
+
+	// The next occurrence of item fell due an hour ago; other's is ahead.
+	now := time.Now().UTC().Truncate(time.Second)
+	for _, rec := range []store.SetItemRecurrenceParams{
+		{ItemID: item.ID, Frequency: "daily", StartsAt: now.Add(-25 * time.Hour), NextAt: now.Add(-time.Hour)},
+		{ItemID: other.ID, Frequency: "weekly", StartsAt: now, NextAt: now.Add(7 * 24 * time.Hour)},
+	} {
+		rec.CreatedAt, rec.UpdatedAt = now, now
+		if _, err := s.Store.SetItemRecurrence(ctx, rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	itemService := service.NewItemService(s.DB.Writer, s.Store, service.ItemRules{}, nil)
+	if n, err := itemService.MaterializeRecurrences(ctx); err != nil || n != 1 {
+		t.Fatalf("MaterializeRecurrences = %d, %v; want 1", n, err)
+	}
+	if n, err := itemService.MaterializeRecurrences(ctx); err != nil || n != 0 {
+		t.Errorf("second MaterializeRecurrences = %d, %v; want 0", n, err)
+	}
+
+	// The open item is kept and the new one is due when the old rule said.
+	var list handler.ItemListResponse
+	s.Get("/api/items").Expect(http.StatusOK).JSON(&list)
+	var titles []string
+	for _, got := range list.Data {
+		titles = append(titles, got.Title)
+		if got.Title == "standup" && got.ID != item.ID {
+			if *got.DueAt != now.Add(-time.Hour).Format(time.RFC3339) {
+				t.Errorf("new occurrence due %s, want %s", *got.DueAt, now.Add(-time.Hour).Format(time.RFC3339))
+			}
+			if got.Recurrence == nil || got.Recurrence.NextAt != now.Add(23*time.Hour).Format(time.RFC3339) {
+				t.Errorf("new occurrence recurrence = %+v", got.Recurrence)
+			}
+		}
+	}
+	slices.Sort(titles)
+	if fmt.Sprint(titles) != "[report standup standup]" {
+		t.Errorf("items = %v", titles)
+	}
+
+	// Deleting the latest occurrence ends the series.
+	for _, got := range list.Data {
+		if got.Title == "standup" && got.ID != item.ID {
+			s.Delete("/api/items/" + got.ID).Expect(http.StatusNoContent)
+		}
+	}
+	if due, err := s.Store.ListDueItemRecurrences(ctx, now.Add(8*24*time.Hour)); err != nil || len(due) != 1 || due[0].ItemID != other.ID {
+		t.Errorf("recurrences after deleting = %+v, %v", due, err)
+	}
+}
+
+// failingStore fails GetItem for one item, in and out of transactions.
+type failingStore struct {
+	store.Store
+	itemID string
+}
+
+func (f failingStore) InTx(tx *sql.Tx) store.Store {
+	return failingStore{f.Store.InTx(tx), f.itemID}
+}
+
+func (f failingStore) GetItem(ctx context.Context, id string) (store.Item, error) {
+	if id == f.itemID {
+		return store.Item{}, errors.New("boom")
+	}
+	return f.Store.GetItem(ctx, id)
+}
+
+func TestMaterializeRecurrencesContinuesAfterFailure(t *testing.T) {
+	s := apitest.New(t)
+	ctx := context.Background()
+	broken := s.Item().Title("broken").Create()
+	item := s.Item().Title("standup").Create()
+
+	// broken falls due first, so its failure comes before item's turn.
+	now := time.Now().UTC().Truncate(time.Second)
+	for _, rec := range []store.SetItemRecurrenceParams{
+		{ItemID: broken.ID, Frequency: "daily", StartsAt: now.Add(-26 * time.Hour), NextAt: now.Add(-2 * time.Hour)},
+		{ItemID: item.ID, Frequency: "daily", StartsAt: now.Add(-25 * time.Hour), NextAt: now.Add(-time.Hour)},
+	} {
+		rec.CreatedAt, rec.UpdatedAt = now, now
+		if _, err := s.Store.SetItemRecurrence(ctx, rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	itemService := service.NewItemService(s.DB.Writer, failingStore{s.Store, broken.ID}, service.ItemRules{}, nil)
+	n, err := itemService.MaterializeRecurrences(ctx)
+	if n != 1 || err == nil || !strings.Contains(err.Error(), broken.ID) {
+		t.Fatalf("MaterializeRecurrences = %d, %v; want 1 and an error for %s", n, err, broken.ID)
+	}
+
+	// The failed rule is rolled back and still due.
+	due, err := s.Store.ListDueItemRecurrences(ctx, now)
+	if err != nil || len(due) != 1 || due[0].ItemID != broken.ID {
+		t.Errorf("due recurrences = %+v, %v; want only %s", due, err, broken.ID)
+	}
+	var list handler.ItemListResponse
+	s.Get("/api/items").Expect(http.StatusOK).JSON(&list)
+	if list.Pagination.Total != 3 {
+		t.Errorf("total = %d, want 3", list.Pagination.Total)
+	}
+}
+
+func TestDeleteItem(t *testing.T) {
+	s := apitest.New(t)
+	item := s.Item().Create()
+
+	s.Delete("/api/items/" + item.ID).Expect(http.StatusNoContent)
+	s.Get("/api/items/" + item.ID).Expect(http.StatusNotFound)
+
+	// The owner is kept.
+	s.Get("/api/users/" + item.UserID).Expect(http.StatusOK)
+
+	s.Delete("/api/items/" + item.ID).Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+}
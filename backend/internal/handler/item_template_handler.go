@@ -0,0 +1,334 @@
+package handler
+
+// Sections between scaffold:begin and scaffold:end markers are regenerated
+// by cmd/scaffold -regen; remove a section's markers to keep local edits.
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/keel/api/internal/apierror"
+	"github.com/keel/api/internal/service"
+)
+
+// scaffold:begin handler
+
+// ItemTemplateHandler handles HTTP requests for item template operations.
+type ItemTemplateHandler struct {
+	itemTemplateService *service.ItemTemplateService
+}
+
+// NewItemTemplateHandler creates a new ItemTemplateHandler.
+func NewItemTemplateHandler(itemTemplateService *service.ItemTemplateService) *ItemTemplateHandler {
+	return &ItemTemplateHandler{itemTemplateService: itemTemplateService}
+}
+
+// scaffold:end handler
+
+// RegisterRoutes registers item template routes on the given router.
+func (h *ItemTemplateHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/item-templates", h.List)
+	r.Post("/item-templates", h.Create)
+	r.Get("/item-templates/{id}", h.Get)
+	r.Put("/item-templates/{id}", h.Update)
+	r.Delete("/item-templates/{id}", h.Delete)
+}
+
+// CreateItemTemplateRequest represents the request body for creating an item template.
+type CreateItemTemplateRequest struct {
+	Name        string   `json:"name"`
+	Title       string   `json:"title"`
+	Description *string  `json:"description,omitempty"`
+	Status      *string  `json:"status,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Checklist   []string `json:"checklist,omitempty"`
+}
+
+// UpdateItemTemplateRequest represents the request body for updating an item template.
+type UpdateItemTemplateRequest struct {
+	Name        *string   `json:"name,omitempty"`
+	Title       *string   `json:"title,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	Status      *string   `json:"status,omitempty"`
+	Tags        *[]string `json:"tags,omitempty"`
+	Checklist   *[]string `json:"checklist,omitempty"`
+}
+
+// ItemTemplateResponse represents an item template in the API response.
+type ItemTemplateResponse struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Title       string   `json:"title"`
+	Description *string  `json:"description"`
+	Status      string   `json:"status"`
+	Tags        []string `json:"tags"`
+	Checklist   []string `json:"checklist"`
+	CreatedAt   string   `json:"createdAt"`
+	UpdatedAt   string   `json:"updatedAt"`
+}
+
+// ItemTemplateListResponse represents a paginated list of item templates.
+type ItemTemplateListResponse struct {
+	Data       []ItemTemplateResponse `json:"data"`
+	Pagination PaginationResponse     `json:"pagination"`
+}
+
+// scaffold:begin list
+
+// List handles GET /api/item-templates
+func (h *ItemTemplateHandler) List(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit < 1 {
+		limit = 10
+	}
+
+	result, err := h.itemTemplateService.List(r.Context(), page, limit)
+	if err != nil {
+		slog.Error("failed to list item templates", "error", err)
+		apierror.InternalError(w, r, "Failed to list item templates")
+		return
+	}
+
+	response := ItemTemplateListResponse{
+		Data: make([]ItemTemplateResponse, len(result.Data)),
+		Pagination: PaginationResponse{
+			Page:       result.Page,
+			Limit:      result.Limit,
+			Total:      result.Total,
+			TotalPages: result.TotalPages,
+		},
+	}
+
+	for i, itemTemplate := range result.Data {
+		response.Data[i] = toItemTemplateResponse(&itemTemplate)
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// scaffold:end list
+
+// Create handles POST /api/item-templates
+func (h *ItemTemplateHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req CreateItemTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.BadRequest(w, r, "Invalid request body", nil)
+		return
+	}
+	if req.Name == "" {
+		apierror.ValidationError(w, r, "Name is required", nil)
+		return
+	}
+	if req.Title == "" {
+		apierror.ValidationError(w, r, "Title is required", nil)
+		return
+	}
+	if req.Status != nil && !slices.Contains(service.ItemTemplateStatusValues, *req.Status) {
+		apierror.ValidationError(w, r, "Status must be one of: pending, in_progress, completed", nil)
+		return
+	}
+	tags, ok := tagNames(req.Tags)
+	if !ok {
+		apierror.ValidationError(w, r, "Tag names cannot be empty", nil)
+		return
+	}
+	checklist, ok := checklistTexts(req.Checklist)
+	if !ok {
+		apierror.ValidationError(w, r, checklistMessage, nil)
+		return
+	}
+
+	itemTemplate, err := h.itemTemplateService.Create(r.Context(), service.CreateItemTemplateInput{
+		Name:        req.Name,
+		Title:       req.Title,
+		Description: req.Description,
+		Status:      req.Status,
+		Tags:        tags,
+		Checklist:   checklist,
+	})
+	if err != nil {
+		if h.writeCheckError(w, r, err) {
+			return
+		}
+		slog.Error("failed to create item template", "error", err)
+		apierror.InternalError(w, r, "Failed to create item template")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toItemTemplateResponse(itemTemplate))
+}
+
+// scaffold:begin get
+
+// Get handles GET /api/item-templates/{id}
+func (h *ItemTemplateHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		apierror.BadRequest(w, r, "Item template ID is required", nil)
+		return
+	}
+
+	itemTemplate, err := h.itemTemplateService.Get(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, service.ErrItemTemplateNotFound) {
+			apierror.NotFound(w, r, "Item template not found")
+			return
+		}
+		slog.Error("failed to get item template", "error", err, "id", id)
+		apierror.InternalError(w, r, "Failed to get item template")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toItemTemplateResponse(itemTemplate))
+}
+
+// scaffold:end get
+
+// Update handles PUT /api/item-templates/{id}
+func (h *ItemTemplateHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		apierror.BadRequest(w, r, "Item template ID is required", nil)
+		return
+	}
+
+	var req UpdateItemTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.BadRequest(w, r, "Invalid request body", nil)
+		return
+	}
+	if req.Name != nil && *req.Name == "" {
+		apierror.ValidationError(w, r, "Name cannot be empty", nil)
+		return
+	}
+	if req.Title != nil && *req.Title == "" {
+		apierror.ValidationError(w, r, "Title cannot be empty", nil)
+		return
+	}
+	if req.Status != nil && !slices.Contains(service.ItemTemplateStatusValues, *req.Status) {
+		apierror.ValidationError(w, r, "Status must be one of: pending, in_progress, completed", nil)
+		return
+	}
+	var tags, checklist *[]string
+	if req.Tags != nil {
+		names, ok := tagNames(*req.Tags)
+		if !ok {
+			apierror.ValidationError(w, r, "Tag names cannot be empty", nil)
+			return
+		}
+		tags = &names
+	}
+	if req.Checklist != nil {
+		texts, ok := checklistTexts(*req.Checklist)
+		if !ok {
+			apierror.ValidationError(w, r, checklistMessage, nil)
+			return
+		}
+		checklist = &texts
+	}
+
+	itemTemplate, err := h.itemTemplateService.Update(r.Context(), id, service.UpdateItemTemplateInput{
+		Name:        req.Name,
+		Title:       req.Title,
+		Description: req.Description,
+		Status:      req.Status,
+		Tags:        tags,
+		Checklist:   checklist,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrItemTemplateNotFound) {
+			apierror.NotFound(w, r, "Item template not found")
+			return
+		}
+		if h.writeCheckError(w, r, err) {
+			return
+		}
+		slog.Error("failed to update item template", "error", err, "id", id)
+		apierror.InternalError(w, r, "Failed to update item template")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toItemTemplateResponse(itemTemplate))
+}
+
+// scaffold:begin delete
+
+// Delete handles DELETE /api/item-templates/{id}
+func (h *ItemTemplateHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		apierror.BadRequest(w, r, "Item template ID is required", nil)
+		return
+	}
+
+	err := h.itemTemplateService.Delete(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, service.ErrItemTemplateNotFound) {
+			apierror.NotFound(w, r, "Item template not found")
+			return
+		}
+		slog.Error("failed to delete item template", "error", err, "id", id)
+		apierror.InternalError(w, r, "Failed to delete item template")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// scaffold:end delete
+
+// scaffold:begin checkerror
+
+// writeCheckError writes the response for errors from the service's
+// reference and uniqueness checks, reporting whether err was one.
+func (h *ItemTemplateHandler) writeCheckError(w http.ResponseWriter, r *http.Request, err error) bool {
+	switch {
+	case errors.Is(err, service.ErrItemTemplateAlreadyExists):
+		apierror.Conflict(w, r, "Item template with this name already exists")
+	default:
+		return false
+	}
+	return true
+}
+
+// scaffold:end checkerror
+
+// toItemTemplateResponse converts a service item template to an API response.
+func toItemTemplateResponse(itemTemplate *service.ItemTemplate) ItemTemplateResponse {
+	return ItemTemplateResponse{
+		ID:          itemTemplate.ID,
+		Name:        itemTemplate.Name,
+		Title:       itemTemplate.Title,
+		Description: itemTemplate.Description,
+		Status:      itemTemplate.Status,
+		Tags:        itemTemplate.Tags,
+		Checklist:   itemTemplate.Checklist,
+		CreatedAt:   itemTemplate.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:   itemTemplate.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+const checklistMessage = "Checklist entries cannot be empty"
+
+// checklistTexts trims checklist entries, reporting false if any is empty.
+func checklistTexts(texts []string) (trimmed []string, ok bool) {
+	trimmed = make([]string, len(texts))
+	for i, text := range texts {
+		trimmed[i] = strings.TrimSpace(text)
+		if trimmed[i] == "" {
+			return nil, false
+		}
+	}
+	return trimmed, true
+}
@@ -0,0 +1,266 @@
+package handler_test
+
+// Sections between scaffold:begin and scaffold:end markers are regenerated
+// by cmd/scaffold -regen; remove a section's markers to keep local edits.
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/keel/api/internal/apitest"
+	"github.com/keel/api/internal/handler"
+	"github.com/keel/api/internal/service"
+	"github.com/keel/api/internal/store"
+	"github.com/keel/api/internal/store/storetest"
+)
+
+func newItemTemplateRouter(t *testing.T) (http.Handler, store.Store) {
+	t.Helper()
+	db, queries := storetest.OpenSQLite(t)
+
+	r := chi.NewRouter()
+	handler.NewItemTemplateHandler(service.NewItemTemplateService(db.Writer, queries)).RegisterRoutes(r)
+	return r, queries
+}
+
+func serveItemTemplate(t *testing.T, h http.Handler, method, path string, body any, out any) int {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, path, &buf))
+
+	if out != nil && rec.Body.Len() > 0 {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: decode %q: %v", method, path, rec.Body.String(), err)
+		}
+	}
+	return rec.Code
+}
+
+// assertItemTemplateFields checks that every field in want round-trips to
+// the same JSON value in got.
+func assertItemTemplateFields(t *testing.T, got, want map[string]any) {
+	t.Helper()
+	raw, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var norm map[string]any
+	if err := json.Unmarshal(raw, &norm); err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range norm {
+		if !reflect.DeepEqual(got[k], v) {
+			t.Errorf("%s = %v, want %v", k, got[k], v)
+		}
+	}
+}
+
+// scaffold:begin crud
+
+func TestItemTemplateCRUD(t *testing.T) {
+	h, _ := newItemTemplateRouter(t)
+	base := "/item-templates"
+
+	create := map[string]any{
+		"name":        "name 1",
+		"title":       "title 1",
+		"description": "description 1",
+		"status":      "pending",
+	}
+	var created map[string]any
+	status := serveItemTemplate(t, h, http.MethodPost, base, create, &created)
+	id, _ := created["id"].(string)
+	if status != http.StatusCreated || id == "" {
+		t.Fatalf("create: status %d, body %v", status, created)
+	}
+	assertItemTemplateFields(t, created, create)
+
+	var got map[string]any
+	if status := serveItemTemplate(t, h, http.MethodGet, base+"/"+id, nil, &got); status != http.StatusOK {
+		t.Fatalf("get: status %d", status)
+	}
+	assertItemTemplateFields(t, got, created)
+
+	var list handler.ItemTemplateListResponse
+	if status := serveItemTemplate(t, h, http.MethodGet, base+"?limit=5", nil, &list); status != http.StatusOK {
+		t.Fatalf("list: status %d", status)
+	}
+	if list.Pagination.Total != 1 || list.Pagination.Limit != 5 || len(list.Data) != 1 {
+		t.Errorf("list = %+v", list)
+	}
+
+	update := map[string]any{
+		"name":        "name 2",
+		"title":       "title 2",
+		"description": "description 2",
+		"status":      "completed",
+	}
+	var updated map[string]any
+	if status := serveItemTemplate(t, h, http.MethodPut, base+"/"+id, update, &updated); status != http.StatusOK {
+		t.Fatalf("update: status %d", status)
+	}
+	assertItemTemplateFields(t, updated, update)
+
+	if status := serveItemTemplate(t, h, http.MethodDelete, base+"/"+id, nil, nil); status != http.StatusNoContent {
+		t.Fatalf("delete: status %d", status)
+	}
+	if status := serveItemTemplate(t, h, http.MethodGet, base+"/"+id, nil, nil); status != http.StatusNotFound {
+		t.Errorf("get after delete: status %d, want 404", status)
+	}
+}
+
+// scaffold:end crud
+
+// scaffold:begin errors
+
+func TestItemTemplateErrors(t *testing.T) {
+	h, _ := newItemTemplateRouter(t)
+	base := "/item-templates"
+
+	valid := func(overrides map[string]any) map[string]any {
+		body := map[string]any{
+			"name":        "name 1",
+			"title":       "title 1",
+			"description": "description 1",
+			"status":      "pending",
+		}
+		for k, v := range overrides {
+			body[k] = v
+		}
+		return body
+	}
+	if status := serveItemTemplate(t, h, http.MethodPost, base, valid(nil), nil); status != http.StatusCreated {
+		t.Fatalf("create: status %d", status)
+	}
+
+	tests := []struct {
+		name, method, path string
+		body               any
+		status             int
+		code               string
+	}{
+		{"malformed body", http.MethodPost, base, "not an object", http.StatusBadRequest, "BAD_REQUEST"},
+		{"missing required field", http.MethodPost, base, map[string]any{}, http.StatusBadRequest, "VALIDATION_ERROR"},
+		{"invalid status", http.MethodPost, base, valid(map[string]any{"status": "bogus"}), http.StatusBadRequest, "VALIDATION_ERROR"},
+		{"duplicate", http.MethodPost, base, valid(nil), http.StatusConflict, "CONFLICT"},
+		{"get missing", http.MethodGet, base + "/missing", nil, http.StatusNotFound, "NOT_FOUND"},
+		{"update missing", http.MethodPut, base + "/missing", map[string]any{}, http.StatusNotFound, "NOT_FOUND"},
+		{"delete missing", http.MethodDelete, base + "/missing", nil, http.StatusNotFound, "NOT_FOUND"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body struct{ Code string }
+			if status := serveItemTemplate(t, h, tt.method, tt.path, tt.body, &body); status != tt.status || body.Code != tt.code {
+				t.Errorf("status %d code %q, want %d %q", status, body.Code, tt.status, tt.code)
+			}
+		})
+	}
+}
+
+// scaffold:end errors
+
+func TestItemTemplateContents(t *testing.T) {
+	s := apitest.New(t)
+
+	var tmpl handler.ItemTemplateResponse
+	s.Post("/api/item-templates", map[string]any{
+		"name":      "weekly review",
+		"title":     "Review the week",
+		"tags":      []string{" work ", "review"},
+		"checklist": []string{"inbox zero", " plan next week"},
+	}).Expect(http.StatusCreated).JSON(&tmpl)
+	if !slices.Equal(tmpl.Tags, []string{"review", "work"}) || !slices.Equal(tmpl.Checklist, []string{"inbox zero", "plan next week"}) {
+		t.Errorf("created template tags %q checklist %q", tmpl.Tags, tmpl.Checklist)
+	}
+
+	// Omitted lists are kept; given ones replace the template's.
+	s.Put("/api/item-templates/"+tmpl.ID, map[string]any{"title": "Review the week ahead"}).Expect(http.StatusOK).JSON(&tmpl)
+	if len(tmpl.Tags) != 2 || len(tmpl.Checklist) != 2 {
+		t.Errorf("template after title update = %+v", tmpl)
+	}
+	s.Put("/api/item-templates/"+tmpl.ID, map[string]any{"tags": []string{}, "checklist": []string{"plan", "file notes"}}).Expect(http.StatusOK).JSON(&tmpl)
+	if len(tmpl.Tags) != 0 || !slices.Equal(tmpl.Checklist, []string{"plan", "file notes"}) {
+		t.Errorf("template after list update tags %q checklist %q", tmpl.Tags, tmpl.Checklist)
+	}
+
+	// The list loads every template's own contents.
+	s.Post("/api/item-templates", map[string]any{"name": "standup", "title": "Standup", "tags": []string{"team"}}).Expect(http.StatusCreated)
+	var list handler.ItemTemplateListResponse
+	s.Get("/api/item-templates").Expect(http.StatusOK).JSON(&list)
+	contents := map[string]string{}
+	for _, got := range list.Data {
+		contents[got.Name] = fmt.Sprintf("%q %q", got.Tags, got.Checklist)
+	}
+	if want := `[] ["plan" "file notes"]`; contents["weekly review"] != want {
+		t.Errorf("listed weekly review = %s, want %s", contents["weekly review"], want)
+	}
+	if want := `["team"] []`; contents["standup"] != want {
+		t.Errorf("listed standup = %s, want %s", contents["standup"], want)
+	}
+
+	s.Post("/api/item-templates", map[string]any{"name": "bad", "title": "Bad", "checklist": []string{" "}}).Expect(http.StatusBadRequest)
+	s.Put("/api/item-templates/"+tmpl.ID, map[string]any{"tags": []string{""}}).Expect(http.StatusBadRequest)
+}
+
+func TestCreateItemFromTemplate(t *testing.T) {
+	s := apitest.New(t)
+	user := s.User().Create()
+
+	var tmpl handler.ItemTemplateResponse
+	s.Post("/api/item-templates", map[string]any{
+		"name":        "bug",
+		"title":       "Fix bug",
+		"description": "Steps to reproduce:",
+		"status":      "in_progress",
+		"tags":        []string{"bug"},
+		"checklist":   []string{"reproduce", "write a test", "fix"},
+	}).Expect(http.StatusCreated).JSON(&tmpl)
+
+	var item handler.ItemResponse
+	s.Post("/api/item-templates/"+tmpl.ID+"/items", map[string]any{"userId": user.ID}).Expect(http.StatusCreated).JSON(&item)
+	if item.UserID != user.ID || item.Title != "Fix bug" || item.Description != "Steps to reproduce:" || item.Status != "in_progress" || !slices.Equal(item.Tags, []string{"bug"}) {
+		t.Errorf("item from template = %+v", item)
+	}
+	if item.Checklist != (handler.ChecklistProgressResponse{Total: 3}) {
+		t.Errorf("item checklist = %+v, want 3 entries", item.Checklist)
+	}
+	if got := checklistTexts(t, s, item.ID); !slices.Equal(got, tmpl.Checklist) {
+		t.Errorf("item checklist = %q, want %q", got, tmpl.Checklist)
+	}
+
+	// Overrides win over the template, and fields it lacks are passed on.
+	s.Post("/api/item-templates/"+tmpl.ID+"/items", map[string]any{
+		"userId":      user.ID,
+		"title":       "Fix login bug",
+		"description": "",
+		"status":      "pending",
+		"priority":    "urgent",
+		"assigneeId":  user.ID,
+		"parentId":    item.ID,
+		"tags":        []string{"bug", "auth"},
+	}).Expect(http.StatusCreated).JSON(&item)
+	if item.Title != "Fix login bug" || item.Description != "" || item.Status != "pending" || item.Priority != "urgent" ||
+		item.AssigneeID == nil || *item.AssigneeID != user.ID || item.ParentID == nil || !slices.Equal(item.Tags, []string{"auth", "bug"}) {
+		t.Errorf("item with overrides = %+v", item)
+	}
+
+	s.Post("/api/item-templates/"+tmpl.ID+"/items", map[string]any{}).Expect(http.StatusBadRequest)
+	s.Post("/api/item-templates/"+tmpl.ID+"/items", map[string]any{"userId": user.ID, "status": "bogus"}).Expect(http.StatusBadRequest)
+	s.Post("/api/item-templates/"+tmpl.ID+"/items", map[string]any{"userId": user.ID, "assigneeId": "missing"}).Expect(http.StatusBadRequest)
+	s.Post("/api/item-templates/missing/items", map[string]any{"userId": user.ID}).Expect(http.StatusNotFound)
+}
@@ -0,0 +1,8 @@
+package handler
+
+import "github.com/go-chi/chi/v5"
+
+// RouteRegistrar is implemented by handlers that mount their own routes.
+type RouteRegistrar interface {
+	RegisterRoutes(r chi.Router)
+}
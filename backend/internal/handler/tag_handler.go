@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/keel/api/internal/apierror"
+	"github.com/keel/api/internal/service"
+)
+
+// TagHandler handles HTTP requests for tag operations.
+type TagHandler struct {
+	tagService *service.TagService
+}
+
+// NewTagHandler creates a new TagHandler.
+func NewTagHandler(tagService *service.TagService) *TagHandler {
+	return &TagHandler{tagService: tagService}
+}
+
+// RegisterRoutes registers tag routes on the given router.
+func (h *TagHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/tags", h.List)
+	r.Post("/tags", h.Create)
+	r.Get("/tags/{id}", h.Get)
+	r.Put("/tags/{id}", h.Update)
+	r.Delete("/tags/{id}", h.Delete)
+}
+
+// TagRequest represents the request body for creating or renaming a tag.
+type TagRequest struct {
+	Name string `json:"name"`
+}
+
+// TagResponse represents a tag in the API response.
+type TagResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"createdAt"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// TagListResponse represents a paginated list of tags.
+type TagListResponse struct {
+	Data       []TagResponse      `json:"data"`
+	Pagination PaginationResponse `json:"pagination"`
+}
+
+// List handles GET /api/tags
+func (h *TagHandler) List(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit < 1 {
+		limit = 10
+	}
+
+	result, err := h.tagService.List(r.Context(), page, limit)
+	if err != nil {
+		slog.Error("failed to list tags", "error", err)
+		apierror.InternalError(w, r, "Failed to list tags")
+		return
+	}
+
+	response := TagListResponse{
+		Data: make([]TagResponse, len(result.Data)),
+		Pagination: PaginationResponse{
+			Page:       result.Page,
+			Limit:      result.Limit,
+			Total:      result.Total,
+			TotalPages: result.TotalPages,
+		},
+	}
+
+	for i, tag := range result.Data {
+		response.Data[i] = toTagResponse(&tag)
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Create handles POST /api/tags
+func (h *TagHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req TagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.BadRequest(w, r, "Invalid request body", nil)
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		apierror.ValidationError(w, r, "Name is required", nil)
+		return
+	}
+
+	tag, err := h.tagService.Create(r.Context(), name)
+	if err != nil {
+		if errors.Is(err, service.ErrTagAlreadyExists) {
+			apierror.Conflict(w, r, "Tag with this name already exists")
+			return
+		}
+		slog.Error("failed to create tag", "error", err)
+		apierror.InternalError(w, r, "Failed to create tag")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toTagResponse(tag))
+}
+
+// Get handles GET /api/tags/{id}
+func (h *TagHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		apierror.BadRequest(w, r, "Tag ID is required", nil)
+		return
+	}
+
+	tag, err := h.tagService.Get(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, service.ErrTagNotFound) {
+			apierror.NotFound(w, r, "Tag not found")
+			return
+		}
+		slog.Error("failed to get tag", "error", err, "id", id)
+		apierror.InternalError(w, r, "Failed to get tag")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toTagResponse(tag))
+}
+
+// Update handles PUT /api/tags/{id}, which renames the tag.
+func (h *TagHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		apierror.BadRequest(w, r, "Tag ID is required", nil)
+		return
+	}
+
+	var req TagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.BadRequest(w, r, "Invalid request body", nil)
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		apierror.ValidationError(w, r, "Name is required", nil)
+		return
+	}
+
+	tag, err := h.tagService.Rename(r.Context(), id, name)
+	if err != nil {
+		if errors.Is(err, service.ErrTagNotFound) {
+			apierror.NotFound(w, r, "Tag not found")
+			return
+		}
+		if errors.Is(err, service.ErrTagAlreadyExists) {
+			apierror.Conflict(w, r, "Tag with this name already exists")
+			return
+		}
+		slog.Error("failed to update tag", "error", err, "id", id)
+		apierror.InternalError(w, r, "Failed to update tag")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toTagResponse(tag))
+}
+
+// Delete handles DELETE /api/tags/{id}
+func (h *TagHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		apierror.BadRequest(w, r, "Tag ID is required", nil)
+		return
+	}
+
+	err := h.tagService.Delete(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, service.ErrTagNotFound) {
+			apierror.NotFound(w, r, "Tag not found")
+			return
+		}
+		slog.Error("failed to delete tag", "error", err, "id", id)
+		apierror.InternalError(w, r, "Failed to delete tag")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// toTagResponse converts a service tag to an API response.
+func toTagResponse(tag *service.Tag) TagResponse {
+	return TagResponse{
+		ID:        tag.ID,
+		Name:      tag.Name,
+		CreatedAt: tag.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt: tag.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
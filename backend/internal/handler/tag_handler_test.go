@@ -0,0 +1,64 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/keel/api/internal/apierror"
+	"github.com/keel/api/internal/apitest"
+	"github.com/keel/api/internal/handler"
+)
+
+func TestTags(t *testing.T) {
+	s := apitest.New(t)
+
+	var bug, ui handler.TagResponse
+	s.Post("/api/tags", map[string]string{"name": " bug "}).Expect(http.StatusCreated).JSON(&bug)
+	if bug.ID == "" || bug.Name != "bug" || bug.CreatedAt == "" {
+		t.Errorf("created = %+v", bug)
+	}
+	s.Post("/api/tags", map[string]string{"name": "ui"}).Expect(http.StatusCreated).JSON(&ui)
+	s.Post("/api/tags", map[string]string{"name": "bug"}).
+		Expect(http.StatusConflict).Error(apierror.CodeConflict)
+	s.Post("/api/tags", map[string]string{"name": "  "}).
+		Expect(http.StatusBadRequest).Error(apierror.CodeValidationError)
+
+	var list handler.TagListResponse
+	s.Get("/api/tags").Expect(http.StatusOK).JSON(&list)
+	if len(list.Data) != 2 || list.Data[0].Name != "bug" || list.Data[1].Name != "ui" || list.Pagination.Total != 2 {
+		t.Errorf("list = %+v", list)
+	}
+
+	var got handler.TagResponse
+	s.Get("/api/tags/" + bug.ID).Expect(http.StatusOK).JSON(&got)
+	if got != bug {
+		t.Errorf("get = %+v, want %+v", got, bug)
+	}
+
+	// Renaming keeps the tag on its items.
+	item := s.Item().Create()
+	s.Put("/api/items/"+item.ID+"/tags/"+bug.ID, nil).Expect(http.StatusNoContent)
+	s.Put("/api/tags/"+bug.ID, map[string]string{"name": "defect"}).Expect(http.StatusOK).JSON(&got)
+	if got.Name != "defect" {
+		t.Errorf("renamed = %+v", got)
+	}
+	var itemGot handler.ItemResponse
+	s.Get("/api/items/" + item.ID).Expect(http.StatusOK).JSON(&itemGot)
+	if len(itemGot.Tags) != 1 || itemGot.Tags[0] != "defect" {
+		t.Errorf("item tags after rename = %v", itemGot.Tags)
+	}
+	s.Put("/api/tags/"+bug.ID, map[string]string{"name": "defect"}).Expect(http.StatusOK)
+	s.Put("/api/tags/"+bug.ID, map[string]string{"name": "ui"}).
+		Expect(http.StatusConflict).Error(apierror.CodeConflict)
+	s.Put("/api/tags/missing", map[string]string{"name": "other"}).
+		Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+
+	// Deleting removes the tag from its items.
+	s.Delete("/api/tags/" + bug.ID).Expect(http.StatusNoContent)
+	s.Get("/api/tags/" + bug.ID).Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+	s.Get("/api/items/" + item.ID).Expect(http.StatusOK).JSON(&itemGot)
+	if len(itemGot.Tags) != 0 {
+		t.Errorf("item tags after delete = %v", itemGot.Tags)
+	}
+	s.Delete("/api/tags/" + bug.ID).Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+}
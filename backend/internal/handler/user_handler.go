@@ -5,6 +5,7 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"slices"
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
@@ -122,6 +123,10 @@ func (h *UserHandler) Create(w http.ResponseWriter, r *http.Request) {
 		apierror.ValidationError(w, r, "Name is required", nil)
 		return
 	}
+	if req.Role != "" && !slices.Contains(service.UserRoleValues, req.Role) {
+		apierror.ValidationError(w, r, "Role must be one of: admin, user", nil)
+		return
+	}
 
 	user, err := h.userService.Create(r.Context(), service.CreateUserInput{
 		Email: req.Email,
@@ -176,6 +181,18 @@ func (h *UserHandler) Update(w http.ResponseWriter, r *http.Request) {
 		apierror.BadRequest(w, r, "Invalid request body", nil)
 		return
 	}
+	if req.Email != nil && *req.Email == "" {
+		apierror.ValidationError(w, r, "Email cannot be empty", nil)
+		return
+	}
+	if req.Name != nil && *req.Name == "" {
+		apierror.ValidationError(w, r, "Name cannot be empty", nil)
+		return
+	}
+	if req.Role != nil && !slices.Contains(service.UserRoleValues, *req.Role) {
+		apierror.ValidationError(w, r, "Role must be one of: admin, user", nil)
+		return
+	}
 
 	user, err := h.userService.Update(r.Context(), id, service.UpdateUserInput{
 		Email: req.Email,
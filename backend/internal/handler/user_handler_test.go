@@ -0,0 +1,132 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/keel/api/internal/apierror"
+	"github.com/keel/api/internal/apitest"
+	"github.com/keel/api/internal/handler"
+)
+
+func TestListUsers(t *testing.T) {
+	s := apitest.New(t)
+	for range 3 {
+		s.User().Create()
+	}
+
+	var list handler.UserListResponse
+	s.Get("/api/users").Expect(http.StatusOK).JSON(&list)
+	if len(list.Data) != 3 || list.Pagination != (handler.PaginationResponse{Page: 1, Limit: 10, Total: 3, TotalPages: 1}) {
+		t.Errorf("default page = %+v", list)
+	}
+
+	s.Get("/api/users?page=2&limit=2").Expect(http.StatusOK).JSON(&list)
+	if len(list.Data) != 1 || list.Pagination != (handler.PaginationResponse{Page: 2, Limit: 2, Total: 3, TotalPages: 2}) {
+		t.Errorf("second page = %+v", list)
+	}
+
+	s.Get("/api/users?page=0&limit=1000").Expect(http.StatusOK).JSON(&list)
+	if list.Pagination.Page != 1 || list.Pagination.Limit != 100 {
+		t.Errorf("out of range pagination = %+v", list.Pagination)
+	}
+}
+
+func TestCreateUser(t *testing.T) {
+	s := apitest.New(t)
+
+	var user handler.UserResponse
+	s.Post("/api/users", map[string]string{"email": "ada@example.com", "name": "Ada"}).
+		Expect(http.StatusCreated).JSON(&user)
+	if user.ID == "" || user.Email != "ada@example.com" || user.Name != "Ada" || user.Role != "user" {
+		t.Errorf("created = %+v", user)
+	}
+	if user.CreatedAt == "" || user.UpdatedAt == "" {
+		t.Errorf("missing timestamps: %+v", user)
+	}
+
+	s.Post("/api/users", map[string]string{"email": "root@example.com", "name": "Root", "role": "admin"}).
+		Expect(http.StatusCreated).JSON(&user)
+	if user.Role != "admin" {
+		t.Errorf("role = %q, want admin", user.Role)
+	}
+
+	tests := []struct {
+		name string
+		body any
+		code apierror.ErrorCode
+	}{
+		{"malformed body", "{", apierror.CodeBadRequest},
+		{"missing email", map[string]string{"name": "Ada"}, apierror.CodeValidationError},
+		{"missing name", map[string]string{"email": "new@example.com"}, apierror.CodeValidationError},
+		{"invalid role", map[string]string{"email": "new@example.com", "name": "New", "role": "root"}, apierror.CodeValidationError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s.WithT(t).Post("/api/users", tt.body).Expect(http.StatusBadRequest).Error(tt.code)
+		})
+	}
+
+	s.Post("/api/users", map[string]string{"email": "ada@example.com", "name": "Other Ada"}).
+		Expect(http.StatusConflict).Error(apierror.CodeConflict)
+}
+
+func TestGetUser(t *testing.T) {
+	s := apitest.New(t)
+	want := s.User().Email("ada@example.com").Name("Ada").Create()
+
+	var user handler.UserResponse
+	s.Get("/api/users/" + want.ID).Expect(http.StatusOK).JSON(&user)
+	if user.ID != want.ID || user.Email != "ada@example.com" || user.Name != "Ada" {
+		t.Errorf("got %+v", user)
+	}
+
+	s.Get("/api/users/missing").Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+}
+
+func TestUpdateUser(t *testing.T) {
+	s := apitest.New(t)
+	user := s.User().Email("ada@example.com").Name("Ada").Create()
+	other := s.User().Create()
+
+	var got handler.UserResponse
+	s.Put("/api/users/"+user.ID, map[string]string{"name": "Ada Lovelace"}).
+		Expect(http.StatusOK).JSON(&got)
+	if got.Name != "Ada Lovelace" || got.Email != "ada@example.com" || got.Role != "user" {
+		t.Errorf("partial update = %+v", got)
+	}
+
+	s.Put("/api/users/"+user.ID, map[string]string{"email": "lovelace@example.com", "role": "admin"}).
+		Expect(http.StatusOK).JSON(&got)
+	if got.Name != "Ada Lovelace" || got.Email != "lovelace@example.com" || got.Role != "admin" {
+		t.Errorf("update = %+v", got)
+	}
+
+	// Keeping the user's own email is not a conflict.
+	s.Put("/api/users/"+user.ID, map[string]string{"email": "lovelace@example.com"}).Expect(http.StatusOK)
+
+	s.Put("/api/users/"+user.ID, map[string]string{"email": other.Email}).
+		Expect(http.StatusConflict).Error(apierror.CodeConflict)
+	for _, body := range []map[string]string{{"email": ""}, {"name": ""}, {"role": "root"}} {
+		s.Put("/api/users/"+user.ID, body).
+			Expect(http.StatusBadRequest).Error(apierror.CodeValidationError)
+	}
+	s.Put("/api/users/"+user.ID, "{").
+		Expect(http.StatusBadRequest).Error(apierror.CodeBadRequest)
+	s.Put("/api/users/missing", map[string]string{"name": "Nobody"}).
+		Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+}
+
+func TestDeleteUser(t *testing.T) {
+	s := apitest.New(t)
+	user := s.User().Create()
+	item := s.Item().Owner(user.ID).Create()
+
+	s.Delete("/api/users/" + user.ID).Expect(http.StatusNoContent)
+	s.Get("/api/users/" + user.ID).Expect(http.StatusNotFound)
+
+	// Deleting a user deletes their items.
+	s.Get("/api/items/" + item.ID).Expect(http.StatusNotFound)
+
+	s.Delete("/api/users/" + user.ID).Expect(http.StatusNotFound).Error(apierror.CodeNotFound)
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/keel/api/internal/apierror"
+)
+
+// RequireAdminToken rejects requests that do not carry
+// "Authorization: Bearer <token>". An empty token rejects every request, so
+// admin routes are disabled unless a token is configured.
+func RequireAdminToken(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token == "" {
+				apierror.Unauthorized(w, r, "Admin API is disabled")
+				return
+			}
+			given, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+				apierror.Unauthorized(w, r, "Invalid admin token")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/go-chi/cors"
+)
+
+// CORS is a CORS middleware whose options can be replaced while the server
+// is running.
+type CORS struct {
+	current atomic.Pointer[cors.Cors]
+}
+
+// NewCORS creates a CORS middleware with the given options.
+func NewCORS(opts cors.Options) *CORS {
+	c := &CORS{}
+	c.Update(opts)
+	return c
+}
+
+// Update atomically swaps the options used for subsequent requests.
+func (c *CORS) Update(opts cors.Options) {
+	c.current.Store(cors.New(opts))
+}
+
+// Handler returns the middleware handler.
+func (c *CORS) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.current.Load().Handler(next).ServeHTTP(w, r)
+	})
+}
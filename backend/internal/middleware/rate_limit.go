@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/keel/api/internal/apierror"
+)
+
+// staleBucketAge is how long a client may be idle before its bucket is
+// dropped.
+const staleBucketAge = 10 * time.Minute
+
+// RateLimiter limits requests per client IP using a token bucket. Limits
+// can be changed while the server is running; a rate of zero disables it.
+type RateLimiter struct {
+	mu        sync.Mutex
+	rate      float64
+	burst     int
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a RateLimiter allowing rate requests per second
+// with bursts of up to burst requests.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		rate:    rate,
+		burst:   burst,
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Update changes the limits. Existing clients keep their remaining tokens,
+// capped at the new burst.
+func (l *RateLimiter) Update(rate float64, burst int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.rate = rate
+	l.burst = burst
+	for _, b := range l.buckets {
+		if b.tokens > float64(burst) {
+			b.tokens = float64(burst)
+		}
+	}
+}
+
+// Handler returns the middleware handler.
+func (l *RateLimiter) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		allowed, retryAfter := l.allow(clientIP(r))
+		if !allowed {
+			w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds()+0.999)))
+			apierror.TooManyRequests(w, r, "")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// allow consumes a token for key, reporting whether the request may proceed
+// and, if not, how long until a token is available.
+func (l *RateLimiter) allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.rate <= 0 {
+		return true, 0
+	}
+
+	now := l.now()
+	if now.Sub(l.lastSweep) > staleBucketAge {
+		for k, b := range l.buckets {
+			if now.Sub(b.last) > staleBucketAge {
+				delete(l.buckets, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.burst), last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens += now.Sub(b.last).Seconds() * l.rate
+	if b.tokens > float64(l.burst) {
+		b.tokens = float64(l.burst)
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+	}
+	b.tokens--
+	return true, 0
+}
+
+// clientIP returns the request's client address without the port. It relies
+// on chi's RealIP middleware running first when behind a proxy.
+func clientIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
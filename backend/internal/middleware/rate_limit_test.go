@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Now()
+	l := NewRateLimiter(1, 2)
+	l.now = func() time.Time { return now }
+
+	h := l.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	do := func(addr string) int {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = addr
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Burst of two is allowed, the third is rejected.
+	for i, want := range []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests} {
+		if got := do("10.0.0.1:1234"); got != want {
+			t.Fatalf("request %d: got %d, want %d", i, got, want)
+		}
+	}
+
+	// Other clients have their own bucket.
+	if got := do("10.0.0.2:1234"); got != http.StatusOK {
+		t.Fatalf("second client: got %d, want 200", got)
+	}
+
+	// Tokens refill over time.
+	now = now.Add(time.Second)
+	if got := do("10.0.0.1:1234"); got != http.StatusOK {
+		t.Fatalf("after refill: got %d, want 200", got)
+	}
+
+	// Disabling the limiter lets everything through.
+	l.Update(0, 0)
+	for i := 0; i < 5; i++ {
+		if got := do("10.0.0.1:1234"); got != http.StatusOK {
+			t.Fatalf("disabled limiter: got %d, want 200", got)
+		}
+	}
+}
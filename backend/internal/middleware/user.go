@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/keel/api/internal/apierror"
+)
+
+// UserKey is the context key for the User a request is made on behalf of.
+const UserKey contextKey = "user"
+
+// User identifies who a request is made on behalf of.
+type User struct {
+	ID   string
+	Role string
+}
+
+// UserLookup returns the user with the given ID; ok is false if there is
+// none.
+type UserLookup func(ctx context.Context, id string) (user User, ok bool, err error)
+
+// Identify attaches the user named by the X-User-ID header to the request
+// context. Requests without the header carry no user; an unknown ID is
+// rejected with 401.
+//
+// Keel has no authentication yet, so the header is taken at its word. Checks
+// made against the user, such as who may edit a comment, keep well-behaved
+// clients honest; they are not a security boundary.
+func Identify(lookup UserLookup) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get("X-User-ID")
+			if id == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			user, ok, err := lookup(r.Context(), id)
+			if err != nil {
+				slog.Error("failed to look up user", "error", err, "id", id)
+				apierror.InternalError(w, r, "Failed to look up user")
+				return
+			}
+			if !ok {
+				apierror.Unauthorized(w, r, "Unknown user in X-User-ID")
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), UserKey, user)))
+		})
+	}
+}
+
+// RequireUser rejects requests without a user with 401. It must run after
+// Identify.
+func RequireUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := GetUser(r.Context()); !ok {
+			apierror.Unauthorized(w, r, "X-User-ID is required")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// GetUser returns the user set by Identify, if any.
+func GetUser(ctx context.Context) (User, bool) {
+	user, ok := ctx.Value(UserKey).(User)
+	return user, ok
+}
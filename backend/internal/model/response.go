@@ -30,4 +30,8 @@ const (
 	ErrCodeInternal     = "INTERNAL_ERROR"
 	ErrCodeBadRequest   = "BAD_REQUEST"
 	ErrCodeUnauthorized = "UNAUTHORIZED"
+	ErrCodeForbidden    = "FORBIDDEN"
+	ErrCodeTooLarge     = "PAYLOAD_TOO_LARGE"
+	ErrCodeUnsupported  = "UNSUPPORTED_MEDIA_TYPE"
+	ErrCodeRateLimited  = "RATE_LIMITED"
 )
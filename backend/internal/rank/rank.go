@@ -0,0 +1,160 @@
+// Package rank generates keys for ordering items by hand.
+//
+// Keys are strings of the digits 0-9a-z that sort in the intended order
+// under plain byte comparison, which SQLite and Postgres agree on for these
+// characters. A key can always be found between two others, so moving an
+// item rewrites only that item's key. Keys grow by about a character each
+// time the same gap is split, so Spread reissues short, evenly spaced keys
+// once Balanced reports they have grown too long.
+//
+// The algorithm treats keys as base-36 fractions and never produces a key
+// ending in 0, so there is always room below a key as well as above it.
+package rank
+
+import (
+	"errors"
+	"strings"
+)
+
+// Digits are the characters keys are made of, in order.
+const Digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+const base = len(Digits)
+
+// slack is how many characters keys may grow beyond the width Spread
+// would give them before Balanced reports they need rebalancing.
+const slack = 6
+
+// ErrOrder is returned by Between when the bounds are not in increasing
+// order, which happens when two items share a key.
+var ErrOrder = errors.New("rank: bounds are not in increasing order")
+
+// ErrInvalid is returned by Between when a bound is not a valid key.
+var ErrInvalid = errors.New("rank: invalid key")
+
+// Between returns a key that sorts after lo and before hi. An empty lo is
+// below every key and an empty hi above every key, so Between("", "")
+// returns a first key and Between(last, "") one after the last.
+func Between(lo, hi string) (string, error) {
+	if (lo != "" && !Valid(lo)) || (hi != "" && !Valid(hi)) {
+		return "", ErrInvalid
+	}
+	if hi != "" && lo >= hi {
+		return "", ErrOrder
+	}
+	return midpoint(lo, hi), nil
+}
+
+// midpoint implements Between for valid, ordered bounds.
+func midpoint(lo, hi string) string {
+	if hi != "" {
+		// Keep the common prefix, reading lo as padded with zeros
+		n := 0
+		for n < len(hi) && digitAt(lo, n) == hi[n] {
+			n++
+		}
+		if n > 0 {
+			return hi[:n] + midpoint(suffix(lo, n), hi[n:])
+		}
+	}
+	digitLo := 0
+	if lo != "" {
+		digitLo = strings.IndexByte(Digits, lo[0])
+	}
+	digitHi := base
+	if hi != "" {
+		digitHi = strings.IndexByte(Digits, hi[0])
+	}
+	if digitHi-digitLo > 1 {
+		return string(Digits[(digitLo+digitHi+1)/2])
+	}
+	// The first digits are adjacent: a prefix of hi, or lo's first digit
+	// followed by anything above the rest of lo
+	if len(hi) > 1 {
+		return hi[:1]
+	}
+	return string(Digits[digitLo]) + midpoint(suffix(lo, 1), "")
+}
+
+// digitAt returns the i-th character of key, or 0 past its end.
+func digitAt(key string, i int) byte {
+	if i < len(key) {
+		return key[i]
+	}
+	return Digits[0]
+}
+
+// suffix returns key without its first n characters.
+func suffix(key string, n int) string {
+	if n >= len(key) {
+		return ""
+	}
+	return key[n:]
+}
+
+// Valid reports whether key is a key Between or Spread could return.
+func Valid(key string) bool {
+	if key == "" || key[len(key)-1] == Digits[0] {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if strings.IndexByte(Digits, key[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Spread returns n evenly spaced keys in increasing order, leaving room
+// for a one-character key in every gap.
+func Spread(n int) []string {
+	width := spreadWidth(n)
+	space := pow(width)
+	step := space / uint64(n+1)
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = format(uint64(i+1)*step, width)
+	}
+	return keys
+}
+
+// spreadWidth returns the number of characters Spread uses for n keys.
+func spreadWidth(n int) int {
+	width := 1
+	for pow(width) < uint64(n+1)*uint64(base) {
+		width++
+	}
+	return width
+}
+
+// pow returns base to the power of width.
+func pow(width int) uint64 {
+	p := uint64(1)
+	for i := 0; i < width; i++ {
+		p *= uint64(base)
+	}
+	return p
+}
+
+// format writes v in base 36, zero-padded to width, without trailing zeros.
+func format(v uint64, width int) string {
+	b := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		b[i] = Digits[v%uint64(base)]
+		v /= uint64(base)
+	}
+	return strings.TrimRight(string(b), Digits[:1])
+}
+
+// Balanced reports whether keys, sorted in increasing order, are valid,
+// distinct and not much longer than Spread would make them. Keys that are
+// not balanced should be replaced with Spread(len(keys)).
+func Balanced(keys []string) bool {
+	limit := spreadWidth(len(keys)) + slack
+	for i, key := range keys {
+		if !Valid(key) || len(key) > limit || (i > 0 && keys[i-1] >= key) {
+			return false
+		}
+	}
+	return true
+}
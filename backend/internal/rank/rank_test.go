@@ -0,0 +1,100 @@
+package rank
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		lo, hi, want string
+	}{
+		{"", "", "i"},
+		{"i", "", "r"},
+		{"", "i", "9"},
+		{"a", "b", "ai"},
+		{"a", "a1", "a0i"},
+		{"az", "b", "azi"},
+		{"0001", "0002", "0001i"},
+		{"", "0001", "0000i"},
+		{"zz", "", "zzi"},
+	}
+	for _, tt := range tests {
+		got, err := Between(tt.lo, tt.hi)
+		if err != nil || got != tt.want {
+			t.Errorf("Between(%q, %q) = %q, %v; want %q", tt.lo, tt.hi, got, err, tt.want)
+		}
+	}
+
+	for _, bounds := range [][2]string{{"b", "a"}, {"a", "a"}} {
+		if _, err := Between(bounds[0], bounds[1]); !errors.Is(err, ErrOrder) {
+			t.Errorf("Between(%q, %q) error = %v, want ErrOrder", bounds[0], bounds[1], err)
+		}
+	}
+	for _, bounds := range [][2]string{{"a0", ""}, {"", "A"}} {
+		if _, err := Between(bounds[0], bounds[1]); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Between(%q, %q) error = %v, want ErrInvalid", bounds[0], bounds[1], err)
+		}
+	}
+}
+
+// TestBetweenRandom inserts keys at random positions and checks that every
+// key lands where it was meant to.
+func TestBetweenRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	keys := []string{}
+	for range 2000 {
+		i := r.Intn(len(keys) + 1)
+		var lo, hi string
+		if i > 0 {
+			lo = keys[i-1]
+		}
+		if i < len(keys) {
+			hi = keys[i]
+		}
+		key, err := Between(lo, hi)
+		if err != nil {
+			t.Fatalf("Between(%q, %q): %v", lo, hi, err)
+		}
+		if !Valid(key) || key <= lo || (hi != "" && key >= hi) {
+			t.Fatalf("Between(%q, %q) = %q, not strictly between", lo, hi, key)
+		}
+		keys = slices.Insert(keys, i, key)
+	}
+}
+
+func TestSpread(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 35, 36, 1000, 50000} {
+		keys := Spread(n)
+		if len(keys) != n || !Balanced(keys) {
+			t.Errorf("Spread(%d) is not %d balanced keys", n, n)
+		}
+		// Every gap has room for a key no longer than its bounds.
+		for i := 1; i < len(keys); i++ {
+			key, err := Between(keys[i-1], keys[i])
+			if err != nil || len(key) > spreadWidth(n) {
+				t.Errorf("Spread(%d): Between(%q, %q) = %q, %v", n, keys[i-1], keys[i], key, err)
+				break
+			}
+		}
+	}
+}
+
+func TestBalanced(t *testing.T) {
+	if !Balanced([]string{"a", "b", "c"}) {
+		t.Error("short distinct keys should be balanced")
+	}
+	for _, keys := range [][]string{
+		{"a", "a"},
+		{"b", "a"},
+		{"a", ""},
+		{"a", "b0"},
+		{"a", "bzzzzzzzzz"},
+	} {
+		if Balanced(keys) {
+			t.Errorf("Balanced(%q) = true", keys)
+		}
+	}
+}
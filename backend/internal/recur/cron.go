@@ -0,0 +1,158 @@
+package recur
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a parsed cron expression.
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// domAny and dowAny record a * in the day fields: when both fields are
+	// restricted, a day matches if either does, as in cron(8).
+	domAny, dowAny bool
+}
+
+// cronField describes one field of a cron expression.
+type cronField struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var cronFields = [5]cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}},
+	// 7 is accepted for Sunday as well as 0
+	{name: "day of week", min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}},
+}
+
+// ParseCron parses a standard five-field cron expression: minute, hour, day
+// of month, month and day of week. Each field is *, a number, a range a-b
+// or a comma-separated list of them, optionally followed by /step. Months
+// and weekdays may also be given by their three-letter English names.
+func ParseCron(expr string) (*CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("cron expression needs %d fields, got %d", len(cronFields), len(fields))
+	}
+	var sets [5]uint64
+	for i, f := range fields {
+		set, err := cronFields[i].parse(strings.ToLower(f))
+		if err != nil {
+			return nil, err
+		}
+		sets[i] = set
+	}
+	dow := sets[4]
+	if dow&(1<<7) != 0 {
+		dow |= 1
+	}
+	return &CronSchedule{
+		minute: sets[0],
+		hour:   sets[1],
+		dom:    sets[2],
+		month:  sets[3],
+		dow:    dow,
+		domAny: strings.HasPrefix(fields[2], "*"),
+		dowAny: strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+// parse returns the values a field matches as a bit set.
+func (f cronField) parse(expr string) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(expr, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepExpr)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid step %q in %s field", stepExpr, f.name)
+			}
+			step = n
+		}
+
+		lo, hi := f.min, f.max
+		switch {
+		case rangeExpr == "*":
+		case strings.Contains(rangeExpr, "-"):
+			loExpr, hiExpr, _ := strings.Cut(rangeExpr, "-")
+			var err error
+			if lo, err = f.value(loExpr); err != nil {
+				return 0, err
+			}
+			if hi, err = f.value(hiExpr); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("invalid range %q in %s field", rangeExpr, f.name)
+			}
+		default:
+			v, err := f.value(rangeExpr)
+			if err != nil {
+				return 0, err
+			}
+			lo = v
+			if !hasStep {
+				// A single value; with a step it runs to the maximum
+				hi = v
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+// value parses a single number or name in the field.
+func (f cronField) value(expr string) (int, error) {
+	for i, name := range f.names {
+		if expr == name {
+			// Month names start at 1, weekday names at 0
+			return f.min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(expr)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid value %q in %s field, want %d-%d", expr, f.name, f.min, f.max)
+	}
+	return v, nil
+}
+
+// Next returns the first time after t that the schedule matches, to the
+// minute, or the zero time if it does not match within five years.
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(horizon, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches reports whether the day fields match t's date.
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domAny || s.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
@@ -0,0 +1,114 @@
+// Package recur computes when recurring items fall due.
+//
+// A Rule repeats daily, weekly or monthly from its start, or at the times
+// matched by a cron expression. All times are in UTC. Errors describe the
+// problem with the rule in words fit to show to the user who wrote it.
+package recur
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Frequencies of a Rule.
+const (
+	Daily   = "daily"
+	Weekly  = "weekly"
+	Monthly = "monthly"
+	Cron    = "cron"
+)
+
+// Frequencies lists the allowed values of Rule.Frequency.
+var Frequencies = []string{Daily, Weekly, Monthly, Cron}
+
+// ErrNoOccurrence is returned by Validate for a cron expression that never
+// matches, such as one for the 30th of February.
+var ErrNoOccurrence = errors.New("schedule has no upcoming occurrence")
+
+// horizon is how far ahead Next looks for a cron match.
+const horizon = 5 // years
+
+// Rule says when an item recurs.
+type Rule struct {
+	Frequency string
+	// Cron is a five-field cron expression, used when Frequency is Cron.
+	Cron string
+	// Start is the first occurrence for daily, weekly and monthly rules,
+	// and the earliest time a cron expression may match.
+	Start time.Time
+}
+
+// Validate reports whether the rule is complete and can occur.
+func (r Rule) Validate() error {
+	switch r.Frequency {
+	case Daily, Weekly, Monthly:
+		if r.Cron != "" {
+			return fmt.Errorf("cron is only allowed with frequency %s", Cron)
+		}
+		return nil
+	case Cron:
+		s, err := ParseCron(r.Cron)
+		if err != nil {
+			return err
+		}
+		if s.Next(r.Start.Add(-time.Minute)).IsZero() {
+			return ErrNoOccurrence
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown frequency %q", r.Frequency)
+	}
+}
+
+// Next returns the first occurrence after t, or the zero time if there is
+// none. Rules that do not validate never occur.
+func (r Rule) Next(t time.Time) time.Time {
+	start := r.Start.UTC()
+	t = t.UTC()
+	switch r.Frequency {
+	case Daily:
+		return nextPeriod(start, t, func(n int) time.Time { return start.AddDate(0, 0, n) }, 24*time.Hour)
+	case Weekly:
+		return nextPeriod(start, t, func(n int) time.Time { return start.AddDate(0, 0, 7*n) }, 7*24*time.Hour)
+	case Monthly:
+		return nextPeriod(start, t, func(n int) time.Time { return addMonths(start, n) }, 31*24*time.Hour)
+	case Cron:
+		s, err := ParseCron(r.Cron)
+		if err != nil {
+			return time.Time{}
+		}
+		if t.Before(start) {
+			// Let a match at start itself count
+			t = start.Add(-time.Nanosecond)
+		}
+		return s.Next(t)
+	}
+	return time.Time{}
+}
+
+// nextPeriod returns the first of start, at(1), at(2), ... after t. at(n)
+// is at least n periods of no more than period after start.
+func nextPeriod(start, t time.Time, at func(n int) time.Time, period time.Duration) time.Time {
+	if t.Before(start) {
+		return start
+	}
+	// Jump close to t, then step to the first occurrence after it
+	n := int(t.Sub(start) / period)
+	for n > 0 && at(n).After(t) {
+		n--
+	}
+	for !at(n).After(t) {
+		n++
+	}
+	return at(n)
+}
+
+// addMonths adds n months to t, keeping the day of the month where the
+// month has it and using the month's last day otherwise, so a rule
+// starting on the 31st falls on the 30th in April.
+func addMonths(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(t.Day(), lastDay)-1)
+}
@@ -0,0 +1,77 @@
+package recur
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestRuleNext(t *testing.T) {
+	tests := []struct {
+		rule        Rule
+		after, want string
+	}{
+		{Rule{Frequency: Daily, Start: date("2026-03-01 09:00")}, "2026-02-01 00:00", "2026-03-01 09:00"},
+		{Rule{Frequency: Daily, Start: date("2026-03-01 09:00")}, "2026-03-01 09:00", "2026-03-02 09:00"},
+		{Rule{Frequency: Daily, Start: date("2026-03-01 09:00")}, "2026-03-10 08:59", "2026-03-10 09:00"},
+		{Rule{Frequency: Weekly, Start: date("2026-03-02 09:00")}, "2026-03-20 12:00", "2026-03-23 09:00"},
+		{Rule{Frequency: Monthly, Start: date("2026-01-31 09:00")}, "2026-01-31 09:00", "2026-02-28 09:00"},
+		{Rule{Frequency: Monthly, Start: date("2026-01-31 09:00")}, "2026-02-28 09:00", "2026-03-31 09:00"},
+		{Rule{Frequency: Monthly, Start: date("2026-01-31 09:00")}, "2026-04-01 00:00", "2026-04-30 09:00"},
+		{Rule{Frequency: Monthly, Start: date("2024-01-15 09:00")}, "2026-10-19 00:00", "2026-11-15 09:00"},
+		{Rule{Frequency: Cron, Cron: "30 9 * * mon-fri", Start: date("2026-10-01 00:00")}, "2026-10-16 09:30", "2026-10-19 09:30"},
+		{Rule{Frequency: Cron, Cron: "*/15 * * * *", Start: date("2026-10-01 00:00")}, "2026-10-19 10:07", "2026-10-19 10:15"},
+		{Rule{Frequency: Cron, Cron: "0 0 1 * *", Start: date("2026-10-01 00:00")}, "2026-09-01 00:00", "2026-10-01 00:00"},
+		{Rule{Frequency: Cron, Cron: "0 12 13 * 5", Start: date("2026-01-01 00:00")}, "2026-10-13 12:00", "2026-10-16 12:00"},
+		{Rule{Frequency: Cron, Cron: "0 0 29 feb *", Start: date("2026-01-01 00:00")}, "2026-01-01 00:00", "2028-02-29 00:00"},
+		{Rule{Frequency: Cron, Cron: "0 8 * * 7", Start: date("2026-01-01 00:00")}, "2026-10-19 00:00", "2026-10-25 08:00"},
+	}
+	for _, tt := range tests {
+		got := tt.rule.Next(date(tt.after))
+		if !got.Equal(date(tt.want)) {
+			t.Errorf("%+v.Next(%s) = %s, want %s", tt.rule, tt.after, got, tt.want)
+		}
+	}
+}
+
+func TestRuleValidate(t *testing.T) {
+	start := date("2026-10-19 00:00")
+	for _, rule := range []Rule{
+		{Frequency: Daily},
+		{Frequency: Monthly, Start: start},
+		{Frequency: Cron, Cron: "0 9 * * 1,3,5", Start: start},
+		{Frequency: Cron, Cron: "5-55/10 */2 1-15 jan-jun sun", Start: start},
+	} {
+		if err := rule.Validate(); err != nil {
+			t.Errorf("%+v.Validate() = %v", rule, err)
+		}
+	}
+
+	for _, rule := range []Rule{
+		{Frequency: "yearly"},
+		{Frequency: Daily, Cron: "0 9 * * *"},
+		{Frequency: Cron, Start: start},
+		{Frequency: Cron, Cron: "0 9 * *", Start: start},
+		{Frequency: Cron, Cron: "60 9 * * *", Start: start},
+		{Frequency: Cron, Cron: "0 9-5 * * *", Start: start},
+		{Frequency: Cron, Cron: "*/0 9 * * *", Start: start},
+		{Frequency: Cron, Cron: "0 9 * foo *", Start: start},
+	} {
+		if err := rule.Validate(); err == nil {
+			t.Errorf("%+v.Validate() = nil, want an error", rule)
+		}
+	}
+
+	never := Rule{Frequency: Cron, Cron: "0 0 30 2 *", Start: start}
+	if err := never.Validate(); !errors.Is(err, ErrNoOccurrence) {
+		t.Errorf("%+v.Validate() = %v, want ErrNoOccurrence", never, err)
+	}
+}
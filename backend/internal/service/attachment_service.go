@@ -0,0 +1,272 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/keel/api/internal/storage"
+	"github.com/keel/api/internal/store"
+)
+
+// Attachment is a file attached to an item. UploaderID is empty when no
+// user was given or the user has since been deleted.
+type Attachment struct {
+	ID          string    `json:"id"`
+	ItemID      string    `json:"itemId"`
+	UploaderID  string    `json:"uploaderId"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// AttachmentLimits restricts uploads. MaxSize is in bytes; AllowedTypes
+// holds MIME types such as "image/png", or "image/*" for any image.
+type AttachmentLimits struct {
+	MaxSize      int64
+	AllowedTypes []string
+}
+
+// UploadAttachmentInput represents the input for uploading an attachment.
+type UploadAttachmentInput struct {
+	ItemID   string
+	Filename string
+	// ContentType is the type the client declared. If it is empty or
+	// application/octet-stream, the type is detected from the content.
+	ContentType string
+	Content     io.Reader
+	// UploaderID is the user uploading the file, if known.
+	UploaderID string
+}
+
+// Common errors
+var (
+	ErrAttachmentNotFound       = errors.New("attachment not found")
+	ErrAttachmentTooLarge       = errors.New("attachment is too large")
+	ErrAttachmentTypeNotAllowed = errors.New("attachment type not allowed")
+)
+
+// AttachmentService provides attachment-related business logic. Metadata
+// is kept in the database and file contents in files.
+type AttachmentService struct {
+	queries store.Store
+	db      *sql.DB
+	files   storage.Storage
+	limits  AttachmentLimits
+}
+
+// NewAttachmentService creates a new AttachmentService that keeps files in
+// files and enforces limits on uploads.
+func NewAttachmentService(db *sql.DB, queries store.Store, files storage.Storage, limits AttachmentLimits) *AttachmentService {
+	return &AttachmentService{
+		queries: queries,
+		db:      db,
+		files:   files,
+		limits:  limits,
+	}
+}
+
+// Limits returns the limits uploads must meet.
+func (s *AttachmentService) Limits() AttachmentLimits {
+	return s.limits
+}
+
+// Upload stores a file and attaches it to an item. Content is streamed to
+// storage; it fails with ErrAttachmentTooLarge as soon as it exceeds the
+// size limit, and with ErrAttachmentTypeNotAllowed if its type is not
+// allowed.
+func (s *AttachmentService) Upload(ctx context.Context, input UploadAttachmentInput) (*Attachment, error) {
+	if _, err := s.queries.GetItem(ctx, input.ItemID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrItemNotFound
+		}
+		return nil, err
+	}
+
+	content := bufio.NewReaderSize(input.Content, 512)
+	contentType := input.ContentType
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType == "" || mediaType == "application/octet-stream" {
+		head, _ := content.Peek(512)
+		contentType = http.DetectContentType(head)
+	}
+	if !s.typeAllowed(contentType) {
+		return nil, ErrAttachmentTypeNotAllowed
+	}
+
+	id := uuid.New().String()
+	key := "items/" + input.ItemID + "/" + id
+	counted := &sizeLimitReader{r: content, remaining: s.limits.MaxSize}
+	if err := s.files.Put(ctx, key, counted, -1, contentType); err != nil {
+		if errors.Is(err, ErrAttachmentTooLarge) {
+			return nil, ErrAttachmentTooLarge
+		}
+		return nil, err
+	}
+
+	dbAttachment, err := s.queries.CreateAttachment(ctx, store.CreateAttachmentParams{
+		ID:          id,
+		ItemID:      input.ItemID,
+		UploaderID:  sql.NullString{String: input.UploaderID, Valid: input.UploaderID != ""},
+		Filename:    input.Filename,
+		ContentType: contentType,
+		SizeBytes:   counted.read,
+		StorageKey:  key,
+		CreatedAt:   time.Now().UTC(),
+	})
+	if err != nil {
+		_ = s.files.Delete(ctx, key)
+		return nil, err
+	}
+
+	return toAttachment(dbAttachment), nil
+}
+
+// Get retrieves an attachment of an item.
+func (s *AttachmentService) Get(ctx context.Context, itemID, id string) (*Attachment, error) {
+	dbAttachment, err := s.get(ctx, itemID, id)
+	if err != nil {
+		return nil, err
+	}
+	return toAttachment(dbAttachment), nil
+}
+
+// List retrieves an item's attachments, oldest first.
+func (s *AttachmentService) List(ctx context.Context, itemID string) ([]Attachment, error) {
+	if _, err := s.queries.GetItem(ctx, itemID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrItemNotFound
+		}
+		return nil, err
+	}
+
+	attachments, err := s.queries.ListAttachments(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Attachment, len(attachments))
+	for i, a := range attachments {
+		result[i] = *toAttachment(a)
+	}
+	return result, nil
+}
+
+// Open returns an attachment and its content. The caller closes the
+// content.
+func (s *AttachmentService) Open(ctx context.Context, itemID, id string) (*Attachment, io.ReadSeekCloser, error) {
+	dbAttachment, err := s.get(ctx, itemID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.files.Open(ctx, dbAttachment.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return toAttachment(dbAttachment), content, nil
+}
+
+// Delete removes an attachment and its file.
+func (s *AttachmentService) Delete(ctx context.Context, itemID, id string) error {
+	dbAttachment, err := s.get(ctx, itemID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.queries.DeleteAttachment(ctx, id); err != nil {
+		return err
+	}
+	return s.files.Delete(ctx, dbAttachment.StorageKey)
+}
+
+// get returns an attachment, or ErrItemNotFound or ErrAttachmentNotFound.
+func (s *AttachmentService) get(ctx context.Context, itemID, id string) (store.Attachment, error) {
+	if _, err := s.queries.GetItem(ctx, itemID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return store.Attachment{}, ErrItemNotFound
+		}
+		return store.Attachment{}, err
+	}
+
+	dbAttachment, err := s.queries.GetAttachment(ctx, store.GetAttachmentParams{ID: id, ItemID: itemID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return store.Attachment{}, ErrAttachmentNotFound
+		}
+		return store.Attachment{}, err
+	}
+	return dbAttachment, nil
+}
+
+// typeAllowed reports whether contentType matches one of the allowed
+// types.
+func (s *AttachmentService) typeAllowed(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range s.limits.AllowedTypes {
+		allowed = strings.ToLower(allowed)
+		if allowed == mediaType || allowed == "*/*" {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(allowed, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// sizeLimitReader reads from r until more than remaining bytes have been
+// read, then fails with ErrAttachmentTooLarge.
+type sizeLimitReader struct {
+	r         io.Reader
+	remaining int64
+	read      int64
+}
+
+func (l *sizeLimitReader) Read(p []byte) (int, error) {
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n, ErrAttachmentTooLarge
+	}
+	return n, err
+}
+
+// deleteFiles removes the files of attachments whose rows are already
+// gone. A file that cannot be removed is only logged: the deletion that
+// orphaned it has succeeded.
+func deleteFiles(ctx context.Context, files storage.Storage, attachments []store.Attachment) {
+	for _, a := range attachments {
+		if err := files.Delete(ctx, a.StorageKey); err != nil {
+			slog.Warn("failed to delete attachment file", "error", err, "key", a.StorageKey)
+		}
+	}
+}
+
+// toAttachment converts a database attachment to a service attachment.
+func toAttachment(dbAttachment store.Attachment) *Attachment {
+	return &Attachment{
+		ID:          dbAttachment.ID,
+		ItemID:      dbAttachment.ItemID,
+		UploaderID:  dbAttachment.UploaderID.String,
+		Filename:    dbAttachment.Filename,
+		ContentType: dbAttachment.ContentType,
+		Size:        dbAttachment.SizeBytes,
+		CreatedAt:   dbAttachment.CreatedAt,
+	}
+}
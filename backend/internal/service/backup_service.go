@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/keel/api/internal/backup"
+)
+
+// Common errors
+var (
+	ErrBackupUnsupported = errors.New("backups are only supported for file-based SQLite databases")
+)
+
+// BackupService takes snapshots of the database.
+type BackupService struct {
+	dbPath   string
+	dir      string
+	compress bool
+}
+
+// NewBackupService creates a new BackupService. An empty dbPath means the
+// database cannot be backed up (e.g. Postgres or in-memory SQLite).
+func NewBackupService(dbPath, dir string, compress bool) *BackupService {
+	return &BackupService{
+		dbPath:   dbPath,
+		dir:      dir,
+		compress: compress,
+	}
+}
+
+// Create takes a verified snapshot of the live database.
+func (s *BackupService) Create(ctx context.Context) (*backup.Result, error) {
+	if s.dbPath == "" {
+		return nil, ErrBackupUnsupported
+	}
+	return backup.Snapshot(ctx, s.dbPath, s.dir, backup.Options{Compress: s.compress})
+}
@@ -0,0 +1,308 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/keel/api/internal/store"
+)
+
+// ChecklistEntry is an entry in an item's checklist. Positions run from 0
+// in checklist order.
+type ChecklistEntry struct {
+	ID        string    `json:"id"`
+	ItemID    string    `json:"itemId"`
+	Text      string    `json:"text"`
+	Done      bool      `json:"done"`
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// CreateChecklistEntryInput represents the input for adding a checklist
+// entry.
+type CreateChecklistEntryInput struct {
+	Text string
+	Done bool
+	// Position, if set, is where the entry goes; entries from there on move
+	// down one. It defaults to the end of the checklist.
+	Position *int
+}
+
+// UpdateChecklistEntryInput represents the input for changing a checklist
+// entry. Nil fields are left unchanged.
+type UpdateChecklistEntryInput struct {
+	Text *string
+	Done *bool
+	// Position moves the entry, shifting the entries in between.
+	Position *int
+}
+
+// Common errors
+var (
+	ErrChecklistEntryNotFound = errors.New("checklist entry not found")
+)
+
+// ChecklistService provides the business logic of item checklists.
+type ChecklistService struct {
+	queries store.Store
+	db      *sql.DB
+	rules   ItemRules
+}
+
+// NewChecklistService creates a new ChecklistService. With
+// rules.RequireChecklistDone set, a completed item's checklist must stay
+// done.
+func NewChecklistService(db *sql.DB, queries store.Store, rules ItemRules) *ChecklistService {
+	return &ChecklistService{
+		queries: queries,
+		db:      db,
+		rules:   rules,
+	}
+}
+
+// List returns an item's checklist in order.
+func (s *ChecklistService) List(ctx context.Context, itemID string) ([]ChecklistEntry, error) {
+	if err := s.checkItem(ctx, s.queries, itemID); err != nil {
+		return nil, err
+	}
+
+	dbEntries, err := s.queries.ListItemChecklistEntries(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]ChecklistEntry, len(dbEntries))
+	for i, e := range dbEntries {
+		entries[i] = *toChecklistEntry(e)
+	}
+	return entries, nil
+}
+
+// Create adds an entry to an item's checklist. Adding an open entry to a
+// completed item fails with a *ChecklistIncompleteError if the rules
+// require its checklist done.
+func (s *ChecklistService) Create(ctx context.Context, itemID string, input CreateChecklistEntryInput) (*ChecklistEntry, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+	q := s.queries.InTx(tx)
+
+	if err := s.checkItem(ctx, q, itemID); err != nil {
+		return nil, err
+	}
+	entries, err := q.ListItemChecklistEntries(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	dbEntry, err := insertChecklistEntry(ctx, q, itemID, input.Text, input.Done, len(entries))
+	if err != nil {
+		return nil, err
+	}
+	if input.Position != nil && *input.Position < len(entries) {
+		if dbEntry, err = moveChecklistEntry(ctx, q, dbEntry, *input.Position); err != nil {
+			return nil, err
+		}
+	}
+	if !input.Done {
+		if err := s.checkCompleted(ctx, q, itemID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return toChecklistEntry(dbEntry), nil
+}
+
+// Update changes the text of a checklist entry, ticks it off or clears it,
+// or moves it within the checklist. Clearing an entry of a completed item
+// fails with a *ChecklistIncompleteError if the rules require its
+// checklist done.
+func (s *ChecklistService) Update(ctx context.Context, itemID, id string, input UpdateChecklistEntryInput) (*ChecklistEntry, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+	q := s.queries.InTx(tx)
+
+	existing, err := s.get(ctx, q, itemID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	params := store.UpdateItemChecklistEntryParams{
+		Text:      existing.Text,
+		Done:      existing.Done,
+		UpdatedAt: time.Now().UTC(),
+		ID:        id,
+	}
+	if input.Text != nil {
+		params.Text = *input.Text
+	}
+	if input.Done != nil {
+		params.Done = *input.Done
+	}
+
+	dbEntry, err := q.UpdateItemChecklistEntry(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if input.Position != nil {
+		if dbEntry, err = moveChecklistEntry(ctx, q, dbEntry, *input.Position); err != nil {
+			return nil, err
+		}
+	}
+	if existing.Done && !dbEntry.Done {
+		if err := s.checkCompleted(ctx, q, itemID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return toChecklistEntry(dbEntry), nil
+}
+
+// Delete removes an entry from an item's checklist, closing the gap it
+// leaves.
+func (s *ChecklistService) Delete(ctx context.Context, itemID, id string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+	q := s.queries.InTx(tx)
+
+	if _, err := s.get(ctx, q, itemID, id); err != nil {
+		return err
+	}
+	if err := q.DeleteItemChecklistEntry(ctx, id); err != nil {
+		return err
+	}
+	entries, err := q.ListItemChecklistEntries(ctx, itemID)
+	if err != nil {
+		return err
+	}
+	if err := renumberChecklist(ctx, q, entries); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// get returns an entry of an item's checklist.
+func (s *ChecklistService) get(ctx context.Context, q store.Store, itemID, id string) (store.ItemChecklistEntry, error) {
+	if err := s.checkItem(ctx, q, itemID); err != nil {
+		return store.ItemChecklistEntry{}, err
+	}
+	dbEntry, err := q.GetItemChecklistEntry(ctx, store.GetItemChecklistEntryParams{ID: id, ItemID: itemID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return store.ItemChecklistEntry{}, ErrChecklistEntryNotFound
+		}
+		return store.ItemChecklistEntry{}, err
+	}
+	return dbEntry, nil
+}
+
+// checkItem returns ErrItemNotFound if the item does not exist.
+func (s *ChecklistService) checkItem(ctx context.Context, q store.Store, itemID string) error {
+	if _, err := q.GetItem(ctx, itemID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrItemNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// checkCompleted returns a *ChecklistIncompleteError if the rules require
+// a completed item's checklist done and the item's is not.
+func (s *ChecklistService) checkCompleted(ctx context.Context, q store.Store, itemID string) error {
+	item, err := q.GetItem(ctx, itemID)
+	if err != nil {
+		return err
+	}
+	if item.Status != "completed" {
+		return nil
+	}
+	return checkChecklist(ctx, q, s.rules, itemID)
+}
+
+// insertChecklistEntry writes a new checklist entry at position, which
+// must not be taken.
+func insertChecklistEntry(ctx context.Context, q store.Store, itemID, text string, done bool, position int) (store.ItemChecklistEntry, error) {
+	now := time.Now().UTC()
+	return q.CreateItemChecklistEntry(ctx, store.CreateItemChecklistEntryParams{
+		ID:        uuid.New().String(),
+		ItemID:    itemID,
+		Text:      text,
+		Done:      done,
+		Position:  int64(position),
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+}
+
+// moveChecklistEntry moves an entry to position in its checklist, or to
+// the end if position is past it, and returns the moved entry.
+func moveChecklistEntry(ctx context.Context, q store.Store, entry store.ItemChecklistEntry, position int) (store.ItemChecklistEntry, error) {
+	entries, err := q.ListItemChecklistEntries(ctx, entry.ItemID)
+	if err != nil {
+		return store.ItemChecklistEntry{}, err
+	}
+	others := make([]store.ItemChecklistEntry, 0, len(entries))
+	for _, e := range entries {
+		if e.ID != entry.ID {
+			others = append(others, e)
+		}
+	}
+	position = min(max(position, 0), len(others))
+	entries = append(others[:position:position], entry)
+	entries = append(entries, others[position:]...)
+
+	if err := renumberChecklist(ctx, q, entries); err != nil {
+		return store.ItemChecklistEntry{}, err
+	}
+	entry.Position = int64(position)
+	return entry, nil
+}
+
+// renumberChecklist gives entries the positions 0, 1, 2... in the order
+// given, writing only the entries whose position changes.
+func renumberChecklist(ctx context.Context, q store.Store, entries []store.ItemChecklistEntry) error {
+	for i, e := range entries {
+		if e.Position == int64(i) {
+			continue
+		}
+		if err := q.SetItemChecklistEntryPosition(ctx, store.SetItemChecklistEntryPositionParams{
+			Position: int64(i),
+			ID:       e.ID,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toChecklistEntry converts a database checklist entry to a service
+// checklist entry.
+func toChecklistEntry(dbEntry store.ItemChecklistEntry) *ChecklistEntry {
+	return &ChecklistEntry{
+		ID:        dbEntry.ID,
+		ItemID:    dbEntry.ItemID,
+		Text:      dbEntry.Text,
+		Done:      dbEntry.Done,
+		Position:  int(dbEntry.Position),
+		CreatedAt: dbEntry.CreatedAt,
+		UpdatedAt: dbEntry.UpdatedAt,
+	}
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/keel/api/internal/store"
+)
+
+// Comment represents a comment on an item. AuthorID is empty once the
+// author has been deleted.
+type Comment struct {
+	ID        string    `json:"id"`
+	ItemID    string    `json:"itemId"`
+	AuthorID  string    `json:"authorId"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// CommentListResult represents a paginated list of comments.
+type CommentListResult struct {
+	Data       []Comment
+	Page       int
+	Limit      int
+	Total      int64
+	TotalPages int
+}
+
+// Actor is the user a change is made on behalf of.
+type Actor struct {
+	ID   string
+	Role string
+}
+
+// Common errors
+var (
+	ErrCommentNotFound  = errors.New("comment not found")
+	ErrCommentForbidden = errors.New("only the author or an admin can change this comment")
+)
+
+// CommentService provides comment-related business logic.
+type CommentService struct {
+	queries store.Store
+	db      *sql.DB
+}
+
+// NewCommentService creates a new CommentService.
+func NewCommentService(db *sql.DB, queries store.Store) *CommentService {
+	return &CommentService{
+		queries: queries,
+		db:      db,
+	}
+}
+
+// Create adds a comment by authorID to an item.
+func (s *CommentService) Create(ctx context.Context, itemID, authorID, body string) (*Comment, error) {
+	if err := s.checkItem(ctx, itemID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	dbComment, err := s.queries.CreateItemComment(ctx, store.CreateItemCommentParams{
+		ID:        uuid.New().String(),
+		ItemID:    itemID,
+		AuthorID:  sql.NullString{String: authorID, Valid: authorID != ""},
+		Body:      body,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toComment(dbComment), nil
+}
+
+// Get retrieves a comment on an item.
+func (s *CommentService) Get(ctx context.Context, itemID, id string) (*Comment, error) {
+	if err := s.checkItem(ctx, itemID); err != nil {
+		return nil, err
+	}
+
+	dbComment, err := s.queries.GetItemComment(ctx, store.GetItemCommentParams{ID: id, ItemID: itemID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+
+	return toComment(dbComment), nil
+}
+
+// List retrieves a paginated list of an item's comments, oldest first.
+func (s *CommentService) List(ctx context.Context, itemID string, page, limit int) (*CommentListResult, error) {
+	if err := s.checkItem(ctx, itemID); err != nil {
+		return nil, err
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	offset := (page - 1) * limit
+
+	comments, err := s.queries.ListItemComments(ctx, store.ListItemCommentsParams{
+		ItemID: itemID,
+		Limit:  int64(limit),
+		Offset: int64(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.queries.CountItemComments(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	result := &CommentListResult{
+		Data:       make([]Comment, len(comments)),
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+
+	for i, c := range comments {
+		result.Data[i] = *toComment(c)
+	}
+
+	return result, nil
+}
+
+// Update replaces the body of a comment. Only the author or an admin may
+// edit a comment.
+func (s *CommentService) Update(ctx context.Context, itemID, id string, actor Actor, body string) (*Comment, error) {
+	if _, err := s.editable(ctx, itemID, id, actor); err != nil {
+		return nil, err
+	}
+
+	dbComment, err := s.queries.UpdateItemComment(ctx, store.UpdateItemCommentParams{
+		Body:      body,
+		UpdatedAt: time.Now().UTC(),
+		ID:        id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toComment(dbComment), nil
+}
+
+// Delete deletes a comment. Only the author or an admin may delete a
+// comment.
+func (s *CommentService) Delete(ctx context.Context, itemID, id string, actor Actor) error {
+	if _, err := s.editable(ctx, itemID, id, actor); err != nil {
+		return err
+	}
+
+	return s.queries.DeleteItemComment(ctx, id)
+}
+
+// editable returns the comment if actor may change it.
+func (s *CommentService) editable(ctx context.Context, itemID, id string, actor Actor) (*Comment, error) {
+	comment, err := s.Get(ctx, itemID, id)
+	if err != nil {
+		return nil, err
+	}
+	if actor.Role != "admin" && (comment.AuthorID == "" || comment.AuthorID != actor.ID) {
+		return nil, ErrCommentForbidden
+	}
+	return comment, nil
+}
+
+// checkItem returns ErrItemNotFound if the item does not exist.
+func (s *CommentService) checkItem(ctx context.Context, itemID string) error {
+	if _, err := s.queries.GetItem(ctx, itemID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrItemNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// toComment converts a database comment to a service comment.
+func toComment(dbComment store.ItemComment) *Comment {
+	return &Comment{
+		ID:        dbComment.ID,
+		ItemID:    dbComment.ItemID,
+		AuthorID:  dbComment.AuthorID.String,
+		Body:      dbComment.Body,
+		CreatedAt: dbComment.CreatedAt,
+		UpdatedAt: dbComment.UpdatedAt,
+	}
+}
@@ -4,21 +4,71 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/keel/api/internal/rank"
+	"github.com/keel/api/internal/recur"
+	"github.com/keel/api/internal/storage"
 	"github.com/keel/api/internal/store"
 )
 
 // Item represents an item in the system.
 type Item struct {
-	ID          string    `json:"id"`
-	UserID      string    `json:"userId"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Status      string    `json:"status"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	ID          string `json:"id"`
+	UserID      string `json:"userId"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	// DueAt is when the item is due, or nil if it has no due date.
+	DueAt    *time.Time `json:"dueAt"`
+	Priority string     `json:"priority"`
+	// AssigneeID is the user the item is assigned to, or empty.
+	AssigneeID string `json:"assigneeId"`
+	// ParentID is the item this is a sub-item of, or empty.
+	ParentID string `json:"parentId"`
+	// Progress counts the item's descendants and how many are completed.
+	Progress ItemProgress `json:"progress"`
+	// Checklist counts the item's checklist entries and how many are done.
+	Checklist ItemProgress `json:"checklist"`
+	// Rank orders items arranged by hand; see the rank package.
+	Rank string `json:"rank"`
+	// Recurrence is the rule the item recurs by, or nil.
+	Recurrence *ItemRecurrence `json:"recurrence"`
+	Tags       []string        `json:"tags"`
+	// CommentCount is the number of comments on the item.
+	CommentCount int64     `json:"commentCount"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// ItemProgress is the rolled-up progress of an item's sub-items, their
+// sub-items and so on.
+type ItemProgress struct {
+	Total     int64 `json:"total"`
+	Completed int64 `json:"completed"`
+}
+
+// ItemDependencies lists the items an item is blocked by and the items it
+// blocks.
+type ItemDependencies struct {
+	BlockedBy []Item
+	Blocks    []Item
+}
+
+// ItemStatusChange is an entry in an item's status history. FromStatus is
+// empty for the status the item was created with, and ActorID is empty when
+// no user was given or the user has since been deleted.
+type ItemStatusChange struct {
+	ID         string
+	ItemID     string
+	FromStatus string
+	ToStatus   string
+	ActorID    string
+	CreatedAt  time.Time
 }
 
 // CreateItemInput represents the input for creating an item.
@@ -27,6 +77,38 @@ type CreateItemInput struct {
 	Title       string
 	Description string
 	Status      string
+	DueAt       *time.Time
+	// Priority defaults to normal.
+	Priority   string
+	AssigneeID string
+	// ParentID makes the item a sub-item of another.
+	ParentID string
+	// Tags names the item's tags; tags that do not exist yet are created.
+	Tags []string
+	// Checklist is the text of the item's checklist entries, none of them
+	// done.
+	Checklist []string
+	// ActorID is the user making the change, if known.
+	ActorID string
+}
+
+// CreateItemFromTemplateInput represents the input for creating an item
+// from a template. Nil fields take the template's value; the rest are not
+// part of a template and work as in CreateItemInput.
+type CreateItemFromTemplateInput struct {
+	UserID      string
+	Title       *string
+	Description *string
+	Status      *string
+	DueAt       *time.Time
+	Priority    string
+	AssigneeID  string
+	ParentID    string
+	// Tags, if set, replaces the template's tags.
+	Tags *[]string
+	// ActorID is the user making the change, recorded in the item's
+	// status history; it may be empty.
+	ActorID string
 }
 
 // UpdateItemInput represents the input for updating an item.
@@ -34,6 +116,70 @@ type UpdateItemInput struct {
 	Title       *string
 	Description *string
 	Status      *string
+	// DueAt, if set, replaces the due date; the zero time clears it.
+	DueAt    *time.Time
+	Priority *string
+	// AssigneeID, if set, replaces the assignee; "" unassigns the item.
+	AssigneeID *string
+	// ParentID, if set, moves the item under another; "" makes it a
+	// top-level item.
+	ParentID *string
+	// Tags, if set, replaces the item's tags; tags that do not exist yet
+	// are created.
+	Tags *[]string
+	// ActorID is the user making the change, if known.
+	ActorID string
+}
+
+// ItemRecurrence is the rule an item recurs by. It belongs to the latest
+// occurrence: when that is completed, or at NextAt if it is still open, a
+// copy due at NextAt is created and the rule moves to it.
+type ItemRecurrence struct {
+	ItemID string
+	// Frequency is one of recur.Frequencies; Cron is set for recur.Cron.
+	Frequency string
+	Cron      string
+	StartsAt  time.Time
+	NextAt    time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// SetRecurrenceInput represents the input for making an item recur.
+type SetRecurrenceInput struct {
+	Frequency string
+	// Cron is ignored unless Frequency is recur.Cron.
+	Cron string
+	// StartsAt anchors daily, weekly and monthly rules and is the earliest
+	// time a cron rule matches. It defaults to the item's due date, or now.
+	StartsAt *time.Time
+}
+
+// MoveItemInput says where Move puts an item: just before or just after
+// another item. Exactly one must be set.
+type MoveItemInput struct {
+	Before string
+	After  string
+}
+
+// ItemListFilter selects the items List returns and their order. Empty
+// fields do not filter.
+type ItemListFilter struct {
+	UserID string
+	// Tags restricts the list to items with any of the named tags, or with
+	// all of them if AllTags is set.
+	Tags       []string
+	AllTags    bool
+	AssigneeID string
+	// Priorities restricts the list to items with any of the priorities.
+	Priorities []string
+	// DueBefore restricts the list to items due before it.
+	DueBefore time.Time
+	// Overdue restricts the list to items past their due date that are not
+	// completed.
+	Overdue bool
+	// Order is one of ItemOrderValues; empty lists the newest items first.
+	Order string
 }
 
 // ItemListResult represents a paginated list of items.
@@ -45,46 +191,300 @@ type ItemListResult struct {
 	TotalPages int
 }
 
+// ItemStatusValues lists the allowed values of Item.Status.
+var ItemStatusValues = []string{"pending", "in_progress", "completed"}
+
+// ItemPriorityValues lists the allowed values of Item.Priority, from least
+// to most urgent.
+var ItemPriorityValues = []string{"low", "normal", "high", "urgent"}
+
+// ItemRecurrenceFrequencyValues lists the allowed values of
+// ItemRecurrence.Frequency.
+var ItemRecurrenceFrequencyValues = recur.Frequencies
+
+// ItemOrderValues lists the orders List supports: newest first, soonest
+// due first, most urgent first, and as arranged with Move.
+var ItemOrderValues = []string{store.OrderCreated, store.OrderDue, store.OrderPriority, store.OrderRank}
+
+// ItemRules are the conditions an item's status changes must meet.
+type ItemRules struct {
+	// Workflow limits the statuses an item may move to.
+	Workflow ItemWorkflow
+	// RequireChecklistDone stops an item being completed while any of its
+	// checklist entries is not done, and a completed item's checklist
+	// gaining an open entry.
+	RequireChecklistDone bool
+}
+
+// ItemWorkflow maps each item status to the statuses an item may move to
+// from it.
+type ItemWorkflow map[string][]string
+
+// Allows reports whether an item may move from one status to another.
+// Keeping the current status is always allowed, and a nil workflow allows
+// every change.
+func (w ItemWorkflow) Allows(from, to string) bool {
+	return w == nil || from == to || slices.Contains(w[from], to)
+}
+
 // Common errors
 var (
-	ErrItemNotFound = errors.New("item not found")
+	ErrItemNotFound            = errors.New("item not found")
+	ErrAssigneeNotFound        = errors.New("assignee not found")
+	ErrParentNotFound          = errors.New("parent item not found")
+	ErrBlockerNotFound         = errors.New("blocking item not found")
+	ErrItemCycle               = errors.New("item would be its own ancestor or blocker")
+	ErrItemBlocked             = errors.New("item is blocked by open items")
+	ErrChecklistIncomplete     = errors.New("item checklist is not done")
+	ErrMoveTargetNotFound      = errors.New("move target not found")
+	ErrInvalidRecurrence       = errors.New("invalid recurrence")
+	ErrInvalidStatusTransition = errors.New("status transition not allowed")
 )
 
+// StatusTransitionError is returned when the workflow does not allow a
+// status change. It matches ErrInvalidStatusTransition.
+type StatusTransitionError struct {
+	From    string
+	To      string
+	Allowed []string
+}
+
+func (e *StatusTransitionError) Error() string {
+	return fmt.Sprintf("cannot change item status from %s to %s", e.From, e.To)
+}
+
+func (e *StatusTransitionError) Unwrap() error {
+	return ErrInvalidStatusTransition
+}
+
+// BlockedError is returned when an item cannot be completed because items
+// blocking it are still open. It matches ErrItemBlocked.
+type BlockedError struct {
+	// Blockers are the IDs of the open blocking items.
+	Blockers []string
+}
+
+func (e *BlockedError) Error() string {
+	return fmt.Sprintf("item is blocked by %d open items", len(e.Blockers))
+}
+
+func (e *BlockedError) Unwrap() error {
+	return ErrItemBlocked
+}
+
+// ChecklistIncompleteError is returned when an item cannot be completed
+// because ItemRules.RequireChecklistDone is set and its checklist is not
+// done. It matches ErrChecklistIncomplete.
+type ChecklistIncompleteError struct {
+	// Remaining is the number of entries not done.
+	Remaining int64
+}
+
+func (e *ChecklistIncompleteError) Error() string {
+	return fmt.Sprintf("item has %d checklist entries not done", e.Remaining)
+}
+
+func (e *ChecklistIncompleteError) Unwrap() error {
+	return ErrChecklistIncomplete
+}
+
+// RecurrenceError is returned when a recurrence rule is incomplete or can
+// never occur. It matches ErrInvalidRecurrence.
+type RecurrenceError struct {
+	// Reason describes the problem with the rule.
+	Reason error
+}
+
+func (e *RecurrenceError) Error() string {
+	return "invalid recurrence: " + e.Reason.Error()
+}
+
+func (e *RecurrenceError) Unwrap() error {
+	return ErrInvalidRecurrence
+}
+
 // ItemService provides item-related business logic.
 type ItemService struct {
-	queries *store.Queries
+	queries store.Store
 	db      *sql.DB
+	rules   ItemRules
+	files   storage.Storage
 }
 
-// NewItemService creates a new ItemService.
-func NewItemService(db *sql.DB, queries *store.Queries) *ItemService {
+// NewItemService creates a new ItemService whose status changes follow
+// rules. files holds the items' attachments, which are removed with them.
+func NewItemService(db *sql.DB, queries store.Store, rules ItemRules, files storage.Storage) *ItemService {
 	return &ItemService{
 		queries: queries,
 		db:      db,
+		rules:   rules,
+		files:   files,
 	}
 }
 
 // Create creates a new item.
 func (s *ItemService) Create(ctx context.Context, input CreateItemInput) (*Item, error) {
+	id := uuid.New().String()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+	q := s.queries.InTx(tx)
+
+	if err := checkAssignee(ctx, q, input.AssigneeID); err != nil {
+		return nil, err
+	}
+	if err := checkParent(ctx, q, id, input.ParentID); err != nil {
+		return nil, err
+	}
+	dbItem, err := insertItem(ctx, q, id, input)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := withDetails(ctx, q, dbItem)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// CreateFromTemplate creates an item from a template, with the template's
+// title, description, status and tags unless the input overrides them,
+// and a checklist copied from the template's. An item created completed
+// fails with a *ChecklistIncompleteError if the rules require its
+// checklist done.
+func (s *ItemService) CreateFromTemplate(ctx context.Context, templateID string, input CreateItemFromTemplateInput) (*Item, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+	q := s.queries.InTx(tx)
+
+	dbItemTemplate, err := q.GetItemTemplate(ctx, templateID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrItemTemplateNotFound
+		}
+		return nil, err
+	}
+	itemTemplate, err := withTemplateContents(ctx, q, dbItemTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	itemInput := CreateItemInput{
+		UserID:     input.UserID,
+		Title:      itemTemplate.Title,
+		Status:     itemTemplate.Status,
+		DueAt:      input.DueAt,
+		Priority:   input.Priority,
+		AssigneeID: input.AssigneeID,
+		ParentID:   input.ParentID,
+		Tags:       itemTemplate.Tags,
+		Checklist:  itemTemplate.Checklist,
+		ActorID:    input.ActorID,
+	}
+	if itemTemplate.Description != nil {
+		itemInput.Description = *itemTemplate.Description
+	}
+	if input.Title != nil {
+		itemInput.Title = *input.Title
+	}
+	if input.Description != nil {
+		itemInput.Description = *input.Description
+	}
+	if input.Status != nil {
+		itemInput.Status = *input.Status
+	}
+	if input.Tags != nil {
+		itemInput.Tags = *input.Tags
+	}
+
+	itemID := uuid.New().String()
+	if err := checkAssignee(ctx, q, itemInput.AssigneeID); err != nil {
+		return nil, err
+	}
+	if err := checkParent(ctx, q, itemID, itemInput.ParentID); err != nil {
+		return nil, err
+	}
+	dbItem, err := insertItem(ctx, q, itemID, itemInput)
+	if err != nil {
+		return nil, err
+	}
+	if itemInput.Status == "completed" {
+		if err := checkChecklist(ctx, q, s.rules, itemID); err != nil {
+			return nil, err
+		}
+	}
+
+	item, err := withDetails(ctx, q, dbItem)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// insertItem writes a new item with its tags, checklist and initial
+// status, at the end of the manual order. The assignee and parent must
+// already have been checked.
+func insertItem(ctx context.Context, q store.Store, id string, input CreateItemInput) (store.Item, error) {
 	status := input.Status
 	if status == "" {
 		status = "pending"
 	}
+	priority := input.Priority
+	if priority == "" {
+		priority = "normal"
+	}
+	dueAt := sql.NullTime{}
+	if input.DueAt != nil {
+		dueAt = sql.NullTime{Time: input.DueAt.UTC(), Valid: true}
+	}
+	itemRank, err := lastRank(ctx, q)
+	if err != nil {
+		return store.Item{}, err
+	}
+	if itemRank, err = rank.Between(itemRank, ""); err != nil {
+		return store.Item{}, err
+	}
 
-	id := uuid.New().String()
-
-	dbItem, err := s.queries.CreateItem(ctx, store.CreateItemParams{
+	dbItem, err := q.CreateItem(ctx, store.CreateItemParams{
 		ID:          id,
 		UserID:      input.UserID,
 		Title:       input.Title,
 		Description: sql.NullString{String: input.Description, Valid: input.Description != ""},
 		Status:      status,
+		DueAt:       dueAt,
+		Priority:    priority,
+		AssigneeID:  sql.NullString{String: input.AssigneeID, Valid: input.AssigneeID != ""},
+		ParentID:    sql.NullString{String: input.ParentID, Valid: input.ParentID != ""},
+		Rank:        itemRank,
 	})
 	if err != nil {
-		return nil, err
+		return store.Item{}, err
 	}
-
-	return toItem(dbItem), nil
+	if err := recordStatusChange(ctx, q, id, "", status, input.ActorID); err != nil {
+		return store.Item{}, err
+	}
+	if err := setItemTags(ctx, q, id, input.Tags); err != nil {
+		return store.Item{}, err
+	}
+	for i, text := range input.Checklist {
+		if _, err := insertChecklistEntry(ctx, q, id, text, false, i); err != nil {
+			return store.Item{}, err
+		}
+	}
+	return dbItem, nil
 }
 
 // Get retrieves an item by ID.
@@ -97,11 +497,11 @@ func (s *ItemService) Get(ctx context.Context, id string) (*Item, error) {
 		return nil, err
 	}
 
-	return toItem(dbItem), nil
+	return withDetails(ctx, s.queries, dbItem)
 }
 
-// List retrieves a paginated list of items, optionally filtered by user.
-func (s *ItemService) List(ctx context.Context, userID string, page, limit int) (*ItemListResult, error) {
+// List retrieves a paginated list of items matching filter.
+func (s *ItemService) List(ctx context.Context, filter ItemListFilter, page, limit int) (*ItemListResult, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -114,30 +514,36 @@ func (s *ItemService) List(ctx context.Context, userID string, page, limit int)
 
 	offset := (page - 1) * limit
 
-	var items []store.Item
-	var total int64
-	var err error
-
-	if userID != "" {
-		items, err = s.queries.ListItemsByUser(ctx, store.ListItemsByUserParams{
-			UserID: userID,
-			Limit:  int64(limit),
-			Offset: int64(offset),
-		})
-		if err != nil {
-			return nil, err
-		}
-		total, err = s.queries.CountItemsByUser(ctx, userID)
-	} else {
-		items, err = s.queries.ListItems(ctx, store.ListItemsParams{
-			Limit:  int64(limit),
-			Offset: int64(offset),
-		})
-		if err != nil {
-			return nil, err
+	storeFilter := store.ItemFilter{
+		UserID:     filter.UserID,
+		Tags:       filter.Tags,
+		AllTags:    filter.AllTags,
+		AssigneeID: filter.AssigneeID,
+		Priorities: filter.Priorities,
+		DueBefore:  filter.DueBefore,
+	}
+	if filter.Overdue {
+		now := time.Now()
+		if storeFilter.DueBefore.IsZero() || now.Before(storeFilter.DueBefore) {
+			storeFilter.DueBefore = now
 		}
-		total, err = s.queries.CountItems(ctx)
+		storeFilter.Open = true
+	}
+	items, err := s.queries.ListFilteredItems(ctx, store.ListFilteredItemsParams{
+		Filter: storeFilter,
+		Order:  filter.Order,
+		Limit:  int64(limit),
+		Offset: int64(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.queries.CountFilteredItems(ctx, storeFilter)
+	if err != nil {
+		return nil, err
 	}
+
+	data, err := withDetailsList(ctx, s.queries, items)
 	if err != nil {
 		return nil, err
 	}
@@ -147,24 +553,30 @@ func (s *ItemService) List(ctx context.Context, userID string, page, limit int)
 		totalPages++
 	}
 
-	result := &ItemListResult{
-		Data:       make([]Item, len(items)),
+	return &ItemListResult{
+		Data:       data,
 		Page:       page,
 		Limit:      limit,
 		Total:      total,
 		TotalPages: totalPages,
-	}
-
-	for i, item := range items {
-		result.Data[i] = *toItem(item)
-	}
-
-	return result, nil
+	}, nil
 }
 
-// Update updates an item.
+// Update updates an item. A status change the workflow does not allow
+// fails with a *StatusTransitionError, completing an item with open
+// blockers with a *BlockedError, and completing one whose checklist the
+// rules require done with a *ChecklistIncompleteError; allowed changes are
+// recorded in the item's history. Completing a recurring item creates its
+// next occurrence.
 func (s *ItemService) Update(ctx context.Context, id string, input UpdateItemInput) (*Item, error) {
-	existing, err := s.queries.GetItem(ctx, id)
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+	q := s.queries.InTx(tx)
+
+	existing, err := q.GetItem(ctx, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrItemNotFound
@@ -177,6 +589,9 @@ func (s *ItemService) Update(ctx context.Context, id string, input UpdateItemInp
 		Title:       existing.Title,
 		Description: existing.Description,
 		Status:      existing.Status,
+		DueAt:       existing.DueAt,
+		Priority:    existing.Priority,
+		AssigneeID:  existing.AssigneeID,
 	}
 
 	if input.Title != nil {
@@ -188,26 +603,847 @@ func (s *ItemService) Update(ctx context.Context, id string, input UpdateItemInp
 	if input.Status != nil {
 		params.Status = *input.Status
 	}
+	if input.DueAt != nil {
+		params.DueAt = sql.NullTime{Time: input.DueAt.UTC(), Valid: !input.DueAt.IsZero()}
+	}
+	if input.Priority != nil {
+		params.Priority = *input.Priority
+	}
+	if input.AssigneeID != nil {
+		if err := checkAssignee(ctx, q, *input.AssigneeID); err != nil {
+			return nil, err
+		}
+		params.AssigneeID = sql.NullString{String: *input.AssigneeID, Valid: *input.AssigneeID != ""}
+	}
+	if !s.rules.Workflow.Allows(existing.Status, params.Status) {
+		return nil, &StatusTransitionError{From: existing.Status, To: params.Status, Allowed: s.rules.Workflow[existing.Status]}
+	}
+	if params.Status == "completed" && existing.Status != "completed" {
+		if err := checkBlockers(ctx, q, id); err != nil {
+			return nil, err
+		}
+		if err := checkChecklist(ctx, q, s.rules, id); err != nil {
+			return nil, err
+		}
+	}
 
-	dbItem, err := s.queries.UpdateItem(ctx, params)
+	dbItem, err := q.UpdateItem(ctx, params)
 	if err != nil {
 		return nil, err
 	}
+	if input.ParentID != nil && *input.ParentID != existing.ParentID.String {
+		if err := checkParent(ctx, q, id, *input.ParentID); err != nil {
+			return nil, err
+		}
+		dbItem, err = q.SetItemParent(ctx, store.SetItemParentParams{
+			ParentID: sql.NullString{String: *input.ParentID, Valid: *input.ParentID != ""},
+			ID:       id,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if dbItem.Status != existing.Status {
+		if err := recordStatusChange(ctx, q, id, existing.Status, dbItem.Status, input.ActorID); err != nil {
+			return nil, err
+		}
+	}
+	if input.Tags != nil {
+		if err := q.RemoveItemTags(ctx, id); err != nil {
+			return nil, err
+		}
+		if err := setItemTags(ctx, q, id, *input.Tags); err != nil {
+			return nil, err
+		}
+	}
+	if dbItem.Status == "completed" && existing.Status != "completed" {
+		// Completing an occurrence brings the next one forward
+		if _, err := materializeRecurrence(ctx, q, id, time.Now()); err != nil {
+			return nil, err
+		}
+	}
 
-	return toItem(dbItem), nil
+	item, err := withDetails(ctx, q, dbItem)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return item, nil
 }
 
-// Delete removes an item.
+// Delete removes an item along with its attachments.
 func (s *ItemService) Delete(ctx context.Context, id string) error {
-	_, err := s.queries.GetItem(ctx, id)
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+	q := s.queries.InTx(tx)
+
+	if _, err := q.GetItem(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrItemNotFound
+		}
+		return err
+	}
+
+	// Listing the attachments in the same transaction as the delete means
+	// no upload can slip in between and leave its file behind.
+	attachments, err := q.ListAttachments(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := q.DeleteItem(ctx, id); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	deleteFiles(ctx, s.files, attachments)
+	return nil
+}
+
+// AddTag attaches a tag to an item. Attaching a tag the item already has
+// does nothing.
+func (s *ItemService) AddTag(ctx context.Context, itemID, tagID string) error {
+	if err := s.checkItemTag(ctx, itemID, tagID); err != nil {
+		return err
+	}
+	return s.queries.AddItemTag(ctx, store.AddItemTagParams{ItemID: itemID, TagID: tagID})
+}
+
+// RemoveTag detaches a tag from an item. Detaching a tag the item does not
+// have does nothing.
+func (s *ItemService) RemoveTag(ctx context.Context, itemID, tagID string) error {
+	if err := s.checkItemTag(ctx, itemID, tagID); err != nil {
+		return err
+	}
+	return s.queries.RemoveItemTag(ctx, store.RemoveItemTagParams{ItemID: itemID, TagID: tagID})
+}
+
+// checkItemTag returns ErrItemNotFound or ErrTagNotFound if the item or tag
+// does not exist.
+func (s *ItemService) checkItemTag(ctx context.Context, itemID, tagID string) error {
+	if _, err := s.queries.GetItem(ctx, itemID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrItemNotFound
+		}
+		return err
+	}
+	if _, err := s.queries.GetTag(ctx, tagID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTagNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// History returns the status changes of an item, oldest first.
+func (s *ItemService) History(ctx context.Context, id string) ([]ItemStatusChange, error) {
+	if _, err := s.queries.GetItem(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrItemNotFound
+		}
+		return nil, err
+	}
+
+	rows, err := s.queries.ListItemStatusHistory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]ItemStatusChange, len(rows))
+	for i, row := range rows {
+		history[i] = ItemStatusChange{
+			ID:         row.ID,
+			ItemID:     row.ItemID,
+			FromStatus: row.FromStatus.String,
+			ToStatus:   row.ToStatus,
+			ActorID:    row.ActorID.String,
+			CreatedAt:  row.CreatedAt,
+		}
+	}
+	return history, nil
+}
+
+// recordStatusChange adds a status change to an item's history. from is
+// empty for a new item.
+func recordStatusChange(ctx context.Context, q store.Store, itemID, from, to, actorID string) error {
+	_, err := q.CreateItemStatusChange(ctx, store.CreateItemStatusChangeParams{
+		ID:         uuid.New().String(),
+		ItemID:     itemID,
+		FromStatus: sql.NullString{String: from, Valid: from != ""},
+		ToStatus:   to,
+		ActorID:    sql.NullString{String: actorID, Valid: actorID != ""},
+		// Set here rather than by the database so that changes within the
+		// same second keep their order.
+		CreatedAt: time.Now().UTC(),
+	})
+	return err
+}
+
+// setItemTags attaches the named tags to an item, creating tags that do
+// not exist yet.
+func setItemTags(ctx context.Context, q store.Store, itemID string, names []string) error {
+	for _, name := range names {
+		tag, err := q.GetTagByName(ctx, name)
+		if errors.Is(err, sql.ErrNoRows) {
+			tag, err = q.CreateTag(ctx, store.CreateTagParams{ID: uuid.New().String(), Name: name})
+		}
+		if err != nil {
+			return err
+		}
+		if err := q.AddItemTag(ctx, store.AddItemTagParams{ItemID: itemID, TagID: tag.ID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Move changes an item's rank so it sorts just before or just after another
+// item in the manual order. Only the moved item is written, unless the
+// ranks around the target have run out of room and are rebalanced first.
+func (s *ItemService) Move(ctx context.Context, id string, input MoveItemInput) (*Item, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+	q := s.queries.InTx(tx)
+
+	if _, err := q.GetItem(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrItemNotFound
+		}
+		return nil, err
+	}
+	targetID := input.Before
+	if targetID == "" {
+		targetID = input.After
+	}
+
+	var itemRank string
+	for attempt := 0; ; attempt++ {
+		target, err := q.GetItem(ctx, targetID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, ErrMoveTargetNotFound
+			}
+			return nil, err
+		}
+		if target.ID == id {
+			// Moving next to itself leaves the item where it is
+			itemRank = target.Rank
+			break
+		}
+
+		// A neighbour is looked up by rank, so the target's rank must be
+		// valid and its own
+		shared, err := q.CountItemsByRank(ctx, target.Rank)
+		if err != nil {
+			return nil, err
+		}
+		var lo, hi string
+		if !rank.Valid(target.Rank) {
+			err = rank.ErrInvalid
+		} else if shared > 1 {
+			err = rank.ErrOrder
+		} else if input.Before != "" {
+			hi = target.Rank
+			prev, perr := q.GetPreviousRankedItem(ctx, store.GetPreviousRankedItemParams{Rank: target.Rank, ID: id})
+			if perr != nil && !errors.Is(perr, sql.ErrNoRows) {
+				return nil, perr
+			}
+			lo = prev.Rank
+		} else {
+			lo = target.Rank
+			next, nerr := q.GetNextRankedItem(ctx, store.GetNextRankedItemParams{Rank: target.Rank, ID: id})
+			if nerr != nil && !errors.Is(nerr, sql.ErrNoRows) {
+				return nil, nerr
+			}
+			hi = next.Rank
+		}
+		if err == nil {
+			itemRank, err = rank.Between(lo, hi)
+			if err == nil {
+				break
+			}
+		}
+		// Items sharing a rank, or ranks written outside the service, leave
+		// no room; rebalancing fixes both
+		if attempt > 0 || !(errors.Is(err, rank.ErrOrder) || errors.Is(err, rank.ErrInvalid)) {
+			return nil, err
+		}
+		if _, err := rebalanceRanks(ctx, q); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := q.SetItemRank(ctx, store.SetItemRankParams{Rank: itemRank, ID: id}); err != nil {
+		return nil, err
+	}
+	dbItem, err := q.GetItem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	item, err := withDetails(ctx, q, dbItem)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// RebalanceRanks reissues evenly spaced ranks, keeping the manual order,
+// once repeated moves have made them long or left items sharing a rank. It
+// returns the number of items whose rank changed.
+func (s *ItemService) RebalanceRanks(ctx context.Context) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	n, err := rebalanceRanks(ctx, s.queries.InTx(tx))
+	if err != nil {
+		return 0, err
+	}
+	return n, tx.Commit()
+}
+
+// RebalanceRanksEvery calls RebalanceRanks now and then every interval
+// until ctx is done. Failures are logged and retried at the next interval.
+func (s *ItemService) RebalanceRanksEvery(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		n, err := s.RebalanceRanks(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.Error("failed to rebalance item ranks", "error", err)
+		} else if n > 0 {
+			slog.Info("rebalanced item ranks", "items", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// rebalanceRanks implements RebalanceRanks inside a transaction.
+func rebalanceRanks(ctx context.Context, q store.Store) (int, error) {
+	items, err := q.ListItemsByRank(ctx)
 	if err != nil {
+		return 0, err
+	}
+	ranks := make([]string, len(items))
+	for i, item := range items {
+		ranks[i] = item.Rank
+	}
+	if rank.Balanced(ranks) {
+		return 0, nil
+	}
+
+	changed := 0
+	for i, r := range rank.Spread(len(items)) {
+		if items[i].Rank == r {
+			continue
+		}
+		if err := q.SetItemRank(ctx, store.SetItemRankParams{Rank: r, ID: items[i].ID}); err != nil {
+			return 0, err
+		}
+		changed++
+	}
+	return changed, nil
+}
+
+// lastRank returns the highest item rank, or "" if there are no items.
+func lastRank(ctx context.Context, q store.Store) (string, error) {
+	last, err := q.GetLastRankedItem(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return last.Rank, err
+}
+
+// Children returns an item's sub-items, oldest first.
+func (s *ItemService) Children(ctx context.Context, id string) ([]Item, error) {
+	if _, err := s.queries.GetItem(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrItemNotFound
+		}
+		return nil, err
+	}
+	children, err := s.queries.ListChildItems(ctx, sql.NullString{String: id, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	return withDetailsList(ctx, s.queries, children)
+}
+
+// Dependencies returns the items an item is blocked by and the items it
+// blocks.
+func (s *ItemService) Dependencies(ctx context.Context, id string) (*ItemDependencies, error) {
+	if _, err := s.queries.GetItem(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrItemNotFound
+		}
+		return nil, err
+	}
+	blockers, err := s.queries.ListItemBlockers(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	blocked, err := s.queries.ListBlockedItems(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	deps := &ItemDependencies{}
+	if deps.BlockedBy, err = withDetailsList(ctx, s.queries, blockers); err != nil {
+		return nil, err
+	}
+	if deps.Blocks, err = withDetailsList(ctx, s.queries, blocked); err != nil {
+		return nil, err
+	}
+	return deps, nil
+}
+
+// AddDependency records that an item is blocked by another. Adding an
+// existing dependency is a no-op; one that would make an item block itself,
+// directly or through other items, fails with ErrItemCycle.
+func (s *ItemService) AddDependency(ctx context.Context, id, blockerID string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+	q := s.queries.InTx(tx)
+
+	if _, err := q.GetItem(ctx, id); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return ErrItemNotFound
 		}
 		return err
 	}
+	if _, err := q.GetItem(ctx, blockerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrBlockerNotFound
+		}
+		return err
+	}
+	// The new edge closes a cycle if id already blocks blockerID
+	seen := map[string]bool{}
+	pending := []string{blockerID}
+	for len(pending) > 0 {
+		current := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if current == id {
+			return ErrItemCycle
+		}
+		if seen[current] {
+			continue
+		}
+		seen[current] = true
+		blockers, err := q.ListItemBlockers(ctx, current)
+		if err != nil {
+			return err
+		}
+		for _, blocker := range blockers {
+			pending = append(pending, blocker.ID)
+		}
+	}
 
-	return s.queries.DeleteItem(ctx, id)
+	if err := q.AddItemDependency(ctx, store.AddItemDependencyParams{ItemID: id, BlockerID: blockerID}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RemoveDependency removes a dependency between two items. Removing one
+// that does not exist is a no-op.
+func (s *ItemService) RemoveDependency(ctx context.Context, id, blockerID string) error {
+	if _, err := s.queries.GetItem(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrItemNotFound
+		}
+		return err
+	}
+	if _, err := s.queries.GetItem(ctx, blockerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrBlockerNotFound
+		}
+		return err
+	}
+	return s.queries.RemoveItemDependency(ctx, store.RemoveItemDependencyParams{ItemID: id, BlockerID: blockerID})
+}
+
+// SetRecurrence makes an item recur, replacing any rule it had. The next
+// occurrence falls due at the rule's first occurrence after the item's due
+// date, or after now if that is later. A rule that is incomplete or never
+// occurs fails with a *RecurrenceError.
+func (s *ItemService) SetRecurrence(ctx context.Context, id string, input SetRecurrenceInput) (*ItemRecurrence, error) {
+	item, err := s.queries.GetItem(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrItemNotFound
+		}
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	base := now
+	if item.DueAt.Valid && item.DueAt.Time.After(now) {
+		base = item.DueAt.Time.UTC()
+	}
+	rule := recur.Rule{Frequency: input.Frequency, Start: base}
+	if rule.Frequency == recur.Cron {
+		rule.Cron = input.Cron
+	}
+	if input.StartsAt != nil {
+		rule.Start = input.StartsAt.UTC()
+	}
+	if err := rule.Validate(); err != nil {
+		return nil, &RecurrenceError{Reason: err}
+	}
+	nextAt := rule.Next(base)
+	if nextAt.IsZero() {
+		return nil, &RecurrenceError{Reason: recur.ErrNoOccurrence}
+	}
+
+	rec, err := s.queries.SetItemRecurrence(ctx, store.SetItemRecurrenceParams{
+		ItemID:    id,
+		Frequency: rule.Frequency,
+		Cron:      rule.Cron,
+		StartsAt:  rule.Start,
+		NextAt:    nextAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toItemRecurrence(rec), nil
+}
+
+// RemoveRecurrence stops an item recurring. Occurrences already created
+// are kept, and removing the rule of an item that does not recur is a
+// no-op.
+func (s *ItemService) RemoveRecurrence(ctx context.Context, id string) error {
+	if _, err := s.queries.GetItem(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrItemNotFound
+		}
+		return err
+	}
+	return s.queries.DeleteItemRecurrence(ctx, id)
+}
+
+// MaterializeRecurrences creates the next occurrence of every recurring
+// item whose next occurrence has fallen due without the current one being
+// completed. It returns the number of items created. A rule that fails is
+// logged and left for the next call, and does not hold up the others; the
+// failures are returned joined.
+func (s *ItemService) MaterializeRecurrences(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	due, err := s.queries.ListDueItemRecurrences(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	var errs []error
+	for _, rec := range due {
+		ok, err := s.materializeRecurrence(ctx, rec.ItemID, now)
+		if err != nil {
+			slog.Error("failed to create recurring item", "error", err, "item_id", rec.ItemID)
+			errs = append(errs, fmt.Errorf("item %s: %w", rec.ItemID, err))
+			continue
+		}
+		if ok {
+			created++
+		}
+	}
+	return created, errors.Join(errs...)
+}
+
+// MaterializeRecurrencesEvery calls MaterializeRecurrences now and then
+// every interval until ctx is done. Failures are logged and retried at the
+// next interval.
+func (s *ItemService) MaterializeRecurrencesEvery(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		n, err := s.MaterializeRecurrences(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.Error("failed to create recurring items", "error", err)
+		} else if n > 0 {
+			slog.Info("created recurring items", "items", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// materializeRecurrence runs materializeRecurrence in a transaction of its
+// own.
+func (s *ItemService) materializeRecurrence(ctx context.Context, id string, now time.Time) (bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	ok, err := materializeRecurrence(ctx, s.queries.InTx(tx), id, now)
+	if err != nil {
+		return false, err
+	}
+	return ok, tx.Commit()
+}
+
+// materializeRecurrence creates the next occurrence of the item id if it
+// recurs, copying its owner, title, description, priority, assignee,
+// parent, tags and checklist, with no entry done, and moves the rule to
+// the new item. It reports whether the item recurred.
+func materializeRecurrence(ctx context.Context, q store.Store, id string, now time.Time) (bool, error) {
+	// Taking the rule off the item first means that of two concurrent
+	// callers only one sees it
+	rec, err := q.TakeItemRecurrence(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	item, err := q.GetItem(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	tagRows, err := q.ListTagsForItems(ctx, []string{id})
+	if err != nil {
+		return false, err
+	}
+	tags := make([]string, len(tagRows))
+	for i, row := range tagRows {
+		tags[i] = row.Name
+	}
+	entries, err := q.ListItemChecklistEntries(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	checklist := make([]string, len(entries))
+	for i, e := range entries {
+		checklist[i] = e.Text
+	}
+
+	dueAt := rec.NextAt
+	next, err := insertItem(ctx, q, uuid.New().String(), CreateItemInput{
+		UserID:      item.UserID,
+		Title:       item.Title,
+		Description: item.Description.String,
+		DueAt:       &dueAt,
+		Priority:    item.Priority,
+		AssigneeID:  item.AssigneeID.String,
+		ParentID:    item.ParentID.String,
+		Tags:        tags,
+		Checklist:   checklist,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	// After downtime NextAt may be long past; skip the missed occurrences
+	rule := recur.Rule{Frequency: rec.Frequency, Cron: rec.Cron, Start: rec.StartsAt}
+	nextAt := rule.Next(rec.NextAt)
+	if !nextAt.After(now) {
+		nextAt = rule.Next(now)
+	}
+	if nextAt.IsZero() {
+		// The rule has run out of occurrences
+		return true, nil
+	}
+	_, err = q.SetItemRecurrence(ctx, store.SetItemRecurrenceParams{
+		ItemID:    next.ID,
+		Frequency: rec.Frequency,
+		Cron:      rec.Cron,
+		StartsAt:  rec.StartsAt,
+		NextAt:    nextAt,
+		CreatedAt: rec.CreatedAt,
+		UpdatedAt: now.UTC(),
+	})
+	return true, err
+}
+
+// toItemRecurrence converts a store recurrence to the service type.
+func toItemRecurrence(rec store.ItemRecurrence) *ItemRecurrence {
+	return &ItemRecurrence{
+		ItemID:    rec.ItemID,
+		Frequency: rec.Frequency,
+		Cron:      rec.Cron,
+		StartsAt:  rec.StartsAt,
+		NextAt:    rec.NextAt,
+		CreatedAt: rec.CreatedAt,
+		UpdatedAt: rec.UpdatedAt,
+	}
+}
+
+// checkParent returns ErrParentNotFound unless parentID is empty or names an
+// item, and ErrItemCycle if the item id would become its own ancestor.
+func checkParent(ctx context.Context, q store.Store, id, parentID string) error {
+	seen := map[string]bool{}
+	for current := parentID; current != ""; {
+		if current == id {
+			return ErrItemCycle
+		}
+		if seen[current] {
+			// An existing cycle, which does not involve id
+			return nil
+		}
+		seen[current] = true
+		item, err := q.GetItem(ctx, current)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) && current == parentID {
+				return ErrParentNotFound
+			}
+			return err
+		}
+		current = item.ParentID.String
+	}
+	return nil
+}
+
+// checkBlockers returns a *BlockedError if any item blocking id is not
+// completed.
+func checkBlockers(ctx context.Context, q store.Store, id string) error {
+	blockers, err := q.ListItemBlockers(ctx, id)
+	if err != nil {
+		return err
+	}
+	var open []string
+	for _, blocker := range blockers {
+		if blocker.Status != "completed" {
+			open = append(open, blocker.ID)
+		}
+	}
+	if len(open) > 0 {
+		return &BlockedError{Blockers: open}
+	}
+	return nil
+}
+
+// checkChecklist returns a *ChecklistIncompleteError if the rules require
+// a completed item's checklist done and any of the item's entries is not.
+func checkChecklist(ctx context.Context, q store.Store, rules ItemRules, id string) error {
+	if !rules.RequireChecklistDone {
+		return nil
+	}
+	rows, err := q.ChecklistProgressForItems(ctx, []string{id})
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if remaining := row.Total - row.Completed; remaining > 0 {
+			return &ChecklistIncompleteError{Remaining: remaining}
+		}
+	}
+	return nil
+}
+
+// checkAssignee returns ErrAssigneeNotFound unless id is empty or names a
+// user.
+func checkAssignee(ctx context.Context, q store.Store, id string) error {
+	if id == "" {
+		return nil
+	}
+	if _, err := q.GetUser(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrAssigneeNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// withDetails converts a database item to a service item with its tags,
+// comment count, progress and checklist counts.
+func withDetails(ctx context.Context, q store.Store, dbItem store.Item) (*Item, error) {
+	items, err := withDetailsList(ctx, q, []store.Item{dbItem})
+	if err != nil {
+		return nil, err
+	}
+	return &items[0], nil
+}
+
+// withDetailsList is withDetails for several items, with one query per
+// detail rather than per item.
+func withDetailsList(ctx context.Context, q store.Store, dbItems []store.Item) ([]Item, error) {
+	ids := make([]string, len(dbItems))
+	for i, item := range dbItems {
+		ids[i] = item.ID
+	}
+	tagRows, err := q.ListTagsForItems(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	tags := make(map[string][]string)
+	for _, row := range tagRows {
+		tags[row.ItemID] = append(tags[row.ItemID], row.Name)
+	}
+	countRows, err := q.CountCommentsForItems(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	comments := make(map[string]int64, len(countRows))
+	for _, row := range countRows {
+		comments[row.ItemID] = row.Count
+	}
+	progressRows, err := q.ProgressForItems(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	progress := make(map[string]ItemProgress, len(progressRows))
+	for _, row := range progressRows {
+		progress[row.ItemID] = ItemProgress{Total: row.Total, Completed: row.Completed}
+	}
+	checklistRows, err := q.ChecklistProgressForItems(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	checklists := make(map[string]ItemProgress, len(checklistRows))
+	for _, row := range checklistRows {
+		checklists[row.ItemID] = ItemProgress{Total: row.Total, Completed: row.Completed}
+	}
+	recurrenceRows, err := q.ListRecurrencesForItems(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	recurrences := make(map[string]*ItemRecurrence, len(recurrenceRows))
+	for _, row := range recurrenceRows {
+		recurrences[row.ItemID] = toItemRecurrence(row)
+	}
+
+	items := make([]Item, len(dbItems))
+	for i, dbItem := range dbItems {
+		items[i] = *toItem(dbItem)
+		if names := tags[dbItem.ID]; names != nil {
+			items[i].Tags = names
+		}
+		items[i].CommentCount = comments[dbItem.ID]
+		items[i].Progress = progress[dbItem.ID]
+		items[i].Checklist = checklists[dbItem.ID]
+		items[i].Recurrence = recurrences[dbItem.ID]
+	}
+	return items, nil
 }
 
 // toItem converts a database item to a service item.
@@ -217,12 +1453,23 @@ func toItem(dbItem store.Item) *Item {
 		desc = dbItem.Description.String
 	}
 
+	var dueAt *time.Time
+	if dbItem.DueAt.Valid {
+		dueAt = &dbItem.DueAt.Time
+	}
+
 	return &Item{
 		ID:          dbItem.ID,
 		UserID:      dbItem.UserID,
 		Title:       dbItem.Title,
 		Description: desc,
 		Status:      dbItem.Status,
+		DueAt:       dueAt,
+		Priority:    dbItem.Priority,
+		AssigneeID:  dbItem.AssigneeID.String,
+		ParentID:    dbItem.ParentID.String,
+		Rank:        dbItem.Rank,
+		Tags:        []string{},
 		CreatedAt:   dbItem.CreatedAt.Time,
 		UpdatedAt:   dbItem.UpdatedAt.Time,
 	}
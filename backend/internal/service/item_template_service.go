@@ -0,0 +1,394 @@
+package service
+
+// Sections between scaffold:begin and scaffold:end markers are regenerated
+// by cmd/scaffold -regen; remove a section's markers to keep local edits.
+
+// scaffold:begin imports
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/keel/api/internal/store"
+)
+
+// scaffold:end imports
+
+// ItemTemplate represents an item template in the system.
+type ItemTemplate struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Title       string  `json:"title"`
+	Description *string `json:"description"`
+	Status      string  `json:"status"`
+	// Tags names the tags an item made from the template starts with.
+	Tags []string `json:"tags"`
+	// Checklist is the text of the template's checklist entries, in order.
+	Checklist []string  `json:"checklist"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// CreateItemTemplateInput represents the input for creating an item template. Nil
+// fields take their default.
+type CreateItemTemplateInput struct {
+	Name        string
+	Title       string
+	Description *string
+	Status      *string
+	Tags        []string
+	Checklist   []string
+}
+
+// UpdateItemTemplateInput represents the input for updating an item template. Nil
+// fields are left unchanged.
+type UpdateItemTemplateInput struct {
+	Name        *string
+	Title       *string
+	Description *string
+	Status      *string
+	// Tags and Checklist, if set, replace the template's.
+	Tags      *[]string
+	Checklist *[]string
+}
+
+// ItemTemplateListResult represents a paginated list of item templates.
+type ItemTemplateListResult struct {
+	Data       []ItemTemplate
+	Page       int
+	Limit      int
+	Total      int64
+	TotalPages int
+}
+
+// scaffold:begin vars
+
+// ItemTemplateStatusValues lists the allowed values of ItemTemplate.Status.
+var ItemTemplateStatusValues = []string{"pending", "in_progress", "completed"}
+
+// Common errors
+var (
+	ErrItemTemplateNotFound      = errors.New("item template not found")
+	ErrItemTemplateAlreadyExists = errors.New("item template with this name already exists")
+)
+
+// scaffold:end vars
+
+// scaffold:begin service
+
+// ItemTemplateService provides item template-related business logic.
+type ItemTemplateService struct {
+	queries store.Store
+	db      *sql.DB
+}
+
+// NewItemTemplateService creates a new ItemTemplateService.
+func NewItemTemplateService(db *sql.DB, queries store.Store) *ItemTemplateService {
+	return &ItemTemplateService{
+		queries: queries,
+		db:      db,
+	}
+}
+
+// scaffold:end service
+
+// Create creates a new item template.
+func (s *ItemTemplateService) Create(ctx context.Context, input CreateItemTemplateInput) (*ItemTemplate, error) {
+	params := store.CreateItemTemplateParams{
+		ID:    uuid.New().String(),
+		Name:  input.Name,
+		Title: input.Title,
+	}
+	if input.Description != nil {
+		params.Description = sql.NullString{String: *input.Description, Valid: true}
+	}
+	if input.Status != nil {
+		params.Status = *input.Status
+	} else {
+		params.Status = "pending"
+	}
+
+	if err := s.check(ctx, "", params.Name); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+	q := s.queries.InTx(tx)
+
+	dbItemTemplate, err := q.CreateItemTemplate(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if err := setTemplateContents(ctx, q, params.ID, &input.Tags, &input.Checklist); err != nil {
+		return nil, err
+	}
+
+	itemTemplate, err := withTemplateContents(ctx, q, dbItemTemplate)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return itemTemplate, nil
+}
+
+// Get retrieves an item template by ID.
+func (s *ItemTemplateService) Get(ctx context.Context, id string) (*ItemTemplate, error) {
+	dbItemTemplate, err := s.queries.GetItemTemplate(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrItemTemplateNotFound
+		}
+		return nil, err
+	}
+
+	return withTemplateContents(ctx, s.queries, dbItemTemplate)
+}
+
+// List retrieves a paginated list of item templates.
+func (s *ItemTemplateService) List(ctx context.Context, page, limit int) (*ItemTemplateListResult, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	offset := (page - 1) * limit
+
+	rows, err := s.queries.ListItemTemplates(ctx, store.ListItemTemplatesParams{
+		Limit:  int64(limit),
+		Offset: int64(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.queries.CountItemTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	itemTemplates, err := withTemplateContentsList(ctx, s.queries, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	result := &ItemTemplateListResult{
+		Data:       itemTemplates,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+
+	return result, nil
+}
+
+// Update updates an item template.
+func (s *ItemTemplateService) Update(ctx context.Context, id string, input UpdateItemTemplateInput) (*ItemTemplate, error) {
+	existing, err := s.queries.GetItemTemplate(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrItemTemplateNotFound
+		}
+		return nil, err
+	}
+
+	// Use existing values if not provided
+	params := store.UpdateItemTemplateParams{
+		ID:          id,
+		Name:        existing.Name,
+		Title:       existing.Title,
+		Description: existing.Description,
+		Status:      existing.Status,
+	}
+
+	if input.Name != nil {
+		params.Name = *input.Name
+	}
+	if input.Title != nil {
+		params.Title = *input.Title
+	}
+	if input.Description != nil {
+		params.Description = sql.NullString{String: *input.Description, Valid: true}
+	}
+	if input.Status != nil {
+		params.Status = *input.Status
+	}
+
+	if err := s.check(ctx, id, params.Name); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+	q := s.queries.InTx(tx)
+
+	dbItemTemplate, err := q.UpdateItemTemplate(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if err := setTemplateContents(ctx, q, id, input.Tags, input.Checklist); err != nil {
+		return nil, err
+	}
+
+	itemTemplate, err := withTemplateContents(ctx, q, dbItemTemplate)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return itemTemplate, nil
+}
+
+// scaffold:begin delete
+
+// Delete removes an item template.
+func (s *ItemTemplateService) Delete(ctx context.Context, id string) error {
+	_, err := s.queries.GetItemTemplate(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrItemTemplateNotFound
+		}
+		return err
+	}
+
+	return s.queries.DeleteItemTemplate(ctx, id)
+}
+
+// scaffold:end delete
+
+// scaffold:begin check
+
+// check enforces the constraints the database would otherwise reject with
+// an opaque error: referenced records must exist and unique fields must
+// not be taken by another item template. id is empty when creating.
+func (s *ItemTemplateService) check(ctx context.Context, id string, name string) error {
+	if other, err := s.queries.GetItemTemplateByName(ctx, name); err == nil && other.ID != id {
+		return ErrItemTemplateAlreadyExists
+	} else if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	return nil
+}
+
+// scaffold:end check
+
+// scaffold:begin convert
+
+// toItemTemplate converts a database item template to a service item template.
+func toItemTemplate(dbItemTemplate store.ItemTemplate) *ItemTemplate {
+	itemTemplate := &ItemTemplate{
+		ID:        dbItemTemplate.ID,
+		Name:      dbItemTemplate.Name,
+		Title:     dbItemTemplate.Title,
+		Status:    dbItemTemplate.Status,
+		CreatedAt: dbItemTemplate.CreatedAt.Time,
+		UpdatedAt: dbItemTemplate.UpdatedAt.Time,
+	}
+	if dbItemTemplate.Description.Valid {
+		itemTemplate.Description = &dbItemTemplate.Description.String
+	}
+	return itemTemplate
+}
+
+// scaffold:end convert
+
+// setTemplateContents replaces a template's tags and checklist with the
+// given ones, leaving either alone if nil.
+func setTemplateContents(ctx context.Context, q store.Store, id string, tags, checklist *[]string) error {
+	if tags != nil {
+		if err := q.RemoveItemTemplateTags(ctx, id); err != nil {
+			return err
+		}
+		for _, name := range *tags {
+			if err := q.AddItemTemplateTag(ctx, store.AddItemTemplateTagParams{TemplateID: id, Name: name}); err != nil {
+				return err
+			}
+		}
+	}
+	if checklist != nil {
+		if err := q.RemoveItemTemplateChecklistEntries(ctx, id); err != nil {
+			return err
+		}
+		for i, text := range *checklist {
+			if err := q.AddItemTemplateChecklistEntry(ctx, store.AddItemTemplateChecklistEntryParams{
+				TemplateID: id,
+				Position:   int64(i),
+				Text:       text,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// withTemplateContents converts a database item template and loads its
+// tags and checklist.
+func withTemplateContents(ctx context.Context, q store.Store, dbItemTemplate store.ItemTemplate) (*ItemTemplate, error) {
+	itemTemplates, err := withTemplateContentsList(ctx, q, []store.ItemTemplate{dbItemTemplate})
+	if err != nil {
+		return nil, err
+	}
+	return &itemTemplates[0], nil
+}
+
+// withTemplateContentsList is withTemplateContents for several templates,
+// with one query for all their tags and one for all their checklists.
+func withTemplateContentsList(ctx context.Context, q store.Store, dbItemTemplates []store.ItemTemplate) ([]ItemTemplate, error) {
+	ids := make([]string, len(dbItemTemplates))
+	for i, itemTemplate := range dbItemTemplates {
+		ids[i] = itemTemplate.ID
+	}
+	tagRows, err := q.ListTagsForItemTemplates(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	tags := make(map[string][]string)
+	for _, row := range tagRows {
+		tags[row.TemplateID] = append(tags[row.TemplateID], row.Name)
+	}
+	entryRows, err := q.ListChecklistEntriesForItemTemplates(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	checklists := make(map[string][]string)
+	for _, row := range entryRows {
+		checklists[row.TemplateID] = append(checklists[row.TemplateID], row.Text)
+	}
+
+	itemTemplates := make([]ItemTemplate, len(dbItemTemplates))
+	for i, dbItemTemplate := range dbItemTemplates {
+		itemTemplates[i] = *toItemTemplate(dbItemTemplate)
+		itemTemplates[i].Tags = []string{}
+		if names := tags[dbItemTemplate.ID]; names != nil {
+			itemTemplates[i].Tags = names
+		}
+		itemTemplates[i].Checklist = []string{}
+		if texts := checklists[dbItemTemplate.ID]; texts != nil {
+			itemTemplates[i].Checklist = texts
+		}
+	}
+	return itemTemplates, nil
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/keel/api/internal/store"
+)
+
+// Tag represents a tag that can be attached to items.
+type Tag struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// TagListResult represents a paginated list of tags.
+type TagListResult struct {
+	Data       []Tag
+	Page       int
+	Limit      int
+	Total      int64
+	TotalPages int
+}
+
+// Common errors
+var (
+	ErrTagNotFound      = errors.New("tag not found")
+	ErrTagAlreadyExists = errors.New("tag with this name already exists")
+)
+
+// TagService provides tag-related business logic.
+type TagService struct {
+	queries store.Store
+	db      *sql.DB
+}
+
+// NewTagService creates a new TagService.
+func NewTagService(db *sql.DB, queries store.Store) *TagService {
+	return &TagService{
+		queries: queries,
+		db:      db,
+	}
+}
+
+// Create creates a new tag.
+func (s *TagService) Create(ctx context.Context, name string) (*Tag, error) {
+	_, err := s.queries.GetTagByName(ctx, name)
+	if err == nil {
+		return nil, ErrTagAlreadyExists
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	dbTag, err := s.queries.CreateTag(ctx, store.CreateTagParams{
+		ID:   uuid.New().String(),
+		Name: name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toTag(dbTag), nil
+}
+
+// Get retrieves a tag by ID.
+func (s *TagService) Get(ctx context.Context, id string) (*Tag, error) {
+	dbTag, err := s.queries.GetTag(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTagNotFound
+		}
+		return nil, err
+	}
+
+	return toTag(dbTag), nil
+}
+
+// List retrieves a paginated list of tags, ordered by name.
+func (s *TagService) List(ctx context.Context, page, limit int) (*TagListResult, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	offset := (page - 1) * limit
+
+	tags, err := s.queries.ListTags(ctx, store.ListTagsParams{
+		Limit:  int64(limit),
+		Offset: int64(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.queries.CountTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+
+	result := &TagListResult{
+		Data:       make([]Tag, len(tags)),
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+
+	for i, t := range tags {
+		result.Data[i] = *toTag(t)
+	}
+
+	return result, nil
+}
+
+// Rename changes a tag's name. Items keep the tag under its new name.
+func (s *TagService) Rename(ctx context.Context, id, name string) (*Tag, error) {
+	if _, err := s.queries.GetTag(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTagNotFound
+		}
+		return nil, err
+	}
+
+	existing, err := s.queries.GetTagByName(ctx, name)
+	if err == nil && existing.ID != id {
+		return nil, ErrTagAlreadyExists
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	dbTag, err := s.queries.UpdateTag(ctx, store.UpdateTagParams{ID: id, Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	return toTag(dbTag), nil
+}
+
+// Delete removes a tag from every item and deletes it.
+func (s *TagService) Delete(ctx context.Context, id string) error {
+	if _, err := s.queries.GetTag(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTagNotFound
+		}
+		return err
+	}
+
+	return s.queries.DeleteTag(ctx, id)
+}
+
+// toTag converts a database tag to a service tag.
+func toTag(dbTag store.Tag) *Tag {
+	return &Tag{
+		ID:        dbTag.ID,
+		Name:      dbTag.Name,
+		CreatedAt: dbTag.CreatedAt.Time,
+		UpdatedAt: dbTag.UpdatedAt.Time,
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/keel/api/internal/storage"
 	"github.com/keel/api/internal/store"
 )
 
@@ -43,6 +44,9 @@ type UserListResult struct {
 	TotalPages int
 }
 
+// UserRoleValues lists the allowed values of User.Role.
+var UserRoleValues = []string{"admin", "user"}
+
 // Common errors
 var (
 	ErrUserNotFound      = errors.New("user not found")
@@ -51,15 +55,18 @@ var (
 
 // UserService provides user-related business logic.
 type UserService struct {
-	queries *store.Queries
+	queries store.Store
 	db      *sql.DB
+	files   storage.Storage
 }
 
-// NewUserService creates a new UserService.
-func NewUserService(db *sql.DB, queries *store.Queries) *UserService {
+// NewUserService creates a new UserService. files holds the attachments of
+// the users' items, which are removed with them.
+func NewUserService(db *sql.DB, queries store.Store, files storage.Storage) *UserService {
 	return &UserService{
 		queries: queries,
 		db:      db,
+		files:   files,
 	}
 }
 
@@ -200,18 +207,37 @@ func (s *UserService) Update(ctx context.Context, id string, input UpdateUserInp
 	return toUser(dbUser), nil
 }
 
-// Delete removes a user.
+// Delete removes a user along with their items and the items'
+// attachments.
 func (s *UserService) Delete(ctx context.Context, id string) error {
-	// Check if user exists first
-	_, err := s.queries.GetUser(ctx, id)
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+	q := s.queries.InTx(tx)
+
+	// Check if user exists first
+	if _, err := q.GetUser(ctx, id); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return ErrUserNotFound
 		}
 		return err
 	}
 
-	return s.queries.DeleteUser(ctx, id)
+	// Read with the delete, so an upload cannot land in between.
+	attachments, err := q.ListUserAttachments(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := q.DeleteUser(ctx, id); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	deleteFiles(ctx, s.files, attachments)
+	return nil
 }
 
 // toUser converts a database user to a service user.
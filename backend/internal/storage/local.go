@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Local stores objects as files under a directory, one file per key.
+type Local struct {
+	dir string
+}
+
+// NewLocal returns a Local storing files under dir, which is created on
+// first use.
+func NewLocal(dir string) *Local {
+	return &Local{dir: dir}
+}
+
+// Put implements Storage. The object is written to a temporary file and
+// renamed into place, so a failed upload leaves nothing behind.
+func (l *Local) Put(_ context.Context, key string, r io.Reader, _ int64, _ string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// Open implements Storage.
+func (l *Local) Open(_ context.Context, key string) (io.ReadSeekCloser, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return f, err
+}
+
+// Delete implements Storage. Directories left empty are removed too.
+func (l *Local) Delete(_ context.Context, key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	for dir := filepath.Dir(path); dir != filepath.Clean(l.dir); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			break
+		}
+	}
+	return nil
+}
+
+// path returns the file for key.
+func (l *Local) path(key string) (string, error) {
+	if !validKey(key) {
+		return "", fmt.Errorf("storage: invalid key %q", key)
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,306 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// S3Options configures an S3 storage.
+type S3Options struct {
+	// Endpoint is the base URL of the service, e.g.
+	// https://s3.eu-west-1.amazonaws.com or http://localhost:9000 for
+	// MinIO. Buckets are addressed path-style, which every S3-compatible
+	// service supports.
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// Client sends the requests; nil uses http.DefaultClient.
+	Client *http.Client
+}
+
+// S3 stores objects in a bucket of an S3-compatible service. Requests are
+// signed with AWS Signature Version 4.
+type S3 struct {
+	opts S3Options
+}
+
+// NewS3 returns an S3 storage for the bucket in opts.
+func NewS3(opts S3Options) *S3 {
+	opts.Endpoint = strings.TrimSuffix(opts.Endpoint, "/")
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+	return &S3{opts: opts}
+}
+
+// Put implements Storage. S3 needs the length of an upload in advance, so
+// an object of unknown size is spooled to a temporary file first.
+func (s *S3) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if size < 0 {
+		f, err := os.CreateTemp("", "keel-upload-*")
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+		}()
+		if size, err = io.Copy(f, r); err != nil {
+			return err
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		r = f
+	}
+
+	req, err := s.request(ctx, http.MethodPut, key, io.NopCloser(r))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if size == 0 {
+		req.Body = http.NoBody
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Open implements Storage. It looks up the object's size; the content is
+// fetched when first read, from the current offset, so seeking before
+// reading costs nothing.
+func (s *S3) Open(ctx context.Context, key string) (io.ReadSeekCloser, error) {
+	req, err := s.request(ctx, http.MethodHead, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body.Close()
+	return &s3Object{s: s, ctx: ctx, key: key, size: resp.ContentLength}, nil
+}
+
+// Delete implements Storage.
+func (s *S3) Delete(ctx context.Context, key string) error {
+	req, err := s.request(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// request returns a request for the object under key.
+func (s *S3) request(ctx context.Context, method, key string, body io.ReadCloser) (*http.Request, error) {
+	if !validKey(key) {
+		return nil, fmt.Errorf("storage: invalid key %q", key)
+	}
+	url := s.opts.Endpoint + "/" + uriEncode(s.opts.Bucket, true) + "/" + uriEncode(key, false)
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Body = body
+	return req, nil
+}
+
+// do signs and sends req. A 404 is returned as ErrNotFound and any other
+// error status as an error; otherwise the caller closes the body.
+func (s *S3) do(req *http.Request) (*http.Response, error) {
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+	s.sign(req, time.Now())
+
+	resp, err := s.opts.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		_ = resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("storage: s3 %s %s: %s %s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return resp, nil
+}
+
+// unsignedPayload skips hashing the body, which S3 allows for every
+// request; the payload is still covered by TLS.
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// sign adds an AWS Signature Version 4 Authorization header to req. It
+// signs the host and the Content-Type, Range and X-Amz-* headers, which
+// must be set beforehand, including X-Amz-Content-Sha256.
+func (s *S3) sign(req *http.Request, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if name == "content-type" || name == "range" || strings.HasPrefix(name, "x-amz-") {
+			headers[name] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uriEncode(req.URL.Path, false),
+		canonicalQuery(req),
+		canonicalHeaders.String(),
+		signedHeaders,
+		req.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+
+	scope := now.Format("20060102") + "/" + s.opts.Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hexSHA256(canonicalRequest)
+
+	key := hmacSHA256([]byte("AWS4"+s.opts.SecretAccessKey), now.Format("20060102"))
+	for _, part := range []string{s.opts.Region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+s.opts.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+// canonicalQuery returns the query string of req in the form SigV4 signs:
+// sorted by name, then value, with both URI-encoded.
+func canonicalQuery(req *http.Request) string {
+	var pairs []string
+	for name, values := range req.URL.Query() {
+		for _, v := range values {
+			pairs = append(pairs, uriEncode(name, true)+"="+uriEncode(v, true))
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode percent-encodes every byte of s except the unreserved
+// characters, as SigV4 requires. Slashes are kept unless encodeSlash is set.
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func hexSHA256(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+// s3Object reads an S3 object with ranged GETs. A GET is issued on the
+// first Read after opening or seeking and streams to the end of the object.
+type s3Object struct {
+	s    *S3
+	ctx  context.Context
+	key  string
+	size int64
+	pos  int64
+	body io.ReadCloser
+}
+
+func (o *s3Object) Read(p []byte) (int, error) {
+	if o.pos >= o.size {
+		return 0, io.EOF
+	}
+	if o.body == nil {
+		req, err := o.s.request(o.ctx, http.MethodGet, o.key, nil)
+		if err != nil {
+			return 0, err
+		}
+		req.Header.Set("Range", "bytes="+strconv.FormatInt(o.pos, 10)+"-")
+		resp, err := o.s.do(req)
+		if err != nil {
+			return 0, err
+		}
+		o.body = resp.Body
+	}
+	n, err := o.body.Read(p)
+	o.pos += int64(n)
+	return n, err
+}
+
+func (o *s3Object) Seek(offset int64, whence int) (int64, error) {
+	pos := offset
+	switch whence {
+	case io.SeekCurrent:
+		pos += o.pos
+	case io.SeekEnd:
+		pos += o.size
+	}
+	if pos < 0 {
+		return 0, errors.New("storage: seek before start of object")
+	}
+	if pos != o.pos && o.body != nil {
+		_ = o.body.Close()
+		o.body = nil
+	}
+	o.pos = pos
+	return pos, nil
+}
+
+func (o *s3Object) Close() error {
+	if o.body == nil {
+		return nil
+	}
+	err := o.body.Close()
+	o.body = nil
+	return err
+}
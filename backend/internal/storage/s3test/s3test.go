@@ -0,0 +1,115 @@
+// Package s3test provides an in-memory stand-in for an S3-compatible
+// service, for testing storage.S3 without network access.
+//
+//	srv := s3test.New(t)
+//	files := storage.NewS3(srv.Options())
+package s3test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/keel/api/internal/storage"
+)
+
+// Credentials the stand-in accepts.
+const (
+	Bucket          = "keel-test"
+	AccessKeyID     = "AKIAKEELTEST"
+	SecretAccessKey = "keel-test-secret"
+)
+
+// Server serves PUT, GET (with Range), HEAD and DELETE on the objects of
+// one bucket, addressed path-style. Requests must carry a SigV4
+// Authorization header for AccessKeyID; signatures are not verified.
+type Server struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	objects map[string]object
+}
+
+type object struct {
+	data        []byte
+	contentType string
+	modified    time.Time
+}
+
+// New starts a Server that is closed when the test ends.
+func New(t *testing.T) *Server {
+	s := &Server{objects: make(map[string]object)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Options returns storage options for the stand-in's bucket.
+func (s *Server) Options() storage.S3Options {
+	return storage.S3Options{
+		Endpoint:        s.URL,
+		Region:          "us-east-1",
+		Bucket:          Bucket,
+		AccessKeyID:     AccessKeyID,
+		SecretAccessKey: SecretAccessKey,
+		Client:          s.Client(),
+	}
+}
+
+// Keys returns the keys of the stored objects.
+func (s *Server) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := make([]string, 0, len(s.objects))
+	for key := range s.objects {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential="+AccessKeyID+"/") ||
+		r.Header.Get("X-Amz-Date") == "" || r.Header.Get("X-Amz-Content-Sha256") == "" {
+		http.Error(w, "AccessDenied", http.StatusForbidden)
+		return
+	}
+	key, ok := strings.CutPrefix(r.URL.Path, "/"+Bucket+"/")
+	if !ok {
+		http.Error(w, "NoSuchBucket", http.StatusNotFound)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		if r.ContentLength < 0 {
+			http.Error(w, "MissingContentLength", http.StatusLengthRequired)
+			return
+		}
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.objects[key] = object{data: data, contentType: r.Header.Get("Content-Type"), modified: time.Now()}
+	case http.MethodGet, http.MethodHead:
+		obj, ok := s.objects[key]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", obj.contentType)
+		http.ServeContent(w, r, "", obj.modified, bytes.NewReader(obj.data))
+	case http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "MethodNotAllowed", http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,45 @@
+// Package storage keeps the files attached to items. Local stores them in a
+// directory and S3 in an S3-compatible bucket; services only see Storage.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+)
+
+// ErrNotFound is returned by Open for a key with no object.
+var ErrNotFound = errors.New("storage: object not found")
+
+// Storage stores objects under slash-separated keys such as
+// "items/<id>/<attachment id>".
+type Storage interface {
+	// Put stores the contents of r under key, replacing any existing
+	// object. size is the length of r, or -1 if it is not known. If reading
+	// r fails, nothing is stored and Put returns the read error.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+
+	// Open returns the object stored under key, or ErrNotFound. The object
+	// can seek, so it can be served with http.ServeContent, which handles
+	// Range requests.
+	Open(ctx context.Context, key string) (io.ReadSeekCloser, error)
+
+	// Delete removes the object stored under key. Deleting a missing
+	// object is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// validKey reports whether key is a relative, slash-separated path without
+// empty, "." or ".." segments, so it cannot escape a Local directory.
+func validKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, seg := range strings.Split(key, "/") {
+		if seg == "" || seg == "." || seg == ".." || strings.ContainsRune(seg, '\\') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,102 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/keel/api/internal/storage"
+	"github.com/keel/api/internal/storage/s3test"
+)
+
+func TestStorage(t *testing.T) {
+	for name, open := range map[string]func(t *testing.T) storage.Storage{
+		"local": func(t *testing.T) storage.Storage { return storage.NewLocal(t.TempDir()) },
+		"s3":    func(t *testing.T) storage.Storage { return storage.NewS3(s3test.New(t).Options()) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			files := open(t)
+
+			if err := files.Put(ctx, "items/i1/a1", strings.NewReader("hello, world"), -1, "text/plain"); err != nil {
+				t.Fatalf("Put: %v", err)
+			}
+			if err := files.Put(ctx, "items/i1/empty", strings.NewReader(""), 0, "text/plain"); err != nil {
+				t.Fatalf("Put empty: %v", err)
+			}
+
+			obj, err := files.Open(ctx, "items/i1/a1")
+			if err != nil {
+				t.Fatalf("Open: %v", err)
+			}
+			if size, err := obj.Seek(0, io.SeekEnd); err != nil || size != 12 {
+				t.Errorf("Seek to end = %d, %v; want 12", size, err)
+			}
+			if _, err := obj.Seek(7, io.SeekStart); err != nil {
+				t.Fatalf("Seek: %v", err)
+			}
+			if got, err := io.ReadAll(obj); err != nil || string(got) != "world" {
+				t.Errorf("read from 7 = %q, %v", got, err)
+			}
+			if _, err := obj.Seek(0, io.SeekStart); err != nil {
+				t.Fatalf("Seek: %v", err)
+			}
+			if got, err := io.ReadAll(obj); err != nil || string(got) != "hello, world" {
+				t.Errorf("read all = %q, %v", got, err)
+			}
+			if err := obj.Close(); err != nil {
+				t.Errorf("Close: %v", err)
+			}
+
+			// A failing reader stores nothing.
+			failing := io.MultiReader(strings.NewReader("partial"), errReader{})
+			if err := files.Put(ctx, "items/i1/a2", failing, -1, "text/plain"); !errors.Is(err, errRead) {
+				t.Errorf("Put with failing reader = %v, want %v", err, errRead)
+			}
+			if _, err := files.Open(ctx, "items/i1/a2"); !errors.Is(err, storage.ErrNotFound) {
+				t.Errorf("Open after failed Put = %v, want ErrNotFound", err)
+			}
+
+			for _, key := range []string{"items/i1/a1", "items/i1/empty", "items/i1/missing"} {
+				if err := files.Delete(ctx, key); err != nil {
+					t.Errorf("Delete %s: %v", key, err)
+				}
+			}
+			if _, err := files.Open(ctx, "items/i1/a1"); !errors.Is(err, storage.ErrNotFound) {
+				t.Errorf("Open after Delete = %v, want ErrNotFound", err)
+			}
+
+			for _, key := range []string{"", "../escape", "items//a", "/abs", "items/./a"} {
+				if err := files.Put(ctx, key, strings.NewReader("x"), 1, ""); err == nil {
+					t.Errorf("Put %q succeeded", key)
+				}
+			}
+		})
+	}
+}
+
+func TestLocalRemovesEmptyDirectories(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	files := storage.NewLocal(dir)
+
+	if err := files.Put(ctx, "items/i1/a1", strings.NewReader("x"), 1, ""); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := files.Delete(ctx, "items/i1/a1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 0 {
+		t.Errorf("directory after Delete = %v, %v; want empty", entries, err)
+	}
+}
+
+var errRead = errors.New("read failed")
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) { return 0, errRead }
@@ -24,48 +24,225 @@ func New(db DBTX) *Queries {
 func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	q := Queries{db: db}
 	var err error
+	if q.addItemDependencyStmt, err = db.PrepareContext(ctx, addItemDependency); err != nil {
+		return nil, fmt.Errorf("error preparing query AddItemDependency: %w", err)
+	}
+	if q.addItemTagStmt, err = db.PrepareContext(ctx, addItemTag); err != nil {
+		return nil, fmt.Errorf("error preparing query AddItemTag: %w", err)
+	}
+	if q.addItemTemplateChecklistEntryStmt, err = db.PrepareContext(ctx, addItemTemplateChecklistEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query AddItemTemplateChecklistEntry: %w", err)
+	}
+	if q.addItemTemplateTagStmt, err = db.PrepareContext(ctx, addItemTemplateTag); err != nil {
+		return nil, fmt.Errorf("error preparing query AddItemTemplateTag: %w", err)
+	}
+	if q.countItemCommentsStmt, err = db.PrepareContext(ctx, countItemComments); err != nil {
+		return nil, fmt.Errorf("error preparing query CountItemComments: %w", err)
+	}
+	if q.countItemTemplatesStmt, err = db.PrepareContext(ctx, countItemTemplates); err != nil {
+		return nil, fmt.Errorf("error preparing query CountItemTemplates: %w", err)
+	}
 	if q.countItemsStmt, err = db.PrepareContext(ctx, countItems); err != nil {
 		return nil, fmt.Errorf("error preparing query CountItems: %w", err)
 	}
+	if q.countItemsByRankStmt, err = db.PrepareContext(ctx, countItemsByRank); err != nil {
+		return nil, fmt.Errorf("error preparing query CountItemsByRank: %w", err)
+	}
 	if q.countItemsByUserStmt, err = db.PrepareContext(ctx, countItemsByUser); err != nil {
 		return nil, fmt.Errorf("error preparing query CountItemsByUser: %w", err)
 	}
+	if q.countTagsStmt, err = db.PrepareContext(ctx, countTags); err != nil {
+		return nil, fmt.Errorf("error preparing query CountTags: %w", err)
+	}
 	if q.countUsersStmt, err = db.PrepareContext(ctx, countUsers); err != nil {
 		return nil, fmt.Errorf("error preparing query CountUsers: %w", err)
 	}
+	if q.createAttachmentStmt, err = db.PrepareContext(ctx, createAttachment); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateAttachment: %w", err)
+	}
 	if q.createItemStmt, err = db.PrepareContext(ctx, createItem); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateItem: %w", err)
 	}
+	if q.createItemChecklistEntryStmt, err = db.PrepareContext(ctx, createItemChecklistEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateItemChecklistEntry: %w", err)
+	}
+	if q.createItemCommentStmt, err = db.PrepareContext(ctx, createItemComment); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateItemComment: %w", err)
+	}
+	if q.createItemStatusChangeStmt, err = db.PrepareContext(ctx, createItemStatusChange); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateItemStatusChange: %w", err)
+	}
+	if q.createItemTemplateStmt, err = db.PrepareContext(ctx, createItemTemplate); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateItemTemplate: %w", err)
+	}
+	if q.createTagStmt, err = db.PrepareContext(ctx, createTag); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateTag: %w", err)
+	}
 	if q.createUserStmt, err = db.PrepareContext(ctx, createUser); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateUser: %w", err)
 	}
+	if q.deleteAttachmentStmt, err = db.PrepareContext(ctx, deleteAttachment); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteAttachment: %w", err)
+	}
 	if q.deleteItemStmt, err = db.PrepareContext(ctx, deleteItem); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteItem: %w", err)
 	}
+	if q.deleteItemChecklistEntryStmt, err = db.PrepareContext(ctx, deleteItemChecklistEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteItemChecklistEntry: %w", err)
+	}
+	if q.deleteItemCommentStmt, err = db.PrepareContext(ctx, deleteItemComment); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteItemComment: %w", err)
+	}
+	if q.deleteItemRecurrenceStmt, err = db.PrepareContext(ctx, deleteItemRecurrence); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteItemRecurrence: %w", err)
+	}
+	if q.deleteItemTemplateStmt, err = db.PrepareContext(ctx, deleteItemTemplate); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteItemTemplate: %w", err)
+	}
+	if q.deleteTagStmt, err = db.PrepareContext(ctx, deleteTag); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteTag: %w", err)
+	}
 	if q.deleteUserStmt, err = db.PrepareContext(ctx, deleteUser); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteUser: %w", err)
 	}
+	if q.getAttachmentStmt, err = db.PrepareContext(ctx, getAttachment); err != nil {
+		return nil, fmt.Errorf("error preparing query GetAttachment: %w", err)
+	}
 	if q.getItemStmt, err = db.PrepareContext(ctx, getItem); err != nil {
 		return nil, fmt.Errorf("error preparing query GetItem: %w", err)
 	}
+	if q.getItemChecklistEntryStmt, err = db.PrepareContext(ctx, getItemChecklistEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query GetItemChecklistEntry: %w", err)
+	}
+	if q.getItemCommentStmt, err = db.PrepareContext(ctx, getItemComment); err != nil {
+		return nil, fmt.Errorf("error preparing query GetItemComment: %w", err)
+	}
+	if q.getItemTemplateStmt, err = db.PrepareContext(ctx, getItemTemplate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetItemTemplate: %w", err)
+	}
+	if q.getItemTemplateByNameStmt, err = db.PrepareContext(ctx, getItemTemplateByName); err != nil {
+		return nil, fmt.Errorf("error preparing query GetItemTemplateByName: %w", err)
+	}
+	if q.getLastRankedItemStmt, err = db.PrepareContext(ctx, getLastRankedItem); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLastRankedItem: %w", err)
+	}
+	if q.getNextRankedItemStmt, err = db.PrepareContext(ctx, getNextRankedItem); err != nil {
+		return nil, fmt.Errorf("error preparing query GetNextRankedItem: %w", err)
+	}
+	if q.getPreviousRankedItemStmt, err = db.PrepareContext(ctx, getPreviousRankedItem); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPreviousRankedItem: %w", err)
+	}
+	if q.getTagStmt, err = db.PrepareContext(ctx, getTag); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTag: %w", err)
+	}
+	if q.getTagByNameStmt, err = db.PrepareContext(ctx, getTagByName); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTagByName: %w", err)
+	}
 	if q.getUserStmt, err = db.PrepareContext(ctx, getUser); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUser: %w", err)
 	}
 	if q.getUserByEmailStmt, err = db.PrepareContext(ctx, getUserByEmail); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUserByEmail: %w", err)
 	}
+	if q.listAttachmentsStmt, err = db.PrepareContext(ctx, listAttachments); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAttachments: %w", err)
+	}
+	if q.listBlockedItemsStmt, err = db.PrepareContext(ctx, listBlockedItems); err != nil {
+		return nil, fmt.Errorf("error preparing query ListBlockedItems: %w", err)
+	}
+	if q.listChildItemsStmt, err = db.PrepareContext(ctx, listChildItems); err != nil {
+		return nil, fmt.Errorf("error preparing query ListChildItems: %w", err)
+	}
+	if q.listDueItemRecurrencesStmt, err = db.PrepareContext(ctx, listDueItemRecurrences); err != nil {
+		return nil, fmt.Errorf("error preparing query ListDueItemRecurrences: %w", err)
+	}
+	if q.listItemBlockersStmt, err = db.PrepareContext(ctx, listItemBlockers); err != nil {
+		return nil, fmt.Errorf("error preparing query ListItemBlockers: %w", err)
+	}
+	if q.listItemChecklistEntriesStmt, err = db.PrepareContext(ctx, listItemChecklistEntries); err != nil {
+		return nil, fmt.Errorf("error preparing query ListItemChecklistEntries: %w", err)
+	}
+	if q.listItemCommentsStmt, err = db.PrepareContext(ctx, listItemComments); err != nil {
+		return nil, fmt.Errorf("error preparing query ListItemComments: %w", err)
+	}
+	if q.listItemStatusHistoryStmt, err = db.PrepareContext(ctx, listItemStatusHistory); err != nil {
+		return nil, fmt.Errorf("error preparing query ListItemStatusHistory: %w", err)
+	}
+	if q.listItemTagsStmt, err = db.PrepareContext(ctx, listItemTags); err != nil {
+		return nil, fmt.Errorf("error preparing query ListItemTags: %w", err)
+	}
+	if q.listItemTemplateChecklistEntriesStmt, err = db.PrepareContext(ctx, listItemTemplateChecklistEntries); err != nil {
+		return nil, fmt.Errorf("error preparing query ListItemTemplateChecklistEntries: %w", err)
+	}
+	if q.listItemTemplateTagsStmt, err = db.PrepareContext(ctx, listItemTemplateTags); err != nil {
+		return nil, fmt.Errorf("error preparing query ListItemTemplateTags: %w", err)
+	}
+	if q.listItemTemplatesStmt, err = db.PrepareContext(ctx, listItemTemplates); err != nil {
+		return nil, fmt.Errorf("error preparing query ListItemTemplates: %w", err)
+	}
 	if q.listItemsStmt, err = db.PrepareContext(ctx, listItems); err != nil {
 		return nil, fmt.Errorf("error preparing query ListItems: %w", err)
 	}
+	if q.listItemsByRankStmt, err = db.PrepareContext(ctx, listItemsByRank); err != nil {
+		return nil, fmt.Errorf("error preparing query ListItemsByRank: %w", err)
+	}
 	if q.listItemsByUserStmt, err = db.PrepareContext(ctx, listItemsByUser); err != nil {
 		return nil, fmt.Errorf("error preparing query ListItemsByUser: %w", err)
 	}
+	if q.listTagsStmt, err = db.PrepareContext(ctx, listTags); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTags: %w", err)
+	}
+	if q.listUserAttachmentsStmt, err = db.PrepareContext(ctx, listUserAttachments); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUserAttachments: %w", err)
+	}
 	if q.listUsersStmt, err = db.PrepareContext(ctx, listUsers); err != nil {
 		return nil, fmt.Errorf("error preparing query ListUsers: %w", err)
 	}
+	if q.removeItemDependencyStmt, err = db.PrepareContext(ctx, removeItemDependency); err != nil {
+		return nil, fmt.Errorf("error preparing query RemoveItemDependency: %w", err)
+	}
+	if q.removeItemTagStmt, err = db.PrepareContext(ctx, removeItemTag); err != nil {
+		return nil, fmt.Errorf("error preparing query RemoveItemTag: %w", err)
+	}
+	if q.removeItemTagsStmt, err = db.PrepareContext(ctx, removeItemTags); err != nil {
+		return nil, fmt.Errorf("error preparing query RemoveItemTags: %w", err)
+	}
+	if q.removeItemTemplateChecklistEntriesStmt, err = db.PrepareContext(ctx, removeItemTemplateChecklistEntries); err != nil {
+		return nil, fmt.Errorf("error preparing query RemoveItemTemplateChecklistEntries: %w", err)
+	}
+	if q.removeItemTemplateTagsStmt, err = db.PrepareContext(ctx, removeItemTemplateTags); err != nil {
+		return nil, fmt.Errorf("error preparing query RemoveItemTemplateTags: %w", err)
+	}
+	if q.setItemChecklistEntryPositionStmt, err = db.PrepareContext(ctx, setItemChecklistEntryPosition); err != nil {
+		return nil, fmt.Errorf("error preparing query SetItemChecklistEntryPosition: %w", err)
+	}
+	if q.setItemParentStmt, err = db.PrepareContext(ctx, setItemParent); err != nil {
+		return nil, fmt.Errorf("error preparing query SetItemParent: %w", err)
+	}
+	if q.setItemRankStmt, err = db.PrepareContext(ctx, setItemRank); err != nil {
+		return nil, fmt.Errorf("error preparing query SetItemRank: %w", err)
+	}
+	if q.setItemRecurrenceStmt, err = db.PrepareContext(ctx, setItemRecurrence); err != nil {
+		return nil, fmt.Errorf("error preparing query SetItemRecurrence: %w", err)
+	}
+	if q.takeItemRecurrenceStmt, err = db.PrepareContext(ctx, takeItemRecurrence); err != nil {
+		return nil, fmt.Errorf("error preparing query TakeItemRecurrence: %w", err)
+	}
 	if q.updateItemStmt, err = db.PrepareContext(ctx, updateItem); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateItem: %w", err)
 	}
+	if q.updateItemChecklistEntryStmt, err = db.PrepareContext(ctx, updateItemChecklistEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateItemChecklistEntry: %w", err)
+	}
+	if q.updateItemCommentStmt, err = db.PrepareContext(ctx, updateItemComment); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateItemComment: %w", err)
+	}
+	if q.updateItemTemplateStmt, err = db.PrepareContext(ctx, updateItemTemplate); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateItemTemplate: %w", err)
+	}
+	if q.updateTagStmt, err = db.PrepareContext(ctx, updateTag); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateTag: %w", err)
+	}
 	if q.updateUserStmt, err = db.PrepareContext(ctx, updateUser); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateUser: %w", err)
 	}
@@ -74,46 +251,196 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 
 func (q *Queries) Close() error {
 	var err error
+	if q.addItemDependencyStmt != nil {
+		if cerr := q.addItemDependencyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addItemDependencyStmt: %w", cerr)
+		}
+	}
+	if q.addItemTagStmt != nil {
+		if cerr := q.addItemTagStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addItemTagStmt: %w", cerr)
+		}
+	}
+	if q.addItemTemplateChecklistEntryStmt != nil {
+		if cerr := q.addItemTemplateChecklistEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addItemTemplateChecklistEntryStmt: %w", cerr)
+		}
+	}
+	if q.addItemTemplateTagStmt != nil {
+		if cerr := q.addItemTemplateTagStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addItemTemplateTagStmt: %w", cerr)
+		}
+	}
+	if q.countItemCommentsStmt != nil {
+		if cerr := q.countItemCommentsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countItemCommentsStmt: %w", cerr)
+		}
+	}
+	if q.countItemTemplatesStmt != nil {
+		if cerr := q.countItemTemplatesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countItemTemplatesStmt: %w", cerr)
+		}
+	}
 	if q.countItemsStmt != nil {
 		if cerr := q.countItemsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing countItemsStmt: %w", cerr)
 		}
 	}
+	if q.countItemsByRankStmt != nil {
+		if cerr := q.countItemsByRankStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countItemsByRankStmt: %w", cerr)
+		}
+	}
 	if q.countItemsByUserStmt != nil {
 		if cerr := q.countItemsByUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing countItemsByUserStmt: %w", cerr)
 		}
 	}
+	if q.countTagsStmt != nil {
+		if cerr := q.countTagsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countTagsStmt: %w", cerr)
+		}
+	}
 	if q.countUsersStmt != nil {
 		if cerr := q.countUsersStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing countUsersStmt: %w", cerr)
 		}
 	}
+	if q.createAttachmentStmt != nil {
+		if cerr := q.createAttachmentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createAttachmentStmt: %w", cerr)
+		}
+	}
 	if q.createItemStmt != nil {
 		if cerr := q.createItemStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createItemStmt: %w", cerr)
 		}
 	}
+	if q.createItemChecklistEntryStmt != nil {
+		if cerr := q.createItemChecklistEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createItemChecklistEntryStmt: %w", cerr)
+		}
+	}
+	if q.createItemCommentStmt != nil {
+		if cerr := q.createItemCommentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createItemCommentStmt: %w", cerr)
+		}
+	}
+	if q.createItemStatusChangeStmt != nil {
+		if cerr := q.createItemStatusChangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createItemStatusChangeStmt: %w", cerr)
+		}
+	}
+	if q.createItemTemplateStmt != nil {
+		if cerr := q.createItemTemplateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createItemTemplateStmt: %w", cerr)
+		}
+	}
+	if q.createTagStmt != nil {
+		if cerr := q.createTagStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTagStmt: %w", cerr)
+		}
+	}
 	if q.createUserStmt != nil {
 		if cerr := q.createUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createUserStmt: %w", cerr)
 		}
 	}
+	if q.deleteAttachmentStmt != nil {
+		if cerr := q.deleteAttachmentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteAttachmentStmt: %w", cerr)
+		}
+	}
 	if q.deleteItemStmt != nil {
 		if cerr := q.deleteItemStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteItemStmt: %w", cerr)
 		}
 	}
+	if q.deleteItemChecklistEntryStmt != nil {
+		if cerr := q.deleteItemChecklistEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteItemChecklistEntryStmt: %w", cerr)
+		}
+	}
+	if q.deleteItemCommentStmt != nil {
+		if cerr := q.deleteItemCommentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteItemCommentStmt: %w", cerr)
+		}
+	}
+	if q.deleteItemRecurrenceStmt != nil {
+		if cerr := q.deleteItemRecurrenceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteItemRecurrenceStmt: %w", cerr)
+		}
+	}
+	if q.deleteItemTemplateStmt != nil {
+		if cerr := q.deleteItemTemplateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteItemTemplateStmt: %w", cerr)
+		}
+	}
+	if q.deleteTagStmt != nil {
+		if cerr := q.deleteTagStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteTagStmt: %w", cerr)
+		}
+	}
 	if q.deleteUserStmt != nil {
 		if cerr := q.deleteUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteUserStmt: %w", cerr)
 		}
 	}
+	if q.getAttachmentStmt != nil {
+		if cerr := q.getAttachmentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getAttachmentStmt: %w", cerr)
+		}
+	}
 	if q.getItemStmt != nil {
 		if cerr := q.getItemStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getItemStmt: %w", cerr)
 		}
 	}
+	if q.getItemChecklistEntryStmt != nil {
+		if cerr := q.getItemChecklistEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getItemChecklistEntryStmt: %w", cerr)
+		}
+	}
+	if q.getItemCommentStmt != nil {
+		if cerr := q.getItemCommentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getItemCommentStmt: %w", cerr)
+		}
+	}
+	if q.getItemTemplateStmt != nil {
+		if cerr := q.getItemTemplateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getItemTemplateStmt: %w", cerr)
+		}
+	}
+	if q.getItemTemplateByNameStmt != nil {
+		if cerr := q.getItemTemplateByNameStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getItemTemplateByNameStmt: %w", cerr)
+		}
+	}
+	if q.getLastRankedItemStmt != nil {
+		if cerr := q.getLastRankedItemStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLastRankedItemStmt: %w", cerr)
+		}
+	}
+	if q.getNextRankedItemStmt != nil {
+		if cerr := q.getNextRankedItemStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getNextRankedItemStmt: %w", cerr)
+		}
+	}
+	if q.getPreviousRankedItemStmt != nil {
+		if cerr := q.getPreviousRankedItemStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPreviousRankedItemStmt: %w", cerr)
+		}
+	}
+	if q.getTagStmt != nil {
+		if cerr := q.getTagStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTagStmt: %w", cerr)
+		}
+	}
+	if q.getTagByNameStmt != nil {
+		if cerr := q.getTagByNameStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTagByNameStmt: %w", cerr)
+		}
+	}
 	if q.getUserStmt != nil {
 		if cerr := q.getUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getUserStmt: %w", cerr)
@@ -124,26 +451,171 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getUserByEmailStmt: %w", cerr)
 		}
 	}
+	if q.listAttachmentsStmt != nil {
+		if cerr := q.listAttachmentsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAttachmentsStmt: %w", cerr)
+		}
+	}
+	if q.listBlockedItemsStmt != nil {
+		if cerr := q.listBlockedItemsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listBlockedItemsStmt: %w", cerr)
+		}
+	}
+	if q.listChildItemsStmt != nil {
+		if cerr := q.listChildItemsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listChildItemsStmt: %w", cerr)
+		}
+	}
+	if q.listDueItemRecurrencesStmt != nil {
+		if cerr := q.listDueItemRecurrencesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listDueItemRecurrencesStmt: %w", cerr)
+		}
+	}
+	if q.listItemBlockersStmt != nil {
+		if cerr := q.listItemBlockersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listItemBlockersStmt: %w", cerr)
+		}
+	}
+	if q.listItemChecklistEntriesStmt != nil {
+		if cerr := q.listItemChecklistEntriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listItemChecklistEntriesStmt: %w", cerr)
+		}
+	}
+	if q.listItemCommentsStmt != nil {
+		if cerr := q.listItemCommentsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listItemCommentsStmt: %w", cerr)
+		}
+	}
+	if q.listItemStatusHistoryStmt != nil {
+		if cerr := q.listItemStatusHistoryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listItemStatusHistoryStmt: %w", cerr)
+		}
+	}
+	if q.listItemTagsStmt != nil {
+		if cerr := q.listItemTagsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listItemTagsStmt: %w", cerr)
+		}
+	}
+	if q.listItemTemplateChecklistEntriesStmt != nil {
+		if cerr := q.listItemTemplateChecklistEntriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listItemTemplateChecklistEntriesStmt: %w", cerr)
+		}
+	}
+	if q.listItemTemplateTagsStmt != nil {
+		if cerr := q.listItemTemplateTagsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listItemTemplateTagsStmt: %w", cerr)
+		}
+	}
+	if q.listItemTemplatesStmt != nil {
+		if cerr := q.listItemTemplatesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listItemTemplatesStmt: %w", cerr)
+		}
+	}
 	if q.listItemsStmt != nil {
 		if cerr := q.listItemsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listItemsStmt: %w", cerr)
 		}
 	}
+	if q.listItemsByRankStmt != nil {
+		if cerr := q.listItemsByRankStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listItemsByRankStmt: %w", cerr)
+		}
+	}
 	if q.listItemsByUserStmt != nil {
 		if cerr := q.listItemsByUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listItemsByUserStmt: %w", cerr)
 		}
 	}
+	if q.listTagsStmt != nil {
+		if cerr := q.listTagsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTagsStmt: %w", cerr)
+		}
+	}
+	if q.listUserAttachmentsStmt != nil {
+		if cerr := q.listUserAttachmentsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUserAttachmentsStmt: %w", cerr)
+		}
+	}
 	if q.listUsersStmt != nil {
 		if cerr := q.listUsersStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listUsersStmt: %w", cerr)
 		}
 	}
+	if q.removeItemDependencyStmt != nil {
+		if cerr := q.removeItemDependencyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing removeItemDependencyStmt: %w", cerr)
+		}
+	}
+	if q.removeItemTagStmt != nil {
+		if cerr := q.removeItemTagStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing removeItemTagStmt: %w", cerr)
+		}
+	}
+	if q.removeItemTagsStmt != nil {
+		if cerr := q.removeItemTagsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing removeItemTagsStmt: %w", cerr)
+		}
+	}
+	if q.removeItemTemplateChecklistEntriesStmt != nil {
+		if cerr := q.removeItemTemplateChecklistEntriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing removeItemTemplateChecklistEntriesStmt: %w", cerr)
+		}
+	}
+	if q.removeItemTemplateTagsStmt != nil {
+		if cerr := q.removeItemTemplateTagsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing removeItemTemplateTagsStmt: %w", cerr)
+		}
+	}
+	if q.setItemChecklistEntryPositionStmt != nil {
+		if cerr := q.setItemChecklistEntryPositionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setItemChecklistEntryPositionStmt: %w", cerr)
+		}
+	}
+	if q.setItemParentStmt != nil {
+		if cerr := q.setItemParentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setItemParentStmt: %w", cerr)
+		}
+	}
+	if q.setItemRankStmt != nil {
+		if cerr := q.setItemRankStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setItemRankStmt: %w", cerr)
+		}
+	}
+	if q.setItemRecurrenceStmt != nil {
+		if cerr := q.setItemRecurrenceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setItemRecurrenceStmt: %w", cerr)
+		}
+	}
+	if q.takeItemRecurrenceStmt != nil {
+		if cerr := q.takeItemRecurrenceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing takeItemRecurrenceStmt: %w", cerr)
+		}
+	}
 	if q.updateItemStmt != nil {
 		if cerr := q.updateItemStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateItemStmt: %w", cerr)
 		}
 	}
+	if q.updateItemChecklistEntryStmt != nil {
+		if cerr := q.updateItemChecklistEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateItemChecklistEntryStmt: %w", cerr)
+		}
+	}
+	if q.updateItemCommentStmt != nil {
+		if cerr := q.updateItemCommentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateItemCommentStmt: %w", cerr)
+		}
+	}
+	if q.updateItemTemplateStmt != nil {
+		if cerr := q.updateItemTemplateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateItemTemplateStmt: %w", cerr)
+		}
+	}
+	if q.updateTagStmt != nil {
+		if cerr := q.updateTagStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateTagStmt: %w", cerr)
+		}
+	}
 	if q.updateUserStmt != nil {
 		if cerr := q.updateUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateUserStmt: %w", cerr)
@@ -186,43 +658,161 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                   DBTX
-	tx                   *sql.Tx
-	countItemsStmt       *sql.Stmt
-	countItemsByUserStmt *sql.Stmt
-	countUsersStmt       *sql.Stmt
-	createItemStmt       *sql.Stmt
-	createUserStmt       *sql.Stmt
-	deleteItemStmt       *sql.Stmt
-	deleteUserStmt       *sql.Stmt
-	getItemStmt          *sql.Stmt
-	getUserStmt          *sql.Stmt
-	getUserByEmailStmt   *sql.Stmt
-	listItemsStmt        *sql.Stmt
-	listItemsByUserStmt  *sql.Stmt
-	listUsersStmt        *sql.Stmt
-	updateItemStmt       *sql.Stmt
-	updateUserStmt       *sql.Stmt
+	db                                     DBTX
+	tx                                     *sql.Tx
+	addItemDependencyStmt                  *sql.Stmt
+	addItemTagStmt                         *sql.Stmt
+	addItemTemplateChecklistEntryStmt      *sql.Stmt
+	addItemTemplateTagStmt                 *sql.Stmt
+	countItemCommentsStmt                  *sql.Stmt
+	countItemTemplatesStmt                 *sql.Stmt
+	countItemsStmt                         *sql.Stmt
+	countItemsByRankStmt                   *sql.Stmt
+	countItemsByUserStmt                   *sql.Stmt
+	countTagsStmt                          *sql.Stmt
+	countUsersStmt                         *sql.Stmt
+	createAttachmentStmt                   *sql.Stmt
+	createItemStmt                         *sql.Stmt
+	createItemChecklistEntryStmt           *sql.Stmt
+	createItemCommentStmt                  *sql.Stmt
+	createItemStatusChangeStmt             *sql.Stmt
+	createItemTemplateStmt                 *sql.Stmt
+	createTagStmt                          *sql.Stmt
+	createUserStmt                         *sql.Stmt
+	deleteAttachmentStmt                   *sql.Stmt
+	deleteItemStmt                         *sql.Stmt
+	deleteItemChecklistEntryStmt           *sql.Stmt
+	deleteItemCommentStmt                  *sql.Stmt
+	deleteItemRecurrenceStmt               *sql.Stmt
+	deleteItemTemplateStmt                 *sql.Stmt
+	deleteTagStmt                          *sql.Stmt
+	deleteUserStmt                         *sql.Stmt
+	getAttachmentStmt                      *sql.Stmt
+	getItemStmt                            *sql.Stmt
+	getItemChecklistEntryStmt              *sql.Stmt
+	getItemCommentStmt                     *sql.Stmt
+	getItemTemplateStmt                    *sql.Stmt
+	getItemTemplateByNameStmt              *sql.Stmt
+	getLastRankedItemStmt                  *sql.Stmt
+	getNextRankedItemStmt                  *sql.Stmt
+	getPreviousRankedItemStmt              *sql.Stmt
+	getTagStmt                             *sql.Stmt
+	getTagByNameStmt                       *sql.Stmt
+	getUserStmt                            *sql.Stmt
+	getUserByEmailStmt                     *sql.Stmt
+	listAttachmentsStmt                    *sql.Stmt
+	listBlockedItemsStmt                   *sql.Stmt
+	listChildItemsStmt                     *sql.Stmt
+	listDueItemRecurrencesStmt             *sql.Stmt
+	listItemBlockersStmt                   *sql.Stmt
+	listItemChecklistEntriesStmt           *sql.Stmt
+	listItemCommentsStmt                   *sql.Stmt
+	listItemStatusHistoryStmt              *sql.Stmt
+	listItemTagsStmt                       *sql.Stmt
+	listItemTemplateChecklistEntriesStmt   *sql.Stmt
+	listItemTemplateTagsStmt               *sql.Stmt
+	listItemTemplatesStmt                  *sql.Stmt
+	listItemsStmt                          *sql.Stmt
+	listItemsByRankStmt                    *sql.Stmt
+	listItemsByUserStmt                    *sql.Stmt
+	listTagsStmt                           *sql.Stmt
+	listUserAttachmentsStmt                *sql.Stmt
+	listUsersStmt                          *sql.Stmt
+	removeItemDependencyStmt               *sql.Stmt
+	removeItemTagStmt                      *sql.Stmt
+	removeItemTagsStmt                     *sql.Stmt
+	removeItemTemplateChecklistEntriesStmt *sql.Stmt
+	removeItemTemplateTagsStmt             *sql.Stmt
+	setItemChecklistEntryPositionStmt      *sql.Stmt
+	setItemParentStmt                      *sql.Stmt
+	setItemRankStmt                        *sql.Stmt
+	setItemRecurrenceStmt                  *sql.Stmt
+	takeItemRecurrenceStmt                 *sql.Stmt
+	updateItemStmt                         *sql.Stmt
+	updateItemChecklistEntryStmt           *sql.Stmt
+	updateItemCommentStmt                  *sql.Stmt
+	updateItemTemplateStmt                 *sql.Stmt
+	updateTagStmt                          *sql.Stmt
+	updateUserStmt                         *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                   tx,
-		tx:                   tx,
-		countItemsStmt:       q.countItemsStmt,
-		countItemsByUserStmt: q.countItemsByUserStmt,
-		countUsersStmt:       q.countUsersStmt,
-		createItemStmt:       q.createItemStmt,
-		createUserStmt:       q.createUserStmt,
-		deleteItemStmt:       q.deleteItemStmt,
-		deleteUserStmt:       q.deleteUserStmt,
-		getItemStmt:          q.getItemStmt,
-		getUserStmt:          q.getUserStmt,
-		getUserByEmailStmt:   q.getUserByEmailStmt,
-		listItemsStmt:        q.listItemsStmt,
-		listItemsByUserStmt:  q.listItemsByUserStmt,
-		listUsersStmt:        q.listUsersStmt,
-		updateItemStmt:       q.updateItemStmt,
-		updateUserStmt:       q.updateUserStmt,
+		db:                                     tx,
+		tx:                                     tx,
+		addItemDependencyStmt:                  q.addItemDependencyStmt,
+		addItemTagStmt:                         q.addItemTagStmt,
+		addItemTemplateChecklistEntryStmt:      q.addItemTemplateChecklistEntryStmt,
+		addItemTemplateTagStmt:                 q.addItemTemplateTagStmt,
+		countItemCommentsStmt:                  q.countItemCommentsStmt,
+		countItemTemplatesStmt:                 q.countItemTemplatesStmt,
+		countItemsStmt:                         q.countItemsStmt,
+		countItemsByRankStmt:                   q.countItemsByRankStmt,
+		countItemsByUserStmt:                   q.countItemsByUserStmt,
+		countTagsStmt:                          q.countTagsStmt,
+		countUsersStmt:                         q.countUsersStmt,
+		createAttachmentStmt:                   q.createAttachmentStmt,
+		createItemStmt:                         q.createItemStmt,
+		createItemChecklistEntryStmt:           q.createItemChecklistEntryStmt,
+		createItemCommentStmt:                  q.createItemCommentStmt,
+		createItemStatusChangeStmt:             q.createItemStatusChangeStmt,
+		createItemTemplateStmt:                 q.createItemTemplateStmt,
+		createTagStmt:                          q.createTagStmt,
+		createUserStmt:                         q.createUserStmt,
+		deleteAttachmentStmt:                   q.deleteAttachmentStmt,
+		deleteItemStmt:                         q.deleteItemStmt,
+		deleteItemChecklistEntryStmt:           q.deleteItemChecklistEntryStmt,
+		deleteItemCommentStmt:                  q.deleteItemCommentStmt,
+		deleteItemRecurrenceStmt:               q.deleteItemRecurrenceStmt,
+		deleteItemTemplateStmt:                 q.deleteItemTemplateStmt,
+		deleteTagStmt:                          q.deleteTagStmt,
+		deleteUserStmt:                         q.deleteUserStmt,
+		getAttachmentStmt:                      q.getAttachmentStmt,
+		getItemStmt:                            q.getItemStmt,
+		getItemChecklistEntryStmt:              q.getItemChecklistEntryStmt,
+		getItemCommentStmt:                     q.getItemCommentStmt,
+		getItemTemplateStmt:                    q.getItemTemplateStmt,
+		getItemTemplateByNameStmt:              q.getItemTemplateByNameStmt,
+		getLastRankedItemStmt:                  q.getLastRankedItemStmt,
+		getNextRankedItemStmt:                  q.getNextRankedItemStmt,
+		getPreviousRankedItemStmt:              q.getPreviousRankedItemStmt,
+		getTagStmt:                             q.getTagStmt,
+		getTagByNameStmt:                       q.getTagByNameStmt,
+		getUserStmt:                            q.getUserStmt,
+		getUserByEmailStmt:                     q.getUserByEmailStmt,
+		listAttachmentsStmt:                    q.listAttachmentsStmt,
+		listBlockedItemsStmt:                   q.listBlockedItemsStmt,
+		listChildItemsStmt:                     q.listChildItemsStmt,
+		listDueItemRecurrencesStmt:             q.listDueItemRecurrencesStmt,
+		listItemBlockersStmt:                   q.listItemBlockersStmt,
+		listItemChecklistEntriesStmt:           q.listItemChecklistEntriesStmt,
+		listItemCommentsStmt:                   q.listItemCommentsStmt,
+		listItemStatusHistoryStmt:              q.listItemStatusHistoryStmt,
+		listItemTagsStmt:                       q.listItemTagsStmt,
+		listItemTemplateChecklistEntriesStmt:   q.listItemTemplateChecklistEntriesStmt,
+		listItemTemplateTagsStmt:               q.listItemTemplateTagsStmt,
+		listItemTemplatesStmt:                  q.listItemTemplatesStmt,
+		listItemsStmt:                          q.listItemsStmt,
+		listItemsByRankStmt:                    q.listItemsByRankStmt,
+		listItemsByUserStmt:                    q.listItemsByUserStmt,
+		listTagsStmt:                           q.listTagsStmt,
+		listUserAttachmentsStmt:                q.listUserAttachmentsStmt,
+		listUsersStmt:                          q.listUsersStmt,
+		removeItemDependencyStmt:               q.removeItemDependencyStmt,
+		removeItemTagStmt:                      q.removeItemTagStmt,
+		removeItemTagsStmt:                     q.removeItemTagsStmt,
+		removeItemTemplateChecklistEntriesStmt: q.removeItemTemplateChecklistEntriesStmt,
+		removeItemTemplateTagsStmt:             q.removeItemTemplateTagsStmt,
+		setItemChecklistEntryPositionStmt:      q.setItemChecklistEntryPositionStmt,
+		setItemParentStmt:                      q.setItemParentStmt,
+		setItemRankStmt:                        q.setItemRankStmt,
+		setItemRecurrenceStmt:                  q.setItemRecurrenceStmt,
+		takeItemRecurrenceStmt:                 q.takeItemRecurrenceStmt,
+		updateItemStmt:                         q.updateItemStmt,
+		updateItemChecklistEntryStmt:           q.updateItemChecklistEntryStmt,
+		updateItemCommentStmt:                  q.updateItemCommentStmt,
+		updateItemTemplateStmt:                 q.updateItemTemplateStmt,
+		updateTagStmt:                          q.updateTagStmt,
+		updateUserStmt:                         q.updateUserStmt,
 	}
 }
@@ -0,0 +1,457 @@
+package store
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// The queries in this file filter on a variable number of values, which
+// sqlc cannot generate portably, so they are written by hand. They use ?
+// placeholders like the generated ones and run through q.db, so
+// store/postgres rewrites them the same way.
+
+// itemColumns lists the columns of items in Item field order.
+const itemColumns = "id, user_id, title, description, status, created_at, updated_at, due_at, priority, assignee_id, parent_id, rank"
+
+// ItemFilter selects items for ListFilteredItems and CountFilteredItems.
+// Empty fields do not filter.
+type ItemFilter struct {
+	UserID string
+	// Tags restricts items to those tagged with any of the named tags, or
+	// with all of them if AllTags is set.
+	Tags    []string
+	AllTags bool
+	// AssigneeID restricts items to those assigned to the user.
+	AssigneeID string
+	// Priorities restricts items to those with any of the priorities.
+	Priorities []string
+	// DueBefore restricts items to those due strictly before it.
+	DueBefore time.Time
+	// Open restricts items to those not completed.
+	Open bool
+}
+
+// Orders for ListFilteredItems.
+const (
+	// OrderCreated lists the newest items first.
+	OrderCreated = "created"
+	// OrderDue lists the items due soonest first, then those without a due
+	// date.
+	OrderDue = "due"
+	// OrderPriority lists the most urgent items first, each priority by
+	// due date.
+	OrderPriority = "priority"
+	// OrderRank lists items in the order users arranged them, lowest rank
+	// first.
+	OrderRank = "rank"
+)
+
+// itemOrders maps orders to their ORDER BY clauses. Each falls back to
+// creation order, newest first.
+var itemOrders = map[string]string{
+	OrderCreated:  "created_at DESC",
+	OrderDue:      "due_at IS NULL, due_at, created_at DESC",
+	OrderPriority: "CASE priority WHEN 'urgent' THEN 0 WHEN 'high' THEN 1 WHEN 'normal' THEN 2 ELSE 3 END, due_at IS NULL, due_at, created_at DESC",
+	OrderRank:     "rank, created_at DESC",
+}
+
+// where returns the WHERE clause for f, or "" if f selects every item.
+func (f ItemFilter) where() (string, []interface{}) {
+	var conds []string
+	var args []interface{}
+	if f.UserID != "" {
+		conds = append(conds, "user_id = ?")
+		args = append(args, f.UserID)
+	}
+	if len(f.Tags) > 0 {
+		names := dedupe(f.Tags)
+		cond := "id IN (SELECT item_tags.item_id FROM item_tags JOIN tags ON tags.id = item_tags.tag_id" +
+			" WHERE tags.name IN (" + placeholders(len(names)) + ")"
+		for _, name := range names {
+			args = append(args, name)
+		}
+		if f.AllTags {
+			cond += " GROUP BY item_tags.item_id HAVING COUNT(*) = ?"
+			args = append(args, len(names))
+		}
+		conds = append(conds, cond+")")
+	}
+	if f.AssigneeID != "" {
+		conds = append(conds, "assignee_id = ?")
+		args = append(args, f.AssigneeID)
+	}
+	if len(f.Priorities) > 0 {
+		priorities := dedupe(f.Priorities)
+		conds = append(conds, "priority IN ("+placeholders(len(priorities))+")")
+		for _, p := range priorities {
+			args = append(args, p)
+		}
+	}
+	if !f.DueBefore.IsZero() {
+		conds = append(conds, "due_at < ?")
+		args = append(args, f.DueBefore.UTC())
+	}
+	if f.Open {
+		conds = append(conds, "status <> 'completed'")
+	}
+	if len(conds) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+// ListFilteredItemsParams selects a page of items. Order is one of the
+// Order constants; empty means OrderCreated.
+type ListFilteredItemsParams struct {
+	Filter ItemFilter
+	Order  string
+	Limit  int64
+	Offset int64
+}
+
+// ListFilteredItems returns a page of the items matching the filter, in
+// the given order.
+func (q *Queries) ListFilteredItems(ctx context.Context, arg ListFilteredItemsParams) ([]Item, error) {
+	where, args := arg.Filter.where()
+	order, ok := itemOrders[arg.Order]
+	if !ok {
+		order = itemOrders[OrderCreated]
+	}
+	query := "SELECT " + itemColumns + " FROM items" + where + " ORDER BY " + order + " LIMIT ? OFFSET ?"
+	rows, err := q.query(ctx, nil, query, append(args, arg.Limit, arg.Offset)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Item
+	for rows.Next() {
+		var i Item
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DueAt,
+			&i.Priority,
+			&i.AssigneeID,
+			&i.ParentID,
+			&i.Rank,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// CountFilteredItems returns the number of items matching the filter.
+func (q *Queries) CountFilteredItems(ctx context.Context, filter ItemFilter) (int64, error) {
+	where, args := filter.where()
+	row := q.queryRow(ctx, nil, "SELECT COUNT(*) FROM items"+where, args...)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+// ItemTagName is a tag of an item, as returned by ListTagsForItems.
+type ItemTagName struct {
+	ItemID string `json:"item_id"`
+	Name   string `json:"name"`
+}
+
+// ListTagsForItems returns the tags of the given items, ordered by item and
+// tag name.
+func (q *Queries) ListTagsForItems(ctx context.Context, itemIDs []string) ([]ItemTagName, error) {
+	if len(itemIDs) == 0 {
+		return nil, nil
+	}
+	args := make([]interface{}, len(itemIDs))
+	for i, id := range itemIDs {
+		args[i] = id
+	}
+	query := "SELECT item_tags.item_id, tags.name FROM item_tags JOIN tags ON tags.id = item_tags.tag_id" +
+		" WHERE item_tags.item_id IN (" + placeholders(len(itemIDs)) + ") ORDER BY item_tags.item_id, tags.name"
+	rows, err := q.query(ctx, nil, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ItemTagName
+	for rows.Next() {
+		var i ItemTagName
+		if err := rows.Scan(&i.ItemID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// ItemCommentCount is the number of comments on an item, as returned by
+// CountCommentsForItems.
+type ItemCommentCount struct {
+	ItemID string `json:"item_id"`
+	Count  int64  `json:"count"`
+}
+
+// CountCommentsForItems returns the comment counts of the given items.
+// Items without comments are left out.
+func (q *Queries) CountCommentsForItems(ctx context.Context, itemIDs []string) ([]ItemCommentCount, error) {
+	if len(itemIDs) == 0 {
+		return nil, nil
+	}
+	args := make([]interface{}, len(itemIDs))
+	for i, id := range itemIDs {
+		args[i] = id
+	}
+	query := "SELECT item_id, COUNT(*) FROM item_comments" +
+		" WHERE item_id IN (" + placeholders(len(itemIDs)) + ") GROUP BY item_id"
+	rows, err := q.query(ctx, nil, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ItemCommentCount
+	for rows.Next() {
+		var i ItemCommentCount
+		if err := rows.Scan(&i.ItemID, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// ItemProgress counts an item's descendants, its children and their
+// children in turn, as returned by ProgressForItems, or its checklist
+// entries, as returned by ChecklistProgressForItems.
+type ItemProgress struct {
+	ItemID    string `json:"item_id"`
+	Total     int64  `json:"total"`
+	Completed int64  `json:"completed"`
+}
+
+// ProgressForItems returns how many descendants the given items have and
+// how many of them are completed. Items without children are left out.
+func (q *Queries) ProgressForItems(ctx context.Context, itemIDs []string) ([]ItemProgress, error) {
+	if len(itemIDs) == 0 {
+		return nil, nil
+	}
+	args := make([]interface{}, len(itemIDs))
+	for i, id := range itemIDs {
+		args[i] = id
+	}
+	// UNION rather than UNION ALL, so the recursion ends even if the
+	// parents somehow form a cycle
+	query := "WITH RECURSIVE descendants(root_id, id, status) AS (" +
+		" SELECT parent_id, id, status FROM items WHERE parent_id IN (" + placeholders(len(itemIDs)) + ")" +
+		" UNION SELECT descendants.root_id, items.id, items.status FROM items" +
+		" JOIN descendants ON items.parent_id = descendants.id)" +
+		" SELECT root_id, COUNT(*), COUNT(CASE WHEN status = 'completed' THEN 1 END)" +
+		" FROM descendants GROUP BY root_id"
+	rows, err := q.query(ctx, nil, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ItemProgress
+	for rows.Next() {
+		var i ItemProgress
+		if err := rows.Scan(&i.ItemID, &i.Total, &i.Completed); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// ChecklistProgressForItems returns how many checklist entries the given
+// items have and how many of them are done. Items without a checklist are
+// left out.
+func (q *Queries) ChecklistProgressForItems(ctx context.Context, itemIDs []string) ([]ItemProgress, error) {
+	if len(itemIDs) == 0 {
+		return nil, nil
+	}
+	args := make([]interface{}, len(itemIDs))
+	for i, id := range itemIDs {
+		args[i] = id
+	}
+	query := "SELECT item_id, COUNT(*), COUNT(CASE WHEN done THEN 1 END) FROM item_checklist_entries" +
+		" WHERE item_id IN (" + placeholders(len(itemIDs)) + ") GROUP BY item_id"
+	rows, err := q.query(ctx, nil, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ItemProgress
+	for rows.Next() {
+		var i ItemProgress
+		if err := rows.Scan(&i.ItemID, &i.Total, &i.Completed); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// ListRecurrencesForItems returns the recurrence rules of the given items.
+// Items that do not recur are left out.
+func (q *Queries) ListRecurrencesForItems(ctx context.Context, itemIDs []string) ([]ItemRecurrence, error) {
+	if len(itemIDs) == 0 {
+		return nil, nil
+	}
+	args := make([]interface{}, len(itemIDs))
+	for i, id := range itemIDs {
+		args[i] = id
+	}
+	query := "SELECT item_id, frequency, cron, starts_at, next_at, created_at, updated_at FROM item_recurrences" +
+		" WHERE item_id IN (" + placeholders(len(itemIDs)) + ")"
+	rows, err := q.query(ctx, nil, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ItemRecurrence
+	for rows.Next() {
+		var i ItemRecurrence
+		if err := rows.Scan(
+			&i.ItemID,
+			&i.Frequency,
+			&i.Cron,
+			&i.StartsAt,
+			&i.NextAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// ListTagsForItemTemplates returns the tags of the given item templates,
+// ordered by template and tag name.
+func (q *Queries) ListTagsForItemTemplates(ctx context.Context, templateIDs []string) ([]ItemTemplateTag, error) {
+	if len(templateIDs) == 0 {
+		return nil, nil
+	}
+	args := make([]interface{}, len(templateIDs))
+	for i, id := range templateIDs {
+		args[i] = id
+	}
+	query := "SELECT template_id, name FROM item_template_tags" +
+		" WHERE template_id IN (" + placeholders(len(templateIDs)) + ") ORDER BY template_id, name"
+	rows, err := q.query(ctx, nil, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ItemTemplateTag
+	for rows.Next() {
+		var i ItemTemplateTag
+		if err := rows.Scan(&i.TemplateID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// ListChecklistEntriesForItemTemplates returns the checklist entries of the
+// given item templates, ordered by template and position.
+func (q *Queries) ListChecklistEntriesForItemTemplates(ctx context.Context, templateIDs []string) ([]ItemTemplateChecklistEntry, error) {
+	if len(templateIDs) == 0 {
+		return nil, nil
+	}
+	args := make([]interface{}, len(templateIDs))
+	for i, id := range templateIDs {
+		args[i] = id
+	}
+	query := "SELECT template_id, position, text FROM item_template_checklist_entries" +
+		" WHERE template_id IN (" + placeholders(len(templateIDs)) + ") ORDER BY template_id, position"
+	rows, err := q.query(ctx, nil, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ItemTemplateChecklistEntry
+	for rows.Next() {
+		var i ItemTemplateChecklistEntry
+		if err := rows.Scan(&i.TemplateID, &i.Position, &i.Text); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// placeholders returns n comma-separated ? placeholders.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// dedupe returns values without repeats, keeping the first occurrence.
+func dedupe(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
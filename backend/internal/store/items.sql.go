@@ -21,6 +21,17 @@ func (q *Queries) CountItems(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const countItemsByRank = `-- name: CountItemsByRank :one
+SELECT COUNT(*) FROM items WHERE rank = ?
+`
+
+func (q *Queries) CountItemsByRank(ctx context.Context, rank string) (int64, error) {
+	row := q.queryRow(ctx, q.countItemsByRankStmt, countItemsByRank, rank)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countItemsByUser = `-- name: CountItemsByUser :one
 SELECT COUNT(*) FROM items WHERE user_id = ?
 `
@@ -33,9 +44,9 @@ func (q *Queries) CountItemsByUser(ctx context.Context, userID string) (int64, e
 }
 
 const createItem = `-- name: CreateItem :one
-INSERT INTO items (id, user_id, title, description, status, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
-RETURNING id, user_id, title, description, status, created_at, updated_at
+INSERT INTO items (id, user_id, title, description, status, due_at, priority, assignee_id, parent_id, rank, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+RETURNING id, user_id, title, description, status, created_at, updated_at, due_at, priority, assignee_id, parent_id, rank
 `
 
 type CreateItemParams struct {
@@ -44,6 +55,11 @@ type CreateItemParams struct {
 	Title       string         `json:"title"`
 	Description sql.NullString `json:"description"`
 	Status      string         `json:"status"`
+	DueAt       sql.NullTime   `json:"due_at"`
+	Priority    string         `json:"priority"`
+	AssigneeID  sql.NullString `json:"assignee_id"`
+	ParentID    sql.NullString `json:"parent_id"`
+	Rank        string         `json:"rank"`
 }
 
 func (q *Queries) CreateItem(ctx context.Context, arg CreateItemParams) (Item, error) {
@@ -53,6 +69,11 @@ func (q *Queries) CreateItem(ctx context.Context, arg CreateItemParams) (Item, e
 		arg.Title,
 		arg.Description,
 		arg.Status,
+		arg.DueAt,
+		arg.Priority,
+		arg.AssigneeID,
+		arg.ParentID,
+		arg.Rank,
 	)
 	var i Item
 	err := row.Scan(
@@ -63,6 +84,11 @@ func (q *Queries) CreateItem(ctx context.Context, arg CreateItemParams) (Item, e
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DueAt,
+		&i.Priority,
+		&i.AssigneeID,
+		&i.ParentID,
+		&i.Rank,
 	)
 	return i, err
 }
@@ -77,7 +103,7 @@ func (q *Queries) DeleteItem(ctx context.Context, id string) error {
 }
 
 const getItem = `-- name: GetItem :one
-SELECT id, user_id, title, description, status, created_at, updated_at FROM items WHERE id = ? LIMIT 1
+SELECT id, user_id, title, description, status, created_at, updated_at, due_at, priority, assignee_id, parent_id, rank FROM items WHERE id = ? LIMIT 1
 `
 
 func (q *Queries) GetItem(ctx context.Context, id string) (Item, error) {
@@ -91,12 +117,139 @@ func (q *Queries) GetItem(ctx context.Context, id string) (Item, error) {
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DueAt,
+		&i.Priority,
+		&i.AssigneeID,
+		&i.ParentID,
+		&i.Rank,
+	)
+	return i, err
+}
+
+const getLastRankedItem = `-- name: GetLastRankedItem :one
+SELECT id, user_id, title, description, status, created_at, updated_at, due_at, priority, assignee_id, parent_id, rank FROM items ORDER BY rank DESC LIMIT 1
+`
+
+func (q *Queries) GetLastRankedItem(ctx context.Context) (Item, error) {
+	row := q.queryRow(ctx, q.getLastRankedItemStmt, getLastRankedItem)
+	var i Item
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Description,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DueAt,
+		&i.Priority,
+		&i.AssigneeID,
+		&i.ParentID,
+		&i.Rank,
+	)
+	return i, err
+}
+
+const getNextRankedItem = `-- name: GetNextRankedItem :one
+SELECT id, user_id, title, description, status, created_at, updated_at, due_at, priority, assignee_id, parent_id, rank FROM items WHERE rank > ? AND id <> ? ORDER BY rank LIMIT 1
+`
+
+type GetNextRankedItemParams struct {
+	Rank string `json:"rank"`
+	ID   string `json:"id"`
+}
+
+func (q *Queries) GetNextRankedItem(ctx context.Context, arg GetNextRankedItemParams) (Item, error) {
+	row := q.queryRow(ctx, q.getNextRankedItemStmt, getNextRankedItem, arg.Rank, arg.ID)
+	var i Item
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Description,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DueAt,
+		&i.Priority,
+		&i.AssigneeID,
+		&i.ParentID,
+		&i.Rank,
+	)
+	return i, err
+}
+
+const getPreviousRankedItem = `-- name: GetPreviousRankedItem :one
+SELECT id, user_id, title, description, status, created_at, updated_at, due_at, priority, assignee_id, parent_id, rank FROM items WHERE rank < ? AND id <> ? ORDER BY rank DESC LIMIT 1
+`
+
+type GetPreviousRankedItemParams struct {
+	Rank string `json:"rank"`
+	ID   string `json:"id"`
+}
+
+func (q *Queries) GetPreviousRankedItem(ctx context.Context, arg GetPreviousRankedItemParams) (Item, error) {
+	row := q.queryRow(ctx, q.getPreviousRankedItemStmt, getPreviousRankedItem, arg.Rank, arg.ID)
+	var i Item
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Description,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DueAt,
+		&i.Priority,
+		&i.AssigneeID,
+		&i.ParentID,
+		&i.Rank,
 	)
 	return i, err
 }
 
+const listChildItems = `-- name: ListChildItems :many
+SELECT id, user_id, title, description, status, created_at, updated_at, due_at, priority, assignee_id, parent_id, rank FROM items WHERE parent_id = ? ORDER BY created_at, id
+`
+
+func (q *Queries) ListChildItems(ctx context.Context, parentID sql.NullString) ([]Item, error) {
+	rows, err := q.query(ctx, q.listChildItemsStmt, listChildItems, parentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Item
+	for rows.Next() {
+		var i Item
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DueAt,
+			&i.Priority,
+			&i.AssigneeID,
+			&i.ParentID,
+			&i.Rank,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listItems = `-- name: ListItems :many
-SELECT id, user_id, title, description, status, created_at, updated_at FROM items ORDER BY created_at DESC LIMIT ? OFFSET ?
+SELECT id, user_id, title, description, status, created_at, updated_at, due_at, priority, assignee_id, parent_id, rank FROM items ORDER BY created_at DESC LIMIT ? OFFSET ?
 `
 
 type ListItemsParams struct {
@@ -121,6 +274,51 @@ func (q *Queries) ListItems(ctx context.Context, arg ListItemsParams) ([]Item, e
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DueAt,
+			&i.Priority,
+			&i.AssigneeID,
+			&i.ParentID,
+			&i.Rank,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listItemsByRank = `-- name: ListItemsByRank :many
+SELECT id, user_id, title, description, status, created_at, updated_at, due_at, priority, assignee_id, parent_id, rank FROM items ORDER BY rank, created_at, id
+`
+
+func (q *Queries) ListItemsByRank(ctx context.Context) ([]Item, error) {
+	rows, err := q.query(ctx, q.listItemsByRankStmt, listItemsByRank)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Item
+	for rows.Next() {
+		var i Item
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DueAt,
+			&i.Priority,
+			&i.AssigneeID,
+			&i.ParentID,
+			&i.Rank,
 		); err != nil {
 			return nil, err
 		}
@@ -136,7 +334,7 @@ func (q *Queries) ListItems(ctx context.Context, arg ListItemsParams) ([]Item, e
 }
 
 const listItemsByUser = `-- name: ListItemsByUser :many
-SELECT id, user_id, title, description, status, created_at, updated_at FROM items WHERE user_id = ? ORDER BY created_at DESC LIMIT ? OFFSET ?
+SELECT id, user_id, title, description, status, created_at, updated_at, due_at, priority, assignee_id, parent_id, rank FROM items WHERE user_id = ? ORDER BY created_at DESC LIMIT ? OFFSET ?
 `
 
 type ListItemsByUserParams struct {
@@ -162,6 +360,11 @@ func (q *Queries) ListItemsByUser(ctx context.Context, arg ListItemsByUserParams
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DueAt,
+			&i.Priority,
+			&i.AssigneeID,
+			&i.ParentID,
+			&i.Rank,
 		); err != nil {
 			return nil, err
 		}
@@ -176,20 +379,73 @@ func (q *Queries) ListItemsByUser(ctx context.Context, arg ListItemsByUserParams
 	return items, nil
 }
 
+const setItemParent = `-- name: SetItemParent :one
+UPDATE items
+SET parent_id = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+RETURNING id, user_id, title, description, status, created_at, updated_at, due_at, priority, assignee_id, parent_id, rank
+`
+
+type SetItemParentParams struct {
+	ParentID sql.NullString `json:"parent_id"`
+	ID       string         `json:"id"`
+}
+
+func (q *Queries) SetItemParent(ctx context.Context, arg SetItemParentParams) (Item, error) {
+	row := q.queryRow(ctx, q.setItemParentStmt, setItemParent, arg.ParentID, arg.ID)
+	var i Item
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Description,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DueAt,
+		&i.Priority,
+		&i.AssigneeID,
+		&i.ParentID,
+		&i.Rank,
+	)
+	return i, err
+}
+
+const setItemRank = `-- name: SetItemRank :exec
+UPDATE items SET rank = ? WHERE id = ?
+`
+
+type SetItemRankParams struct {
+	Rank string `json:"rank"`
+	ID   string `json:"id"`
+}
+
+func (q *Queries) SetItemRank(ctx context.Context, arg SetItemRankParams) error {
+	_, err := q.exec(ctx, q.setItemRankStmt, setItemRank, arg.Rank, arg.ID)
+	return err
+}
+
 const updateItem = `-- name: UpdateItem :one
 UPDATE items 
 SET title = COALESCE(?, title),
     description = COALESCE(?, description),
     status = COALESCE(?, status),
+    due_at = ?,
+    priority = ?,
+    assignee_id = ?,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = ?
-RETURNING id, user_id, title, description, status, created_at, updated_at
+RETURNING id, user_id, title, description, status, created_at, updated_at, due_at, priority, assignee_id, parent_id, rank
 `
 
 type UpdateItemParams struct {
 	Title       string         `json:"title"`
 	Description sql.NullString `json:"description"`
 	Status      string         `json:"status"`
+	DueAt       sql.NullTime   `json:"due_at"`
+	Priority    string         `json:"priority"`
+	AssigneeID  sql.NullString `json:"assignee_id"`
 	ID          string         `json:"id"`
 }
 
@@ -198,6 +454,9 @@ func (q *Queries) UpdateItem(ctx context.Context, arg UpdateItemParams) (Item, e
 		arg.Title,
 		arg.Description,
 		arg.Status,
+		arg.DueAt,
+		arg.Priority,
+		arg.AssigneeID,
 		arg.ID,
 	)
 	var i Item
@@ -209,6 +468,11 @@ func (q *Queries) UpdateItem(ctx context.Context, arg UpdateItemParams) (Item, e
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DueAt,
+		&i.Priority,
+		&i.AssigneeID,
+		&i.ParentID,
+		&i.Rank,
 	)
 	return i, err
 }
@@ -6,8 +6,20 @@ package store
 
 import (
 	"database/sql"
+	"time"
 )
 
+type Attachment struct {
+	ID          string         `json:"id"`
+	ItemID      string         `json:"item_id"`
+	UploaderID  sql.NullString `json:"uploader_id"`
+	Filename    string         `json:"filename"`
+	ContentType string         `json:"content_type"`
+	SizeBytes   int64          `json:"size_bytes"`
+	StorageKey  string         `json:"storage_key"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
 type Item struct {
 	ID          string         `json:"id"`
 	UserID      string         `json:"user_id"`
@@ -16,6 +28,81 @@ type Item struct {
 	Status      string         `json:"status"`
 	CreatedAt   sql.NullTime   `json:"created_at"`
 	UpdatedAt   sql.NullTime   `json:"updated_at"`
+	DueAt       sql.NullTime   `json:"due_at"`
+	Priority    string         `json:"priority"`
+	AssigneeID  sql.NullString `json:"assignee_id"`
+	ParentID    sql.NullString `json:"parent_id"`
+	Rank        string         `json:"rank"`
+}
+
+type ItemChecklistEntry struct {
+	ID        string    `json:"id"`
+	ItemID    string    `json:"item_id"`
+	Text      string    `json:"text"`
+	Done      bool      `json:"done"`
+	Position  int64     `json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ItemComment struct {
+	ID        string         `json:"id"`
+	ItemID    string         `json:"item_id"`
+	AuthorID  sql.NullString `json:"author_id"`
+	Body      string         `json:"body"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+type ItemDependency struct {
+	ItemID    string       `json:"item_id"`
+	BlockerID string       `json:"blocker_id"`
+	CreatedAt sql.NullTime `json:"created_at"`
+}
+
+type ItemRecurrence struct {
+	ItemID    string    `json:"item_id"`
+	Frequency string    `json:"frequency"`
+	Cron      string    `json:"cron"`
+	StartsAt  time.Time `json:"starts_at"`
+	NextAt    time.Time `json:"next_at"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ItemStatusHistory struct {
+	ID         string         `json:"id"`
+	ItemID     string         `json:"item_id"`
+	FromStatus sql.NullString `json:"from_status"`
+	ToStatus   string         `json:"to_status"`
+	ActorID    sql.NullString `json:"actor_id"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+type ItemTag struct {
+	ItemID string `json:"item_id"`
+	TagID  string `json:"tag_id"`
+}
+
+type ItemTemplate struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Title       string         `json:"title"`
+	Description sql.NullString `json:"description"`
+	Status      string         `json:"status"`
+	CreatedAt   sql.NullTime   `json:"created_at"`
+	UpdatedAt   sql.NullTime   `json:"updated_at"`
+}
+
+type ItemTemplateChecklistEntry struct {
+	TemplateID string `json:"template_id"`
+	Position   int64  `json:"position"`
+	Text       string `json:"text"`
+}
+
+type ItemTemplateTag struct {
+	TemplateID string `json:"template_id"`
+	Name       string `json:"name"`
 }
 
 type SchemaMigration struct {
@@ -23,6 +110,13 @@ type SchemaMigration struct {
 	AppliedAt sql.NullTime `json:"applied_at"`
 }
 
+type Tag struct {
+	ID        string       `json:"id"`
+	Name      string       `json:"name"`
+	CreatedAt sql.NullTime `json:"created_at"`
+	UpdatedAt sql.NullTime `json:"updated_at"`
+}
+
 type User struct {
 	ID        string       `json:"id"`
 	Email     string       `json:"email"`
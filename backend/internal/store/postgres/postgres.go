@@ -0,0 +1,100 @@
+// Package postgres implements store.Store for PostgreSQL.
+//
+// The queries in query/*.sql are written in SQL that SQLite and Postgres both
+// accept and differ only in placeholder syntax. Rather than generating and
+// maintaining a second copy, Queries runs the generated store.Queries over a
+// DBTX that rewrites SQLite's ? placeholders to Postgres' $1, $2, ... form.
+// Queries that genuinely need Postgres-specific SQL can be overridden with a
+// method on Queries.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/keel/api/internal/store"
+)
+
+// Queries runs the store queries against Postgres.
+type Queries struct {
+	*store.Queries
+}
+
+// New creates Queries backed by db.
+func New(db store.DBTX) *Queries {
+	return &Queries{Queries: store.New(rebindDB{db: db})}
+}
+
+// WithTx returns Queries bound to tx. It shadows store.Queries.WithTx, which
+// would bypass placeholder rewriting.
+func (q *Queries) WithTx(tx *sql.Tx) *Queries {
+	return New(tx)
+}
+
+// InTx implements store.Store.
+func (q *Queries) InTx(tx *sql.Tx) store.Store {
+	return q.WithTx(tx)
+}
+
+var _ store.Store = (*Queries)(nil)
+
+// rebindDB rewrites placeholders before passing queries to db.
+type rebindDB struct {
+	db store.DBTX
+}
+
+func (r rebindDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return r.db.ExecContext(ctx, Rebind(query), args...)
+}
+
+func (r rebindDB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return r.db.PrepareContext(ctx, Rebind(query))
+}
+
+func (r rebindDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return r.db.QueryContext(ctx, Rebind(query), args...)
+}
+
+func (r rebindDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return r.db.QueryRowContext(ctx, Rebind(query), args...)
+}
+
+// Rebind replaces each ? placeholder in query with $1, $2, ... in order.
+// Question marks inside string literals, quoted identifiers and comments are
+// left untouched.
+func Rebind(query string) string {
+	var b strings.Builder
+	b.Grow(len(query) + 8)
+
+	n := 0
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"':
+			end := strings.IndexByte(query[i+1:], c)
+			if end < 0 {
+				b.WriteString(query[i:])
+				return b.String()
+			}
+			b.WriteString(query[i : i+end+2])
+			i += end + 1
+		case c == '-' && i+1 < len(query) && query[i+1] == '-':
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				b.WriteString(query[i:])
+				return b.String()
+			}
+			b.WriteString(query[i : i+end+1])
+			i += end
+		case c == '?':
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
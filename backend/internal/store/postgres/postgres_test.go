@@ -0,0 +1,19 @@
+package postgres
+
+import "testing"
+
+func TestRebind(t *testing.T) {
+	cases := []struct{ in, want string }{
+		{"SELECT * FROM items WHERE id = ? LIMIT 1", "SELECT * FROM items WHERE id = $1 LIMIT 1"},
+		{"LIMIT ? OFFSET ?", "LIMIT $1 OFFSET $2"},
+		{"-- name: Get :one?\nSELECT ? FROM t", "-- name: Get :one?\nSELECT $1 FROM t"},
+		{"SELECT '?' , \"a?\", ?", "SELECT '?' , \"a?\", $1"},
+		{"SELECT 'it''s ?', ?", "SELECT 'it''s ?', $1"},
+		{"no placeholders", "no placeholders"},
+	}
+	for _, c := range cases {
+		if got := Rebind(c.in); got != c.want {
+			t.Errorf("Rebind(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
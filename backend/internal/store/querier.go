@@ -6,23 +6,84 @@ package store
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
 type Querier interface {
+	AddItemDependency(ctx context.Context, arg AddItemDependencyParams) error
+	AddItemTag(ctx context.Context, arg AddItemTagParams) error
+	AddItemTemplateChecklistEntry(ctx context.Context, arg AddItemTemplateChecklistEntryParams) error
+	AddItemTemplateTag(ctx context.Context, arg AddItemTemplateTagParams) error
+	CountItemComments(ctx context.Context, itemID string) (int64, error)
+	CountItemTemplates(ctx context.Context) (int64, error)
 	CountItems(ctx context.Context) (int64, error)
+	CountItemsByRank(ctx context.Context, rank string) (int64, error)
 	CountItemsByUser(ctx context.Context, userID string) (int64, error)
+	CountTags(ctx context.Context) (int64, error)
 	CountUsers(ctx context.Context) (int64, error)
+	CreateAttachment(ctx context.Context, arg CreateAttachmentParams) (Attachment, error)
 	CreateItem(ctx context.Context, arg CreateItemParams) (Item, error)
+	CreateItemChecklistEntry(ctx context.Context, arg CreateItemChecklistEntryParams) (ItemChecklistEntry, error)
+	CreateItemComment(ctx context.Context, arg CreateItemCommentParams) (ItemComment, error)
+	CreateItemStatusChange(ctx context.Context, arg CreateItemStatusChangeParams) (ItemStatusHistory, error)
+	CreateItemTemplate(ctx context.Context, arg CreateItemTemplateParams) (ItemTemplate, error)
+	CreateTag(ctx context.Context, arg CreateTagParams) (Tag, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	DeleteAttachment(ctx context.Context, id string) error
 	DeleteItem(ctx context.Context, id string) error
+	DeleteItemChecklistEntry(ctx context.Context, id string) error
+	DeleteItemComment(ctx context.Context, id string) error
+	DeleteItemRecurrence(ctx context.Context, itemID string) error
+	DeleteItemTemplate(ctx context.Context, id string) error
+	DeleteTag(ctx context.Context, id string) error
 	DeleteUser(ctx context.Context, id string) error
+	GetAttachment(ctx context.Context, arg GetAttachmentParams) (Attachment, error)
 	GetItem(ctx context.Context, id string) (Item, error)
+	GetItemChecklistEntry(ctx context.Context, arg GetItemChecklistEntryParams) (ItemChecklistEntry, error)
+	GetItemComment(ctx context.Context, arg GetItemCommentParams) (ItemComment, error)
+	GetItemTemplate(ctx context.Context, id string) (ItemTemplate, error)
+	GetItemTemplateByName(ctx context.Context, name string) (ItemTemplate, error)
+	GetLastRankedItem(ctx context.Context) (Item, error)
+	GetNextRankedItem(ctx context.Context, arg GetNextRankedItemParams) (Item, error)
+	GetPreviousRankedItem(ctx context.Context, arg GetPreviousRankedItemParams) (Item, error)
+	GetTag(ctx context.Context, id string) (Tag, error)
+	GetTagByName(ctx context.Context, name string) (Tag, error)
 	GetUser(ctx context.Context, id string) (User, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
+	ListAttachments(ctx context.Context, itemID string) ([]Attachment, error)
+	ListBlockedItems(ctx context.Context, blockerID string) ([]Item, error)
+	ListChildItems(ctx context.Context, parentID sql.NullString) ([]Item, error)
+	ListDueItemRecurrences(ctx context.Context, nextAt time.Time) ([]ItemRecurrence, error)
+	ListItemBlockers(ctx context.Context, itemID string) ([]Item, error)
+	ListItemChecklistEntries(ctx context.Context, itemID string) ([]ItemChecklistEntry, error)
+	ListItemComments(ctx context.Context, arg ListItemCommentsParams) ([]ItemComment, error)
+	ListItemStatusHistory(ctx context.Context, itemID string) ([]ItemStatusHistory, error)
+	ListItemTags(ctx context.Context, itemID string) ([]Tag, error)
+	ListItemTemplateChecklistEntries(ctx context.Context, templateID string) ([]ItemTemplateChecklistEntry, error)
+	ListItemTemplateTags(ctx context.Context, templateID string) ([]ItemTemplateTag, error)
+	ListItemTemplates(ctx context.Context, arg ListItemTemplatesParams) ([]ItemTemplate, error)
 	ListItems(ctx context.Context, arg ListItemsParams) ([]Item, error)
+	ListItemsByRank(ctx context.Context) ([]Item, error)
 	ListItemsByUser(ctx context.Context, arg ListItemsByUserParams) ([]Item, error)
+	ListTags(ctx context.Context, arg ListTagsParams) ([]Tag, error)
+	ListUserAttachments(ctx context.Context, userID string) ([]Attachment, error)
 	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
+	RemoveItemDependency(ctx context.Context, arg RemoveItemDependencyParams) error
+	RemoveItemTag(ctx context.Context, arg RemoveItemTagParams) error
+	RemoveItemTags(ctx context.Context, itemID string) error
+	RemoveItemTemplateChecklistEntries(ctx context.Context, templateID string) error
+	RemoveItemTemplateTags(ctx context.Context, templateID string) error
+	SetItemChecklistEntryPosition(ctx context.Context, arg SetItemChecklistEntryPositionParams) error
+	SetItemParent(ctx context.Context, arg SetItemParentParams) (Item, error)
+	SetItemRank(ctx context.Context, arg SetItemRankParams) error
+	SetItemRecurrence(ctx context.Context, arg SetItemRecurrenceParams) (ItemRecurrence, error)
+	TakeItemRecurrence(ctx context.Context, itemID string) (ItemRecurrence, error)
 	UpdateItem(ctx context.Context, arg UpdateItemParams) (Item, error)
+	UpdateItemChecklistEntry(ctx context.Context, arg UpdateItemChecklistEntryParams) (ItemChecklistEntry, error)
+	UpdateItemComment(ctx context.Context, arg UpdateItemCommentParams) (ItemComment, error)
+	UpdateItemTemplate(ctx context.Context, arg UpdateItemTemplateParams) (ItemTemplate, error)
+	UpdateTag(ctx context.Context, arg UpdateTagParams) (Tag, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
 }
 
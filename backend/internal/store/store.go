@@ -0,0 +1,34 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Store is the data access interface services depend on. It is implemented
+// by the sqlc-generated Queries in this package (SQLite) and by
+// store/postgres.Queries.
+type Store interface {
+	Querier
+
+	// Hand-written queries, see item_filter.go.
+	ListFilteredItems(ctx context.Context, arg ListFilteredItemsParams) ([]Item, error)
+	CountFilteredItems(ctx context.Context, filter ItemFilter) (int64, error)
+	ListTagsForItems(ctx context.Context, itemIDs []string) ([]ItemTagName, error)
+	CountCommentsForItems(ctx context.Context, itemIDs []string) ([]ItemCommentCount, error)
+	ProgressForItems(ctx context.Context, itemIDs []string) ([]ItemProgress, error)
+	ChecklistProgressForItems(ctx context.Context, itemIDs []string) ([]ItemProgress, error)
+	ListRecurrencesForItems(ctx context.Context, itemIDs []string) ([]ItemRecurrence, error)
+	ListTagsForItemTemplates(ctx context.Context, templateIDs []string) ([]ItemTemplateTag, error)
+	ListChecklistEntriesForItemTemplates(ctx context.Context, templateIDs []string) ([]ItemTemplateChecklistEntry, error)
+
+	// InTx returns a Store whose queries run inside tx.
+	InTx(tx *sql.Tx) Store
+}
+
+// InTx implements Store.
+func (q *Queries) InTx(tx *sql.Tx) Store {
+	return q.WithTx(tx)
+}
+
+var _ Store = (*Queries)(nil)
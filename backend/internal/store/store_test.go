@@ -0,0 +1,195 @@
+package store_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keel/api/internal/database"
+	"github.com/keel/api/internal/store"
+	"github.com/keel/api/internal/store/storetest"
+)
+
+func TestUsersAndItems(t *testing.T) {
+	storetest.ForEachDialect(t, func(t *testing.T, _ *database.DB, s store.Store) {
+		ctx := context.Background()
+
+		user, err := s.CreateUser(ctx, store.CreateUserParams{ID: "u1", Email: "a@example.com", Name: "Ada", Role: "admin"})
+		if err != nil {
+			t.Fatalf("CreateUser: %v", err)
+		}
+		if !user.CreatedAt.Valid {
+			t.Error("CreatedAt should be set")
+		}
+
+		byEmail, err := s.GetUserByEmail(ctx, "a@example.com")
+		if err != nil || byEmail.ID != "u1" {
+			t.Fatalf("GetUserByEmail = %+v, %v", byEmail, err)
+		}
+
+		if _, err := s.GetUser(ctx, "missing"); !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("GetUser(missing) error = %v, want sql.ErrNoRows", err)
+		}
+
+		updated, err := s.UpdateUser(ctx, store.UpdateUserParams{ID: "u1", Email: "a@example.com", Name: "Ada L.", Role: "user"})
+		if err != nil || updated.Name != "Ada L." || updated.Role != "user" {
+			t.Fatalf("UpdateUser = %+v, %v", updated, err)
+		}
+
+		for i, title := range []string{"first", "second"} {
+			_, err := s.CreateItem(ctx, store.CreateItemParams{
+				ID:          fmt.Sprintf("i%d", i),
+				UserID:      "u1",
+				Title:       title,
+				Description: sql.NullString{String: "desc", Valid: true},
+				Status:      "pending",
+				Priority:    "normal",
+			})
+			if err != nil {
+				t.Fatalf("CreateItem: %v", err)
+			}
+		}
+
+		items, err := s.ListItemsByUser(ctx, store.ListItemsByUserParams{UserID: "u1", Limit: 10, Offset: 0})
+		if err != nil || len(items) != 2 {
+			t.Fatalf("ListItemsByUser = %d items, %v", len(items), err)
+		}
+		count, err := s.CountItemsByUser(ctx, "u1")
+		if err != nil || count != 2 {
+			t.Fatalf("CountItemsByUser = %d, %v", count, err)
+		}
+
+		item, err := s.UpdateItem(ctx, store.UpdateItemParams{ID: "i0", Title: "renamed", Description: sql.NullString{}, Status: "completed", Priority: "normal"})
+		if err != nil || item.Title != "renamed" || item.Status != "completed" {
+			t.Fatalf("UpdateItem = %+v, %v", item, err)
+		}
+
+		// Deleting the user cascades to their items.
+		if err := s.DeleteUser(ctx, "u1"); err != nil {
+			t.Fatalf("DeleteUser: %v", err)
+		}
+		count, err = s.CountItems(ctx)
+		if err != nil || count != 0 {
+			t.Fatalf("CountItems after cascade = %d, %v", count, err)
+		}
+	})
+}
+
+func TestFilteredItems(t *testing.T) {
+	storetest.ForEachDialect(t, func(t *testing.T, _ *database.DB, s store.Store) {
+		ctx := context.Background()
+
+		for _, id := range []string{"u1", "u2"} {
+			if _, err := s.CreateUser(ctx, store.CreateUserParams{ID: id, Email: id + "@example.com", Name: id, Role: "user"}); err != nil {
+				t.Fatalf("CreateUser: %v", err)
+			}
+		}
+		for _, name := range []string{"bug", "ui"} {
+			if _, err := s.CreateTag(ctx, store.CreateTagParams{ID: name, Name: name}); err != nil {
+				t.Fatalf("CreateTag: %v", err)
+			}
+		}
+		// i0: bug, ui, due 2020, assigned to u2; i1: bug, urgent, due 2030;
+		// i2: none, owned by u2, completed, due 2019.
+		items := map[string][]string{"i0": {"bug", "ui"}, "i1": {"bug"}, "i2": nil}
+		past := sql.NullTime{Time: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+		params := map[string]store.CreateItemParams{
+			"i0": {UserID: "u1", Status: "pending", Priority: "normal", DueAt: past, AssigneeID: sql.NullString{String: "u2", Valid: true}},
+			"i1": {UserID: "u1", Status: "pending", Priority: "urgent", DueAt: sql.NullTime{Time: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true}},
+			"i2": {UserID: "u2", Status: "completed", Priority: "normal", DueAt: sql.NullTime{Time: past.Time.AddDate(-1, 0, 0), Valid: true}},
+		}
+		for id, tags := range items {
+			p := params[id]
+			p.ID, p.Title = id, id
+			if _, err := s.CreateItem(ctx, p); err != nil {
+				t.Fatalf("CreateItem: %v", err)
+			}
+			for _, tag := range tags {
+				if err := s.AddItemTag(ctx, store.AddItemTagParams{ItemID: id, TagID: tag}); err != nil {
+					t.Fatalf("AddItemTag: %v", err)
+				}
+			}
+		}
+
+		tests := []struct {
+			name   string
+			filter store.ItemFilter
+			want   []string
+		}{
+			{"no filter", store.ItemFilter{}, []string{"i0", "i1", "i2"}},
+			{"user", store.ItemFilter{UserID: "u2"}, []string{"i2"}},
+			{"any tag", store.ItemFilter{Tags: []string{"ui", "bug"}}, []string{"i0", "i1"}},
+			{"all tags", store.ItemFilter{Tags: []string{"ui", "bug", "ui"}, AllTags: true}, []string{"i0"}},
+			{"unknown tag", store.ItemFilter{Tags: []string{"bug", "missing"}, AllTags: true}, nil},
+			{"user and tag", store.ItemFilter{UserID: "u2", Tags: []string{"bug"}}, nil},
+			{"assignee", store.ItemFilter{AssigneeID: "u2"}, []string{"i0"}},
+			{"priorities", store.ItemFilter{Priorities: []string{"urgent", "low"}}, []string{"i1"}},
+			{"due before", store.ItemFilter{DueBefore: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}, []string{"i0", "i2"}},
+			{"open and due before", store.ItemFilter{Open: true, DueBefore: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}, []string{"i0"}},
+		}
+		for _, tt := range tests {
+			got, err := s.ListFilteredItems(ctx, store.ListFilteredItemsParams{Filter: tt.filter, Limit: 10})
+			if err != nil {
+				t.Fatalf("%s: ListFilteredItems: %v", tt.name, err)
+			}
+			var ids []string
+			for _, item := range got {
+				ids = append(ids, item.ID)
+			}
+			slices.Sort(ids)
+			if !slices.Equal(ids, tt.want) {
+				t.Errorf("%s: items = %v, want %v", tt.name, ids, tt.want)
+			}
+			count, err := s.CountFilteredItems(ctx, tt.filter)
+			if err != nil || count != int64(len(tt.want)) {
+				t.Errorf("%s: CountFilteredItems = %d, %v; want %d", tt.name, count, err, len(tt.want))
+			}
+		}
+
+		for order, want := range map[string][]string{store.OrderDue: {"i2", "i0", "i1"}, store.OrderPriority: {"i1", "i2", "i0"}} {
+			got, err := s.ListFilteredItems(ctx, store.ListFilteredItemsParams{Order: order, Limit: 10})
+			if err != nil {
+				t.Fatalf("ListFilteredItems(%s): %v", order, err)
+			}
+			var ids []string
+			for _, item := range got {
+				ids = append(ids, item.ID)
+			}
+			if !slices.Equal(ids, want) {
+				t.Errorf("order %s: items = %v, want %v", order, ids, want)
+			}
+		}
+
+		tags, err := s.ListTagsForItems(ctx, []string{"i1", "i0", "i2"})
+		if err != nil {
+			t.Fatalf("ListTagsForItems: %v", err)
+		}
+		want := []store.ItemTagName{{ItemID: "i0", Name: "bug"}, {ItemID: "i0", Name: "ui"}, {ItemID: "i1", Name: "bug"}}
+		if !slices.Equal(tags, want) {
+			t.Errorf("ListTagsForItems = %+v, want %+v", tags, want)
+		}
+
+		for i, itemID := range []string{"i0", "i0", "i2"} {
+			now := time.Now().UTC()
+			if _, err := s.CreateItemComment(ctx, store.CreateItemCommentParams{
+				ID: fmt.Sprintf("c%d", i), ItemID: itemID, Body: "hi", CreatedAt: now, UpdatedAt: now,
+			}); err != nil {
+				t.Fatalf("CreateItemComment: %v", err)
+			}
+		}
+		counts, err := s.CountCommentsForItems(ctx, []string{"i0", "i1", "i2"})
+		if err != nil {
+			t.Fatalf("CountCommentsForItems: %v", err)
+		}
+		slices.SortFunc(counts, func(a, b store.ItemCommentCount) int { return strings.Compare(a.ItemID, b.ItemID) })
+		wantCounts := []store.ItemCommentCount{{ItemID: "i0", Count: 2}, {ItemID: "i2", Count: 1}}
+		if !slices.Equal(counts, wantCounts) {
+			t.Errorf("CountCommentsForItems = %+v, want %+v", counts, wantCounts)
+		}
+	})
+}
@@ -0,0 +1,43 @@
+package storetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/keel/api/internal/store"
+)
+
+// CreateUser inserts a user with a unique email and fails the test on error.
+func CreateUser(t *testing.T, s store.Store) store.User {
+	t.Helper()
+	id := uuid.New().String()
+	user, err := s.CreateUser(context.Background(), store.CreateUserParams{
+		ID:    id,
+		Email: id + "@example.com",
+		Name:  "User " + id[:8],
+		Role:  "user",
+	})
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	return user
+}
+
+// CreateItem inserts a pending item owned by a new user and fails the test
+// on error.
+func CreateItem(t *testing.T, s store.Store) store.Item {
+	t.Helper()
+	id := uuid.New().String()
+	item, err := s.CreateItem(context.Background(), store.CreateItemParams{
+		ID:       id,
+		UserID:   CreateUser(t, s).ID,
+		Title:    "Item " + id[:8],
+		Status:   "pending",
+		Priority: "normal",
+	})
+	if err != nil {
+		t.Fatalf("create item: %v", err)
+	}
+	return item
+}
@@ -0,0 +1,26 @@
+package storetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/keel/api/internal/store"
+)
+
+// CreateItemTemplate inserts an item template with its required fields set and fails the
+// test on error.
+func CreateItemTemplate(t *testing.T, s store.Store) store.ItemTemplate {
+	t.Helper()
+	id := uuid.New().String()
+	itemTemplate, err := s.CreateItemTemplate(context.Background(), store.CreateItemTemplateParams{
+		ID:     id,
+		Name:   "name " + id,
+		Title:  "title " + id,
+		Status: "pending",
+	})
+	if err != nil {
+		t.Fatalf("create item template: %v", err)
+	}
+	return itemTemplate
+}
@@ -0,0 +1,90 @@
+// Package storetest opens freshly migrated databases for tests.
+package storetest
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/keel/api/internal/config"
+	"github.com/keel/api/internal/database"
+	"github.com/keel/api/internal/store"
+	"github.com/keel/api/internal/store/postgres"
+	"github.com/keel/api/migrations"
+)
+
+// ForEachDialect runs fn against a freshly migrated database for every
+// available engine. SQLite always runs in memory; Postgres runs when
+// TEST_POSTGRES_URL is set (see `make test-api-postgres`), each test in its
+// own schema.
+func ForEachDialect(t *testing.T, fn func(t *testing.T, db *database.DB, s store.Store)) {
+	t.Run("sqlite", func(t *testing.T) {
+		db, s := Open(t, ":memory:")
+		fn(t, db, s)
+	})
+	t.Run("postgres", func(t *testing.T) {
+		base := os.Getenv("TEST_POSTGRES_URL")
+		if base == "" {
+			t.Skip("TEST_POSTGRES_URL not set")
+		}
+		db, s := Open(t, postgresSchemaURL(t, base))
+		fn(t, db, s)
+	})
+}
+
+// OpenSQLite returns a migrated in-memory SQLite database.
+func OpenSQLite(t *testing.T) (*database.DB, store.Store) {
+	return Open(t, ":memory:")
+}
+
+// Open opens and migrates the database at rawURL, closing it when the test
+// ends.
+func Open(t *testing.T, rawURL string) (*database.DB, store.Store) {
+	t.Helper()
+	cfg := config.Default().Database
+	cfg.URL = rawURL
+
+	db, err := database.Open(cfg)
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	if err := database.RunMigrations(db.Writer, migrations.FS, string(db.Dialect)); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	if db.Dialect == database.Postgres {
+		return db, postgres.New(db)
+	}
+	return db, store.New(db)
+}
+
+// postgresSchemaURL creates a throwaway schema and returns base with its
+// search_path pointed at it.
+func postgresSchemaURL(t *testing.T, base string) string {
+	t.Helper()
+	admin, err := sql.Open("pgx", base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = admin.Close() })
+
+	schema := fmt.Sprintf("test_%d", time.Now().UnixNano())
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() { _, _ = admin.Exec("DROP SCHEMA " + schema + " CASCADE") })
+
+	u, err := url.Parse(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	q.Set("search_path", schema)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
@@ -2,5 +2,9 @@ package migrations
 
 import "embed"
 
-//go:embed *.sql
+// FS holds the migrations for every supported dialect, one directory per
+// dialect (sqlite/, postgres/). Each directory must contain the same
+// numbered migrations.
+//
+//go:embed sqlite/*.sql postgres/*.sql
 var FS embed.FS
@@ -0,0 +1,12 @@
+-- Create item status history table
+CREATE TABLE IF NOT EXISTS item_status_history (
+    id TEXT PRIMARY KEY,
+    item_id TEXT NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    from_status TEXT,
+    to_status TEXT NOT NULL,
+    actor_id TEXT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Index for listing an item's history in order
+CREATE INDEX IF NOT EXISTS idx_item_status_history_item_id ON item_status_history(item_id, created_at);
@@ -0,0 +1,8 @@
+-- Add due dates, priorities and assignees to items
+ALTER TABLE items ADD COLUMN due_at TIMESTAMPTZ;
+ALTER TABLE items ADD COLUMN priority TEXT NOT NULL DEFAULT 'normal' CHECK(priority IN ('low', 'normal', 'high', 'urgent'));
+ALTER TABLE items ADD COLUMN assignee_id TEXT REFERENCES users(id) ON DELETE SET NULL;
+
+-- Indexes for the due date and assignee filters
+CREATE INDEX IF NOT EXISTS idx_items_due_at ON items(due_at);
+CREATE INDEX IF NOT EXISTS idx_items_assignee_id ON items(assignee_id);
@@ -0,0 +1,15 @@
+-- Create item_recurrences table: the rule for an item that recurs. The row
+-- belongs to the latest occurrence and moves to each new one; next_at is
+-- when the next occurrence falls due.
+CREATE TABLE IF NOT EXISTS item_recurrences (
+    item_id TEXT PRIMARY KEY REFERENCES items(id) ON DELETE CASCADE,
+    frequency TEXT NOT NULL CHECK (frequency IN ('daily', 'weekly', 'monthly', 'cron')),
+    cron TEXT NOT NULL DEFAULT '',
+    starts_at TIMESTAMPTZ NOT NULL,
+    next_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+-- Index for finding recurrences that are due
+CREATE INDEX IF NOT EXISTS idx_item_recurrences_next_at ON item_recurrences(next_at);
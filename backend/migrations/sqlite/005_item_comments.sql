@@ -0,0 +1,12 @@
+-- Create item comments table
+CREATE TABLE IF NOT EXISTS item_comments (
+    id TEXT PRIMARY KEY,
+    item_id TEXT NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    author_id TEXT REFERENCES users(id) ON DELETE SET NULL,
+    body TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Index for listing an item's comments in order
+CREATE INDEX IF NOT EXISTS idx_item_comments_item_id ON item_comments(item_id, created_at);
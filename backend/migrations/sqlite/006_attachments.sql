@@ -0,0 +1,15 @@
+-- Create attachments table. The files themselves live in the configured
+-- storage under storage_key.
+CREATE TABLE IF NOT EXISTS attachments (
+    id TEXT PRIMARY KEY,
+    item_id TEXT NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    uploader_id TEXT REFERENCES users(id) ON DELETE SET NULL,
+    filename TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size_bytes INTEGER NOT NULL,
+    storage_key TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Index for listing an item's attachments in order
+CREATE INDEX IF NOT EXISTS idx_attachments_item_id ON attachments(item_id, created_at);
@@ -0,0 +1,16 @@
+-- Add sub-items. Deleting a parent leaves its children as top-level items.
+ALTER TABLE items ADD COLUMN parent_id TEXT REFERENCES items(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_items_parent_id ON items(parent_id);
+
+-- Create item_dependencies table: item_id is blocked by blocker_id
+CREATE TABLE IF NOT EXISTS item_dependencies (
+    item_id TEXT NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    blocker_id TEXT NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (item_id, blocker_id),
+    CHECK (item_id <> blocker_id)
+);
+
+-- Index for finding the items a blocker blocks
+CREATE INDEX IF NOT EXISTS idx_item_dependencies_blocker_id ON item_dependencies(blocker_id);
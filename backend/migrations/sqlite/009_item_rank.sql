@@ -0,0 +1,10 @@
+-- Add a rank for ordering items by hand; see internal/rank for the key format
+ALTER TABLE items ADD COLUMN rank TEXT NOT NULL DEFAULT '';
+
+-- Rank existing items oldest first. The keys are long but valid; the
+-- rebalancing job shortens them.
+UPDATE items SET rank = ranked.rank
+FROM (SELECT id, printf('%08d1', ROW_NUMBER() OVER (ORDER BY created_at, id)) AS rank FROM items) AS ranked
+WHERE items.id = ranked.id;
+
+CREATE INDEX IF NOT EXISTS idx_items_rank ON items(rank);
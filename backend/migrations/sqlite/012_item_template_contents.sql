@@ -0,0 +1,17 @@
+-- Create item_template_tags table: the names of the tags an item made from
+-- the template starts with. Tags are looked up or created by name when the
+-- item is.
+CREATE TABLE IF NOT EXISTS item_template_tags (
+    template_id TEXT NOT NULL REFERENCES item_templates(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    PRIMARY KEY (template_id, name)
+);
+
+-- Create item_template_checklist_entries table: the template's checklist,
+-- in position order.
+CREATE TABLE IF NOT EXISTS item_template_checklist_entries (
+    template_id TEXT NOT NULL REFERENCES item_templates(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    text TEXT NOT NULL,
+    PRIMARY KEY (template_id, position)
+);
@@ -0,0 +1,14 @@
+-- Create item_checklist_entries table: an item's checklist. Positions run
+-- from 0 without gaps and are renumbered when entries move or go.
+CREATE TABLE IF NOT EXISTS item_checklist_entries (
+    id TEXT PRIMARY KEY,
+    item_id TEXT NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    text TEXT NOT NULL,
+    done BOOLEAN NOT NULL DEFAULT FALSE,
+    position INTEGER NOT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+-- Index for listing an item's checklist in order
+CREATE INDEX IF NOT EXISTS idx_item_checklist_entries_item_id ON item_checklist_entries(item_id, position);
@@ -0,0 +1,20 @@
+-- name: CreateAttachment :one
+INSERT INTO attachments (id, item_id, uploader_id, filename, content_type, size_bytes, storage_key, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetAttachment :one
+SELECT * FROM attachments WHERE id = ? AND item_id = ? LIMIT 1;
+
+-- name: ListAttachments :many
+SELECT * FROM attachments WHERE item_id = ? ORDER BY created_at, id;
+
+-- name: ListUserAttachments :many
+SELECT attachments.id, attachments.item_id, attachments.uploader_id, attachments.filename, attachments.content_type, attachments.size_bytes, attachments.storage_key, attachments.created_at
+FROM attachments
+JOIN items ON items.id = attachments.item_id
+WHERE items.user_id = ?
+ORDER BY attachments.created_at, attachments.id;
+
+-- name: DeleteAttachment :exec
+DELETE FROM attachments WHERE id = ?;
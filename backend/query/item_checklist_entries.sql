@@ -0,0 +1,24 @@
+-- name: CreateItemChecklistEntry :one
+INSERT INTO item_checklist_entries (id, item_id, text, done, position, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetItemChecklistEntry :one
+SELECT * FROM item_checklist_entries WHERE id = ? AND item_id = ? LIMIT 1;
+
+-- name: ListItemChecklistEntries :many
+SELECT * FROM item_checklist_entries WHERE item_id = ? ORDER BY position, id;
+
+-- name: UpdateItemChecklistEntry :one
+UPDATE item_checklist_entries
+SET text = ?,
+    done = ?,
+    updated_at = ?
+WHERE id = ?
+RETURNING *;
+
+-- name: SetItemChecklistEntryPosition :exec
+UPDATE item_checklist_entries SET position = ? WHERE id = ?;
+
+-- name: DeleteItemChecklistEntry :exec
+DELETE FROM item_checklist_entries WHERE id = ?;
@@ -0,0 +1,23 @@
+-- name: CreateItemComment :one
+INSERT INTO item_comments (id, item_id, author_id, body, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetItemComment :one
+SELECT * FROM item_comments WHERE id = ? AND item_id = ? LIMIT 1;
+
+-- name: ListItemComments :many
+SELECT * FROM item_comments WHERE item_id = ? ORDER BY created_at, id LIMIT ? OFFSET ?;
+
+-- name: CountItemComments :one
+SELECT COUNT(*) FROM item_comments WHERE item_id = ?;
+
+-- name: UpdateItemComment :one
+UPDATE item_comments
+SET body = ?,
+    updated_at = ?
+WHERE id = ?
+RETURNING *;
+
+-- name: DeleteItemComment :exec
+DELETE FROM item_comments WHERE id = ?;
@@ -0,0 +1,21 @@
+-- name: AddItemDependency :exec
+INSERT INTO item_dependencies (item_id, blocker_id, created_at)
+VALUES (?, ?, CURRENT_TIMESTAMP)
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveItemDependency :exec
+DELETE FROM item_dependencies WHERE item_id = ? AND blocker_id = ?;
+
+-- name: ListItemBlockers :many
+SELECT items.id, items.user_id, items.title, items.description, items.status, items.created_at, items.updated_at, items.due_at, items.priority, items.assignee_id, items.parent_id, items.rank
+FROM items
+JOIN item_dependencies ON item_dependencies.blocker_id = items.id
+WHERE item_dependencies.item_id = ?
+ORDER BY items.created_at, items.id;
+
+-- name: ListBlockedItems :many
+SELECT items.id, items.user_id, items.title, items.description, items.status, items.created_at, items.updated_at, items.due_at, items.priority, items.assignee_id, items.parent_id, items.rank
+FROM items
+JOIN item_dependencies ON item_dependencies.item_id = items.id
+WHERE item_dependencies.blocker_id = ?
+ORDER BY items.created_at, items.id;
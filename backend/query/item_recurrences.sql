@@ -0,0 +1,19 @@
+-- name: SetItemRecurrence :one
+INSERT INTO item_recurrences (item_id, frequency, cron, starts_at, next_at, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (item_id) DO UPDATE
+SET frequency = excluded.frequency,
+    cron = excluded.cron,
+    starts_at = excluded.starts_at,
+    next_at = excluded.next_at,
+    updated_at = excluded.updated_at
+RETURNING *;
+
+-- name: TakeItemRecurrence :one
+DELETE FROM item_recurrences WHERE item_id = ? RETURNING *;
+
+-- name: DeleteItemRecurrence :exec
+DELETE FROM item_recurrences WHERE item_id = ?;
+
+-- name: ListDueItemRecurrences :many
+SELECT * FROM item_recurrences WHERE next_at <= ? ORDER BY next_at, item_id;
@@ -0,0 +1,7 @@
+-- name: CreateItemStatusChange :one
+INSERT INTO item_status_history (id, item_id, from_status, to_status, actor_id, created_at)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: ListItemStatusHistory :many
+SELECT * FROM item_status_history WHERE item_id = ? ORDER BY created_at, id;
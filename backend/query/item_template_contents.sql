@@ -0,0 +1,18 @@
+-- name: AddItemTemplateTag :exec
+INSERT INTO item_template_tags (template_id, name) VALUES (?, ?)
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveItemTemplateTags :exec
+DELETE FROM item_template_tags WHERE template_id = ?;
+
+-- name: ListItemTemplateTags :many
+SELECT * FROM item_template_tags WHERE template_id = ? ORDER BY name;
+
+-- name: AddItemTemplateChecklistEntry :exec
+INSERT INTO item_template_checklist_entries (template_id, position, text) VALUES (?, ?, ?);
+
+-- name: RemoveItemTemplateChecklistEntries :exec
+DELETE FROM item_template_checklist_entries WHERE template_id = ?;
+
+-- name: ListItemTemplateChecklistEntries :many
+SELECT * FROM item_template_checklist_entries WHERE template_id = ? ORDER BY position;
@@ -0,0 +1,32 @@
+-- Generated by cmd/scaffold; -regen rewrites this file. Add custom queries
+-- in another file under query/.
+
+-- name: CreateItemTemplate :one
+INSERT INTO item_templates (id, name, title, description, status, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+RETURNING *;
+
+-- name: GetItemTemplate :one
+SELECT * FROM item_templates WHERE id = ? LIMIT 1;
+
+-- name: GetItemTemplateByName :one
+SELECT * FROM item_templates WHERE name = ? LIMIT 1;
+
+-- name: ListItemTemplates :many
+SELECT * FROM item_templates ORDER BY created_at DESC LIMIT ? OFFSET ?;
+
+-- name: CountItemTemplates :one
+SELECT COUNT(*) FROM item_templates;
+
+-- name: UpdateItemTemplate :one
+UPDATE item_templates
+SET name = COALESCE(?, name),
+    title = COALESCE(?, title),
+    description = COALESCE(?, description),
+    status = COALESCE(?, status),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+RETURNING *;
+
+-- name: DeleteItemTemplate :exec
+DELETE FROM item_templates WHERE id = ?;
@@ -1,6 +1,6 @@
 -- name: CreateItem :one
-INSERT INTO items (id, user_id, title, description, status, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+INSERT INTO items (id, user_id, title, description, status, due_at, priority, assignee_id, parent_id, rank, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
 RETURNING *;
 
 -- name: GetItem :one
@@ -23,9 +23,40 @@ UPDATE items
 SET title = COALESCE(?, title),
     description = COALESCE(?, description),
     status = COALESCE(?, status),
+    due_at = ?,
+    priority = ?,
+    assignee_id = ?,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = ?
 RETURNING *;
 
+-- name: SetItemParent :one
+UPDATE items
+SET parent_id = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+RETURNING *;
+
+-- name: ListChildItems :many
+SELECT * FROM items WHERE parent_id = ? ORDER BY created_at, id;
+
+-- name: CountItemsByRank :one
+SELECT COUNT(*) FROM items WHERE rank = ?;
+
+-- name: GetLastRankedItem :one
+SELECT * FROM items ORDER BY rank DESC LIMIT 1;
+
+-- name: GetNextRankedItem :one
+SELECT * FROM items WHERE rank > ? AND id <> ? ORDER BY rank LIMIT 1;
+
+-- name: GetPreviousRankedItem :one
+SELECT * FROM items WHERE rank < ? AND id <> ? ORDER BY rank DESC LIMIT 1;
+
+-- name: ListItemsByRank :many
+SELECT * FROM items ORDER BY rank, created_at, id;
+
+-- name: SetItemRank :exec
+UPDATE items SET rank = ? WHERE id = ?;
+
 -- name: DeleteItem :exec
 DELETE FROM items WHERE id = ?;
@@ -0,0 +1,43 @@
+-- name: CreateTag :one
+INSERT INTO tags (id, name, created_at, updated_at)
+VALUES (?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+RETURNING *;
+
+-- name: GetTag :one
+SELECT * FROM tags WHERE id = ? LIMIT 1;
+
+-- name: GetTagByName :one
+SELECT * FROM tags WHERE name = ? LIMIT 1;
+
+-- name: ListTags :many
+SELECT * FROM tags ORDER BY name LIMIT ? OFFSET ?;
+
+-- name: CountTags :one
+SELECT COUNT(*) FROM tags;
+
+-- name: UpdateTag :one
+UPDATE tags
+SET name = ?,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?
+RETURNING *;
+
+-- name: DeleteTag :exec
+DELETE FROM tags WHERE id = ?;
+
+-- name: AddItemTag :exec
+INSERT INTO item_tags (item_id, tag_id) VALUES (?, ?)
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveItemTag :exec
+DELETE FROM item_tags WHERE item_id = ? AND tag_id = ?;
+
+-- name: RemoveItemTags :exec
+DELETE FROM item_tags WHERE item_id = ?;
+
+-- name: ListItemTags :many
+SELECT tags.id, tags.name, tags.created_at, tags.updated_at
+FROM tags
+JOIN item_tags ON item_tags.tag_id = tags.id
+WHERE item_tags.item_id = ?
+ORDER BY tags.name;
@@ -25,8 +25,10 @@ const filesToDelete = [
   'backend/internal/handler/user_handler.go',
   'backend/internal/handler/item_handler.go',
   // Migrations
-  'backend/migrations/001_init.sql',
-  'backend/migrations/002_items.sql',
+  'backend/migrations/sqlite/001_init.sql',
+  'backend/migrations/sqlite/002_items.sql',
+  'backend/migrations/postgres/001_init.sql',
+  'backend/migrations/postgres/002_items.sql',
   // SQL queries
   'backend/query/users.sql',
   'backend/query/items.sql',
@@ -67,8 +69,10 @@ const migrationContent = `-- Your schema goes here
 --     created_at DATETIME DEFAULT CURRENT_TIMESTAMP
 -- );
 `;
-writeFileSync(join(ROOT, 'backend/migrations/001_init.sql'), migrationContent);
-console.log('  ✓ Created backend/migrations/001_init.sql (placeholder)');
+for (const dialect of ['sqlite', 'postgres']) {
+  writeFileSync(join(ROOT, `backend/migrations/${dialect}/001_init.sql`), migrationContent);
+  console.log(`  ✓ Created backend/migrations/${dialect}/001_init.sql (placeholder)`);
+}
 
 // Empty query file
 const queryContent = `-- Your sqlc queries go here
@@ -398,7 +402,7 @@ try {
 console.log('\n✨ Done! The template is now clean.\n');
 console.log('Next steps:');
 console.log('  1. Define your schema in backend/api/openapi.yaml');
-console.log('  2. Create migrations in backend/migrations/');
+console.log('  2. Create migrations in backend/migrations/{sqlite,postgres}/');
 console.log('  3. Write SQL queries in backend/query/');
 console.log('  4. Run: cd backend && sqlc generate');
 console.log('  5. Create services and handlers');